@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/mfrlint"
+)
+
+var (
+	fileFlag    = flag.String("f", "", "MFR CSV filepath to lint")
+	sheetIdFlag = flag.String("sheet", "", "Google Sheet ID of the MFR, used when -f is not set")
+	mfrTabFlag  = flag.String("tab", "", "MFR tab in the Google Sheet")
+	tokenFlag   = flag.String("token", "", "OAuth token to read the Google Sheet")
+	jsonFlag    = flag.Bool("json", false, "Print the report as JSON")
+	entityFlag  = flag.String("entities", "", "Entity registry JSON filepath, the embedded one by default")
+)
+
+func main() {
+	flag.Parse()
+	if *fileFlag == "" && *sheetIdFlag == "" {
+		fmt.Println("Usage: ./mfrlint -f <mfr.csv> | -sheet <id> -tab <tab> -token <token>") //nolint:forbidigo
+		os.Exit(2)
+	}
+
+	var mfrFile io.Reader
+	if *fileFlag != "" {
+		f, err := os.Open(*fileFlag)
+		if err != nil {
+			fmt.Printf("Invalid file: %s\n", *fileFlag) //nolint:forbidigo
+			os.Exit(2)
+		}
+		defer f.Close()
+		mfrFile = f
+	}
+
+	masterFeeRates, err := mfr.ProcessMfr(context.Background(), mfrFile, *sheetIdFlag, *mfrTabFlag, *tokenFlag)
+	var parseErrs *databind.ParseErrors
+	if errors.As(err, &parseErrs) {
+		for _, cellErr := range parseErrs.Errors {
+			fmt.Println(cellErr.Error()) //nolint:forbidigo
+		}
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Println(err) //nolint:forbidigo
+		os.Exit(2)
+	}
+
+	registry, err := loadEntities(*entityFlag)
+	if err != nil {
+		fmt.Println(err) //nolint:forbidigo
+		os.Exit(2)
+	}
+
+	report, err := mfrlint.Lint(masterFeeRates, registry)
+	if err != nil {
+		fmt.Println(err) //nolint:forbidigo
+		os.Exit(2)
+	}
+
+	if *jsonFlag {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out)) //nolint:forbidigo
+	} else {
+		for _, issue := range report.Issues {
+			fmt.Println(issue.String()) //nolint:forbidigo
+		}
+		fmt.Printf("%d issues found\n", len(report.Issues)) //nolint:forbidigo
+	}
+
+	if !report.Valid() {
+		os.Exit(1)
+	}
+}
+
+func loadEntities(filePath string) (*entities.Registry, error) {
+	if filePath == "" {
+		return entities.Default()
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return entities.Parse(data)
+}
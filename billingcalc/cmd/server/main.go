@@ -1,13 +1,25 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/julienschmidt/httprouter"
+	"google.golang.org/grpc"
 
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/grpcapi"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/handlers"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/routes"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
 )
 
 func main() {
@@ -16,7 +28,108 @@ func main() {
 		port = "8080"
 		log.Printf("Defaulting to port %s", port)
 	}
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+		log.Printf("Defaulting to gRPC port %s", grpcPort)
+	}
+	if maxFileSize := os.Getenv("MAX_FILE_SIZE_MB"); maxFileSize != "" {
+		size, err := strconv.ParseInt(maxFileSize, 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid MAX_FILE_SIZE_MB: %v", err)
+		}
+		handlers.SetMaxFileSize(size << 20)
+		log.Printf("Accepting files up to %d MB", size)
+	}
+	if jobsDir := os.Getenv("JOBS_DIR"); jobsDir != "" {
+		store, err := jobs.NewFileStore(jobsDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		handlers.SetJobStore(store)
+		log.Printf("Storing jobs in %s", jobsDir)
+	} else if os.Getenv("JOBS_TTL") != "" || os.Getenv("JOBS_MAX") != "" {
+		ttl, maxJobs := jobs.DefaultJobTTL, jobs.DefaultMaxJobs
+		if value := os.Getenv("JOBS_TTL"); value != "" {
+			var err error
+			if ttl, err = time.ParseDuration(value); err != nil {
+				log.Fatalf("Invalid JOBS_TTL: %v", err)
+			}
+		}
+		if value := os.Getenv("JOBS_MAX"); value != "" {
+			var err error
+			if maxJobs, err = strconv.Atoi(value); err != nil {
+				log.Fatalf("Invalid JOBS_MAX: %v", err)
+			}
+		}
+		handlers.SetJobStore(jobs.NewMemoryStore(ttl, maxJobs))
+		log.Printf("Keeping up to %d finished jobs in memory for %s", maxJobs, ttl)
+	}
+	if entitiesFile := os.Getenv("ENTITIES_FILE"); entitiesFile != "" {
+		handlers.SetEntitiesFile(entitiesFile)
+		log.Printf("Reading the entity registry from %s", entitiesFile)
+	}
+	if validatorFeesFile := os.Getenv("VALIDATOR_FEES_FILE"); validatorFeesFile != "" {
+		handlers.SetValidatorFeesFile(validatorFeesFile)
+		log.Printf("Reading the validator fees from %s", validatorFeesFile)
+	}
+	sequencesBucket, sequencesDir := os.Getenv("SEQUENCES_BUCKET"), os.Getenv("SEQUENCES_DIR")
+	if sequencesBucket != "" {
+		handlers.SetSequenceStore(sequence.NewGCSStore(newStorageClient(), sequencesBucket, "sequences/"))
+		log.Printf("Reserving invoice numbers in gs://%s/sequences/", sequencesBucket)
+	} else if sequencesDir != "" {
+		store, err := sequence.NewFileStore(sequencesDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		handlers.SetSequenceStore(store)
+		log.Printf("Reserving invoice numbers in %s", sequencesDir)
+	}
+	// The one-time charges are always recorded, so an onboarding fee is never
+	// billed twice. They are kept with the sequences unless configured apart.
+	chargesBucket, chargesDir := os.Getenv("CHARGES_BUCKET"), os.Getenv("CHARGES_DIR")
+	if chargesBucket == "" && chargesDir == "" {
+		chargesBucket = sequencesBucket
+		if sequencesDir != "" {
+			chargesDir = filepath.Join(sequencesDir, "charges")
+		} else {
+			chargesDir = defaultChargesDir
+		}
+	}
+	if chargesBucket != "" {
+		handlers.SetChargeStore(charges.NewGCSStore(newStorageClient(), chargesBucket, "charges/charges.json"))
+		log.Printf("Recording one-time fees in gs://%s/charges/", chargesBucket)
+	} else {
+		store, err := charges.NewFileStore(chargesDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		handlers.SetChargeStore(store)
+		log.Printf("Recording one-time fees in %s", chargesDir)
+	}
+
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := grpc.NewServer()
+	grpcapi.Register(s)
+	go func() {
+		log.Fatal(s.Serve(lis))
+	}()
+
 	r := httprouter.New()
 	routes.Install(r)
 	log.Fatal(http.ListenAndServe(":"+port, r))
 }
+
+// defaultChargesDir keeps the one-time charges when no store is configured.
+const defaultChargesDir = "charges"
+
+func newStorageClient() *storage.Client {
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to create the storage client: %v", err)
+	}
+	return client
+}
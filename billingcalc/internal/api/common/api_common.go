@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,8 @@ import (
 
 	"github.com/gorilla/schema"
 
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
 )
 
@@ -22,8 +24,32 @@ type DefaultAPIParams struct {
 	FirstExternalId int       `schema:"firstExternalId,required"`
 	InvoiceDate     time.Time `schema:"invoiceDate,required"`
 	Debug           bool      `schema:"debug,required"`
+	Async           bool      `schema:"async"`
+	// InvoiceGrouping is "account" (default) or "msa_entity", see
+	// fees.InvoiceGrouping.
+	InvoiceGrouping fees.InvoiceGrouping `schema:"invoiceGrouping"`
+	// RunId names the run the invoice identifiers are reserved for, when
+	// sequences are configured. Calculating the same run again reuses them.
+	RunId string `schema:"runId"`
+	// Explain attaches to every line item how its amount was calculated, as
+	// JSON in the response ("json") or as a plain text report ("text").
+	Explain string `schema:"explain"`
+	// Calculators names the fee calculators to run, given as repeated or
+	// comma separated values. Every registered calculator runs when empty.
+	Calculators []string `schema:"calculators"`
+	// MinimumFeeLevel is "msa" (default), "account" or "asset_type", see
+	// fees.MinimumFeeLevel.
+	MinimumFeeLevel fees.MinimumFeeLevel `schema:"minimumFeeLevel"`
+	// StakedBalanceFill is "previous" (default), "zero" or "skip", see
+	// fees.StakedBalanceFill.
+	StakedBalanceFill fees.StakedBalanceFill `schema:"stakedBalanceFill"`
 }
 
+const (
+	ExplainJSON = "json"
+	ExplainText = "text"
+)
+
 type Response struct {
 	Data  interface{} `json:"data"`
 	Warn  interface{} `json:"warn"`
@@ -34,7 +60,7 @@ type Response struct {
 func (r *Response) Write(w http.ResponseWriter) {
 	m, err := json.Marshal(r)
 	if err != nil {
-		WriteErr(w, errors.New("Error while encoding response."))
+		WriteErr(context.Background(), w, errors.New("Error while encoding response."))
 		return
 	}
 
@@ -43,11 +69,11 @@ func (r *Response) Write(w http.ResponseWriter) {
 	debug.NewMessage(fmt.Sprintf("http response wrote: %s", string(m)))
 }
 
-func WriteErr(w http.ResponseWriter, err error) {
+func WriteErr(ctx context.Context, w http.ResponseWriter, err error) {
 	resp := &Response{
 		Data:  "",
 		Warn:  "",
-		Debug: debug.GetAllMessages(),
+		Debug: debug.GetAllMessages(ctx),
 		Err:   err.Error(),
 	}
 	resp.Write(w)
@@ -59,7 +85,12 @@ func SetValuesFromForm(s interface{}, formData map[string][]string) error {
 		return reflect.ValueOf([]byte(s))
 	})
 	decoder.RegisterConverter(time.Time{}, func(s string) reflect.Value {
-		return reflect.ValueOf(converter.FromStrToTimeStamp(s))
+		t, err := date.Parse(s, date.MonthFirst)
+		if err != nil {
+			// An invalid value makes the decoder report the field
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(t)
 	})
 
 	err := decoder.Decode(s, formData)
@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: billingcalc.proto
+
+package billingcalcpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BillingCalc_CalculateFromCsv_FullMethodName      = "/billingcalc.v1.BillingCalc/CalculateFromCsv"
+	BillingCalc_CalculateFromGSheets_FullMethodName  = "/billingcalc.v1.BillingCalc/CalculateFromGSheets"
+	BillingCalc_CalculateFromBigQuery_FullMethodName = "/billingcalc.v1.BillingCalc/CalculateFromBigQuery"
+	BillingCalc_GetAssets_FullMethodName             = "/billingcalc.v1.BillingCalc/GetAssets"
+	BillingCalc_UpdateAssets_FullMethodName          = "/billingcalc.v1.BillingCalc/UpdateAssets"
+)
+
+// BillingCalcClient is the client API for BillingCalc service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BillingCalcClient interface {
+	CalculateFromCsv(ctx context.Context, in *CalculateFromCsvRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	CalculateFromGSheets(ctx context.Context, in *CalculateFromGSheetsRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	CalculateFromBigQuery(ctx context.Context, in *CalculateFromBigQueryRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	GetAssets(ctx context.Context, in *GetAssetsRequest, opts ...grpc.CallOption) (*AssetsResponse, error)
+	UpdateAssets(ctx context.Context, in *UpdateAssetsRequest, opts ...grpc.CallOption) (*AssetsResponse, error)
+}
+
+type billingCalcClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBillingCalcClient(cc grpc.ClientConnInterface) BillingCalcClient {
+	return &billingCalcClient{cc}
+}
+
+func (c *billingCalcClient) CalculateFromCsv(ctx context.Context, in *CalculateFromCsvRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, BillingCalc_CalculateFromCsv_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingCalcClient) CalculateFromGSheets(ctx context.Context, in *CalculateFromGSheetsRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, BillingCalc_CalculateFromGSheets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingCalcClient) CalculateFromBigQuery(ctx context.Context, in *CalculateFromBigQueryRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, BillingCalc_CalculateFromBigQuery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingCalcClient) GetAssets(ctx context.Context, in *GetAssetsRequest, opts ...grpc.CallOption) (*AssetsResponse, error) {
+	out := new(AssetsResponse)
+	err := c.cc.Invoke(ctx, BillingCalc_GetAssets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingCalcClient) UpdateAssets(ctx context.Context, in *UpdateAssetsRequest, opts ...grpc.CallOption) (*AssetsResponse, error) {
+	out := new(AssetsResponse)
+	err := c.cc.Invoke(ctx, BillingCalc_UpdateAssets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingCalcServer is the server API for BillingCalc service.
+// All implementations must embed UnimplementedBillingCalcServer
+// for forward compatibility
+type BillingCalcServer interface {
+	CalculateFromCsv(context.Context, *CalculateFromCsvRequest) (*CalculateResponse, error)
+	CalculateFromGSheets(context.Context, *CalculateFromGSheetsRequest) (*CalculateResponse, error)
+	CalculateFromBigQuery(context.Context, *CalculateFromBigQueryRequest) (*CalculateResponse, error)
+	GetAssets(context.Context, *GetAssetsRequest) (*AssetsResponse, error)
+	UpdateAssets(context.Context, *UpdateAssetsRequest) (*AssetsResponse, error)
+	mustEmbedUnimplementedBillingCalcServer()
+}
+
+// UnimplementedBillingCalcServer must be embedded to have forward compatible implementations.
+type UnimplementedBillingCalcServer struct {
+}
+
+func (UnimplementedBillingCalcServer) CalculateFromCsv(context.Context, *CalculateFromCsvRequest) (*CalculateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateFromCsv not implemented")
+}
+func (UnimplementedBillingCalcServer) CalculateFromGSheets(context.Context, *CalculateFromGSheetsRequest) (*CalculateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateFromGSheets not implemented")
+}
+func (UnimplementedBillingCalcServer) CalculateFromBigQuery(context.Context, *CalculateFromBigQueryRequest) (*CalculateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateFromBigQuery not implemented")
+}
+func (UnimplementedBillingCalcServer) GetAssets(context.Context, *GetAssetsRequest) (*AssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssets not implemented")
+}
+func (UnimplementedBillingCalcServer) UpdateAssets(context.Context, *UpdateAssetsRequest) (*AssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAssets not implemented")
+}
+func (UnimplementedBillingCalcServer) mustEmbedUnimplementedBillingCalcServer() {}
+
+// UnsafeBillingCalcServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BillingCalcServer will
+// result in compilation errors.
+type UnsafeBillingCalcServer interface {
+	mustEmbedUnimplementedBillingCalcServer()
+}
+
+func RegisterBillingCalcServer(s grpc.ServiceRegistrar, srv BillingCalcServer) {
+	s.RegisterService(&BillingCalc_ServiceDesc, srv)
+}
+
+func _BillingCalc_CalculateFromCsv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateFromCsvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingCalcServer).CalculateFromCsv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingCalc_CalculateFromCsv_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingCalcServer).CalculateFromCsv(ctx, req.(*CalculateFromCsvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingCalc_CalculateFromGSheets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateFromGSheetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingCalcServer).CalculateFromGSheets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingCalc_CalculateFromGSheets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingCalcServer).CalculateFromGSheets(ctx, req.(*CalculateFromGSheetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingCalc_CalculateFromBigQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateFromBigQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingCalcServer).CalculateFromBigQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingCalc_CalculateFromBigQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingCalcServer).CalculateFromBigQuery(ctx, req.(*CalculateFromBigQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingCalc_GetAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingCalcServer).GetAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingCalc_GetAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingCalcServer).GetAssets(ctx, req.(*GetAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingCalc_UpdateAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingCalcServer).UpdateAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingCalc_UpdateAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingCalcServer).UpdateAssets(ctx, req.(*UpdateAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BillingCalc_ServiceDesc is the grpc.ServiceDesc for BillingCalc service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BillingCalc_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "billingcalc.v1.BillingCalc",
+	HandlerType: (*BillingCalcServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CalculateFromCsv",
+			Handler:    _BillingCalc_CalculateFromCsv_Handler,
+		},
+		{
+			MethodName: "CalculateFromGSheets",
+			Handler:    _BillingCalc_CalculateFromGSheets_Handler,
+		},
+		{
+			MethodName: "CalculateFromBigQuery",
+			Handler:    _BillingCalc_CalculateFromBigQuery_Handler,
+		},
+		{
+			MethodName: "GetAssets",
+			Handler:    _BillingCalc_GetAssets_Handler,
+		},
+		{
+			MethodName: "UpdateAssets",
+			Handler:    _BillingCalc_UpdateAssets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "billingcalc.proto",
+}
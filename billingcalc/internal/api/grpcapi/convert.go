@@ -0,0 +1,181 @@
+package grpcapi
+
+import (
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/grpcapi/billingcalcpb"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/handlers"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+func toCalculateResponse(result *fees.CalculatedFees, trace *debug.Trace) *billingcalcpb.CalculateResponse {
+	resp := &billingcalcpb.CalculateResponse{
+		Summary: toStakingSummary(result.Summary),
+		RunId:   result.RunID,
+	}
+
+	for _, warn := range result.Warns {
+		resp.Warnings = append(resp.Warnings, toWarning(warn))
+	}
+
+	for _, message := range trace.GetAllMessages() {
+		resp.Debug = append(resp.Debug, &billingcalcpb.DebugMessage{
+			Message: message.Message,
+			Time:    message.Time,
+		})
+	}
+
+	return resp
+}
+
+func toStakingSummary(summary fees.StakingSummary) *billingcalcpb.StakingSummary {
+	pbSummary := &billingcalcpb.StakingSummary{}
+
+	for _, org := range summary {
+		pbOrg := &billingcalcpb.OrgResult{OrgName: org.OrgName, MsaId: org.MsaID}
+		for _, acc := range org.Accounts {
+			pbOrg.Accounts = append(pbOrg.Accounts, toAccountResult(acc))
+		}
+		pbSummary.Organizations = append(pbSummary.Organizations, pbOrg)
+	}
+
+	return pbSummary
+}
+
+func toAccountResult(acc fees.AccountResult) *billingcalcpb.AccountResult {
+	pbAcc := &billingcalcpb.AccountResult{
+		AccountId:     acc.AccountID,
+		ClientName:    acc.AccName,
+		BillingTerms:  acc.BillingTerms,
+		CustomerId:    acc.CustomerID,
+		DisplayName:   acc.DisplayName,
+		EntityId:      acc.EntityID,
+		InvoiceNumber: acc.InvoiceNumber,
+		ExternalId:    acc.ExternalID,
+		InvoiceDate:   acc.InvoiceDate,
+		DueDate:       acc.DueDate,
+	}
+
+	for _, out := range acc.Assets {
+		pbAcc.Assets = append(pbAcc.Assets, toStakingOutput(out))
+	}
+
+	return pbAcc
+}
+
+func toStakingOutput(out fees.StakingOutput) *billingcalcpb.StakingOutput {
+	return &billingcalcpb.StakingOutput{
+		ServiceType:             out.ServiceType,
+		Asset:                   out.Asset,
+		Amount:                  out.Amount.String(),
+		CollectedOnChainAlready: out.CollectedOnChainAlready,
+		EarnedRewards:           out.EarnedRewards.String(),
+		FeeRates:                out.FeeRates.String(),
+		ItemCategory:            out.ItemCategory,
+		ItemDescription:         out.ItemDescription,
+		ItemQuantity:            out.ItemQuantity,
+		Memo:                    out.Memo,
+		MonthlyRate:             out.MonthlyRate,
+		Explanation:             toExplanation(out.Explanation),
+		FeeModel:                out.FeeModel,
+		Validator:               out.Validator,
+	}
+}
+
+func toExplanation(ex *explain.Explanation) *billingcalcpb.Explanation {
+	if ex == nil {
+		return nil
+	}
+
+	pbEx := &billingcalcpb.Explanation{Method: ex.Method}
+	for _, input := range ex.Inputs {
+		pbEx.Inputs = append(pbEx.Inputs, &billingcalcpb.ExplanationValue{Name: input.Name, Value: input.Value})
+	}
+	for _, r := range ex.Rewards {
+		pbEx.Rewards = append(pbEx.Rewards, &billingcalcpb.RewardRow{
+			BusinessDay:   r.BusinessDay.Format(explanationDate),
+			OperationType: r.OperationType,
+			AssetQty:      r.AssetQty.String(),
+			UsdValue:      r.UsdValue.String(),
+		})
+	}
+	for _, r := range ex.DailyBalances {
+		pbEx.DailyBalances = append(pbEx.DailyBalances, &billingcalcpb.BalanceRow{
+			Date:       r.Date.Format(explanationDate),
+			Balance:    r.Balance.String(),
+			Diff:       r.Diff.String(),
+			ClaimedQty: r.ClaimedQty.String(),
+			UsdPrice:   r.UsdPrice.String(),
+			UsdValue:   r.UsdValue.String(),
+			Counted:    r.Counted,
+		})
+	}
+	for _, r := range ex.StakedBalances {
+		pbEx.StakedBalances = append(pbEx.StakedBalances, &billingcalcpb.StakedBalanceRow{
+			Date:    r.Date.Format(explanationDate),
+			Value:   r.Value.String(),
+			Fill:    r.Fill,
+			Counted: r.Counted,
+		})
+	}
+	for _, t := range ex.Tiers {
+		pbEx.Tiers = append(pbEx.Tiers, &billingcalcpb.TierRow{
+			Floor:       t.Floor.String(),
+			Rate:        t.Rate.String(),
+			MonthlyRate: t.MonthlyRate.String(),
+			Balance:     t.Balance.String(),
+			Amount:      t.Amount.String(),
+		})
+	}
+	for _, step := range ex.Steps {
+		pbEx.Steps = append(pbEx.Steps, &billingcalcpb.ExplanationStep{
+			Name:     step.Name,
+			Formula:  step.Formula,
+			Value:    step.Value.String(),
+			Rounding: step.Rounding,
+		})
+	}
+
+	return pbEx
+}
+
+const explanationDate = "2006-01-02"
+
+func toWarning(warn fees.Warning) *billingcalcpb.Warning {
+	return &billingcalcpb.Warning{
+		OrgName:     warn.OrgName,
+		AccName:     warn.AccName,
+		Asset:       warn.Asset,
+		Description: warn.Description,
+	}
+}
+
+func toAssets(assets []handlers.Asset) []*billingcalcpb.Asset {
+	pbAssets := make([]*billingcalcpb.Asset, 0, len(assets))
+	for _, asset := range assets {
+		pbAssets = append(pbAssets, &billingcalcpb.Asset{
+			Asset:      asset.Asset,
+			Validator:  asset.Validator,
+			Operations: asset.Operations,
+			OnChain:    asset.OnChain,
+			Claimable:  asset.Claimable,
+			Active:     asset.Active,
+		})
+	}
+	return pbAssets
+}
+
+func fromAssets(pbAssets []*billingcalcpb.Asset) []handlers.Asset {
+	assets := make([]handlers.Asset, 0, len(pbAssets))
+	for _, asset := range pbAssets {
+		assets = append(assets, handlers.Asset{
+			Asset:      asset.GetAsset(),
+			Validator:  asset.GetValidator(),
+			Operations: asset.GetOperations(),
+			OnChain:    asset.GetOnChain(),
+			Claimable:  asset.GetClaimable(),
+			Active:     asset.GetActive(),
+		})
+	}
+	return assets
+}
@@ -0,0 +1,195 @@
+// package grpcapi serves the billingcalc gRPC contract. It only translates the
+// messages, the work is done by the same handler layer as the HTTP routes.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/grpcapi/billingcalcpb"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/handlers"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/file"
+)
+
+type Server struct {
+	billingcalcpb.UnimplementedBillingCalcServer
+}
+
+func Register(s *grpc.Server) {
+	billingcalcpb.RegisterBillingCalcServer(s, &Server{})
+}
+
+func (s *Server) CalculateFromCsv(ctx context.Context, req *billingcalcpb.CalculateFromCsvRequest) (*billingcalcpb.CalculateResponse, error) {
+	params, err := defaultParams(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	reports := fees.CsvReports{}
+	files := []struct {
+		name string
+		data []byte
+		rows *[][]string
+	}{
+		{"mfr", req.GetMfr(), &reports.Mfr},
+		{"rewards", req.GetRewards(), &reports.Rewards},
+		{"unclaimed", req.GetUnclaimed(), &reports.UnclaimedBalances},
+		{"balance_adjustments", req.GetBalanceAdjustments(), &reports.BalanceAdjustments},
+		{"operations_statuses", req.GetOperationsStatuses(), &reports.OperationsStatuses},
+		{"daily_balances", req.GetDailyBalances(), &reports.DailyBalances},
+		{"trades", req.GetTrades(), &reports.Trades},
+	}
+	for _, f := range files {
+		if *f.rows, err = readCsv(f.name, f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return calculate(ctx, params, func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return handlers.CalculateFromCsv(ctx, params, reports)
+	})
+}
+
+func (s *Server) CalculateFromGSheets(ctx context.Context, req *billingcalcpb.CalculateFromGSheetsRequest) (*billingcalcpb.CalculateResponse, error) {
+	params, err := defaultParams(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	gsheetParams := &handlers.GSheetAPIParams{
+		DefaultAPIParams:      params,
+		MfrTab:                req.GetMfrTab(),
+		RewardsTab:            req.GetRewardsTab(),
+		UnclaimedBalancesTab:  req.GetUnclaimedBalancesTab(),
+		BalanceAdjustmentsTab: req.GetBalanceAdjustmentsTab(),
+		OperationsStatusesTab: req.GetOperationsStatusesTab(),
+		DailyBalancesTab:      req.GetDailyBalancesTab(),
+		TradesTab:             req.GetTradesTab(),
+		SheetId:               req.GetSheetId(),
+		Token:                 req.GetToken(),
+	}
+
+	return calculate(ctx, params, func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return handlers.CalculateFromGSheets(ctx, gsheetParams)
+	})
+}
+
+func (s *Server) CalculateFromBigQuery(ctx context.Context, req *billingcalcpb.CalculateFromBigQueryRequest) (*billingcalcpb.CalculateResponse, error) {
+	params, err := defaultParams(req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	bqParams := &handlers.BigQueryAPIParams{
+		DefaultAPIParams: params,
+		MfrTab:           req.GetMfrTab(),
+		SheetId:          req.GetSheetId(),
+		Token:            req.GetToken(),
+		PeriodBegin:      req.GetPeriodBegin().AsTime(),
+		PeriodEnd:        req.GetPeriodEnd().AsTime(),
+	}
+	mfr, err := readCsv("mfr", req.GetMfr())
+	if err != nil {
+		return nil, err
+	}
+
+	return calculate(ctx, params, func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return handlers.CalculateFromBigQuery(ctx, bqParams, mfr)
+	})
+}
+
+func (s *Server) GetAssets(ctx context.Context, req *billingcalcpb.GetAssetsRequest) (*billingcalcpb.AssetsResponse, error) {
+	if req.GetFilePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_path is required")
+	}
+
+	data, err := handlers.ReadAssetsFile(ctx, req.GetFilePath())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	assets := handlers.UpdateRequestBody{}
+	if err := json.Unmarshal(data, &assets); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid assets file: %v", err)
+	}
+
+	return &billingcalcpb.AssetsResponse{Assets: toAssets(assets)}, nil
+}
+
+func (s *Server) UpdateAssets(ctx context.Context, req *billingcalcpb.UpdateAssetsRequest) (*billingcalcpb.AssetsResponse, error) {
+	if req.GetFilePath() == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_path is required")
+	}
+
+	data, err := json.Marshal(fromAssets(req.GetAssets()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := handlers.WriteAssetsFile(ctx, req.GetFilePath(), data); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &billingcalcpb.AssetsResponse{Assets: req.GetAssets()}, nil
+}
+
+// calculate runs the calculation with its own debug trace and converts the
+// result. Unlike HTTP, the calculation error is returned in the status, since
+// the debug messages can't be sent along with an error.
+func calculate(ctx context.Context, params common.DefaultAPIParams, calculation func(ctx context.Context) (*fees.CalculatedFees, error)) (*billingcalcpb.CalculateResponse, error) {
+	trace := debug.NewTrace(params.Debug)
+	ctx = debug.NewContext(ctx, trace)
+
+	result, err := calculation(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to calculate fees: %v", err)
+	}
+
+	return toCalculateResponse(result, trace), nil
+}
+
+func defaultParams(options *billingcalcpb.CalculationOptions) (common.DefaultAPIParams, error) {
+	if options.GetInvoiceDate() == nil {
+		return common.DefaultAPIParams{}, status.Error(codes.InvalidArgument, "options.invoice_date is required")
+	}
+
+	params := common.DefaultAPIParams{
+		FirstExternalId:   int(options.GetFirstExternalId()),
+		InvoiceDate:       options.GetInvoiceDate().AsTime(),
+		Debug:             options.GetDebug(),
+		InvoiceGrouping:   fees.InvoiceGrouping(options.GetInvoiceGrouping()),
+		RunId:             options.GetRunId(),
+		Calculators:       options.GetCalculators(),
+		MinimumFeeLevel:   fees.MinimumFeeLevel(options.GetMinimumFeeLevel()),
+		StakedBalanceFill: fees.StakedBalanceFill(options.GetStakedBalanceFill()),
+	}
+	if options.GetExplain() {
+		params.Explain = common.ExplainJSON
+	}
+	if err := handlers.ValidateParams(params); err != nil {
+		return common.DefaultAPIParams{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return params, nil
+}
+
+// readCsv reads an uploaded report, a missing report is nil.
+func readCsv(name string, data []byte) ([][]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	rows, err := file.ReadCsv(bytes.NewReader(data))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Error while reading %s file: %v", name, err)
+	}
+
+	return rows, nil
+}
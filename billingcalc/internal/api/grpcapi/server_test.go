@@ -0,0 +1,345 @@
+//go:build !selectTest || unitTest
+
+package grpcapi_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/grpcapi"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/grpcapi/billingcalcpb"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static/statictest"
+)
+
+const dailyBalancesCsv = `MSA ID,Org Name,Account Name,Anchorage Entity,Org ID,Asset Type,Account Internal ID,Total Quantity,Unit Price USD,Total USD Value,Total Quantity From Addresses,Unclaimed rewards USD,Total AUC in USD,Graduated tier?
+22222,Org Test Beta,Test Beta Account,TRUST_COMPANY,orgId,SOL,accountIdFor2222,3000000,10,30000000,0,0,30000000,No
+`
+
+func TestServerValidation(t *testing.T) {
+	ctx := context.Background()
+	s := &grpcapi.Server{}
+
+	t.Run("Missing invoice date", func(t *testing.T) {
+		_, err := s.CalculateFromCsv(ctx, &billingcalcpb.CalculateFromCsvRequest{
+			Options: &billingcalcpb.CalculationOptions{FirstExternalId: 1},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid invoice grouping", func(t *testing.T) {
+		_, err := s.CalculateFromCsv(ctx, &billingcalcpb.CalculateFromCsvRequest{
+			Options: &billingcalcpb.CalculationOptions{InvoiceDate: timestamppb.Now(), InvoiceGrouping: "customer"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Unknown calculator", func(t *testing.T) {
+		_, err := s.CalculateFromCsv(ctx, &billingcalcpb.CalculateFromCsvRequest{
+			Options: &billingcalcpb.CalculationOptions{InvoiceDate: timestamppb.Now(), Calculators: []string{"custody", "unknown"}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid minimum fee level", func(t *testing.T) {
+		_, err := s.CalculateFromCsv(ctx, &billingcalcpb.CalculateFromCsvRequest{
+			Options: &billingcalcpb.CalculationOptions{InvoiceDate: timestamppb.Now(), MinimumFeeLevel: "customer"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid staked balance fill", func(t *testing.T) {
+		_, err := s.CalculateFromCsv(ctx, &billingcalcpb.CalculateFromCsvRequest{
+			Options: &billingcalcpb.CalculationOptions{InvoiceDate: timestamppb.Now(), StakedBalanceFill: "interpolate"},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Missing options", func(t *testing.T) {
+		_, err := s.CalculateFromGSheets(ctx, &billingcalcpb.CalculateFromGSheetsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Missing assets file path", func(t *testing.T) {
+		_, err := s.GetAssets(ctx, &billingcalcpb.GetAssetsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = s.UpdateAssets(ctx, &billingcalcpb.UpdateAssetsRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func csvRequest(t *testing.T) *billingcalcpb.CalculateFromCsvRequest {
+	mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfr, err := io.ReadAll(mfrFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &billingcalcpb.CalculateFromCsvRequest{
+		Options: &billingcalcpb.CalculationOptions{
+			FirstExternalId: 1,
+			InvoiceDate:     timestamppb.New(time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)),
+		},
+		Mfr:           mfr,
+		DailyBalances: []byte(dailyBalancesCsv),
+	}
+}
+
+func TestCalculateFromCsv(t *testing.T) {
+	resp, err := newClient(t).CalculateFromCsv(context.Background(), csvRequest(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The whole response is compared, so a change to the contract or to the
+	// conversion shows up here.
+	expected := &billingcalcpb.CalculateResponse{
+		Summary: &billingcalcpb.StakingSummary{
+			Organizations: []*billingcalcpb.OrgResult{
+				{
+					OrgName: "OrgTestAlpha",
+					MsaId:   "11111",
+					Accounts: []*billingcalcpb.AccountResult{
+						{
+							AccountId:     "2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22",
+							ClientName:    "TestAlphaAccount",
+							BillingTerms:  "15",
+							CustomerId:    "1111",
+							DisplayName:   "Test Alpha Account",
+							EntityId:      "33",
+							InvoiceNumber: "ABS-1",
+							ExternalId:    "1",
+							InvoiceDate:   "06/30/2023",
+							DueDate:       "07/15/2023",
+						},
+					},
+				},
+				{
+					OrgName: "OrgTestBeta",
+					MsaId:   "22222",
+					Accounts: []*billingcalcpb.AccountResult{
+						{
+							AccountId:     "2d0220881d90d35fc8dc8a0bb44d4b36af141b5f0a40603616afdf6225893702",
+							ClientName:    "TestBetaAccount",
+							BillingTerms:  "30",
+							CustomerId:    "6400",
+							DisplayName:   "Test Beta Account",
+							EntityId:      "15",
+							InvoiceNumber: "ADB-2",
+							ExternalId:    "2",
+							InvoiceDate:   "06/30/2023",
+							DueDate:       "07/30/2023",
+						},
+						{
+							AccountId:     "accountIdFor2222",
+							ClientName:    "TestBetaAccount",
+							BillingTerms:  "30",
+							CustomerId:    "6300",
+							DisplayName:   "Test Beta Account",
+							EntityId:      "15",
+							InvoiceNumber: "ADB-3",
+							ExternalId:    "3",
+							InvoiceDate:   "06/30/2023",
+							DueDate:       "07/30/2023",
+							Assets: []*billingcalcpb.StakingOutput{
+								{
+									ServiceType:   "Custody Fee",
+									Asset:         "SOL",
+									Amount:        "14166.67",
+									EarnedRewards: "1000000",
+									FeeRates:      "14166.67",
+									ItemCategory:  "Custody Fee by Asset",
+									MonthlyRate:   "1180.56",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Warnings: []*billingcalcpb.Warning{
+			{
+				OrgName:     "Org Test Beta",
+				AccName:     "Test Beta Account",
+				Description: "MFR row 8: Terminated account without a Termination Date, its last month is not billed",
+			},
+		},
+	}
+	assert.Truef(t, proto.Equal(expected, resp), "Unexpected response:\n%s", protojson.Format(resp))
+}
+
+func TestCalculateFromCsvBrokerage(t *testing.T) {
+	req := csvRequest(t)
+	req.Options.Calculators = []string{"brokerage"}
+	req.Trades = []byte(`Trade ID,Trade Date,RDB Account ID,Asset,Side,Quantity,Notional USD,Fee Rate
+T-1,06/05/2023,accountIdFor2222,BTC,Buy,1,30000,0.25%
+`)
+
+	resp, err := newClient(t).CalculateFromCsv(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orgs := resp.GetSummary().GetOrganizations()
+	if assert.Len(t, orgs, 1) && assert.Len(t, orgs[0].GetAccounts(), 1) {
+		acc := orgs[0].GetAccounts()[0]
+		assert.Equal(t, "accountIdFor2222", acc.GetAccountId())
+		if assert.Len(t, acc.GetAssets(), 1) {
+			assert.Equal(t, "Brokerage Fee", acc.GetAssets()[0].GetServiceType())
+			assert.Equal(t, "75", acc.GetAssets()[0].GetAmount())
+		}
+	}
+}
+
+// alphaAccountID is the RDB Account ID of Test Alpha Account in the sample
+// MFR.
+const alphaAccountID = "2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22"
+
+// stakingRequest bills the June staking of Test Alpha Account from the sample
+// reports, the rewards given the account ID they are looked up by.
+func stakingRequest(t *testing.T, editStatuses func(rows [][]string)) *billingcalcpb.CalculateFromCsvRequest {
+	const rewardsHeaderRow = 7
+
+	req := csvRequest(t)
+	req.Options.Calculators = []string{"staking"}
+	req.Rewards = statictest.EditedCsv(t, "gsheet/rewards_test_calc.csv", func(rows [][]string) {
+		for i, row := range rows {
+			id := ""
+			switch {
+			case i == rewardsHeaderRow:
+				id = "Operations Account Internal ID"
+			case i > rewardsHeaderRow && row[1] == "Test Alpha Account":
+				id = alphaAccountID
+			}
+			rows[i] = append(row, id)
+		}
+	})
+	req.Unclaimed = statictest.EditedCsv(t, "gsheet/unclaimed_test_calc.csv", nil)
+	req.OperationsStatuses = statictest.EditedCsv(t, "gsheet/operations_statuses_test_calc.csv", editStatuses)
+	return req
+}
+
+// hashLine is the HASH staking line of Test Alpha Account.
+func hashLine(t *testing.T, resp *billingcalcpb.CalculateResponse) *billingcalcpb.StakingOutput {
+	for _, org := range resp.GetSummary().GetOrganizations() {
+		for _, acc := range org.GetAccounts() {
+			for _, line := range acc.GetAssets() {
+				if acc.GetAccountId() == alphaAccountID && line.GetAsset() == "HASH" {
+					return line
+				}
+			}
+		}
+	}
+	t.Fatalf("No HASH staking line for Test Alpha Account:\n%s", protojson.Format(resp))
+	return nil
+}
+
+func TestCalculateFromCsvStaking(t *testing.T) {
+	resp, err := newClient(t).CalculateFromCsv(context.Background(), stakingRequest(t, func(rows [][]string) {}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := hashLine(t, resp)
+	assert.Equal(t, "Staking Fee", line.GetServiceType())
+	// The validators of the sample statuses keep all the rewards.
+	assert.Equal(t, "staked_balance", line.GetFeeModel())
+
+	// The sample statuses miss days, counting them as nothing staked lowers
+	// the staked balance billed.
+	req := stakingRequest(t, func(rows [][]string) {})
+	req.Options.StakedBalanceFill = "zero"
+	zeroFilled, err := newClient(t).CalculateFromCsv(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, line.GetAmount(), hashLine(t, zeroFilled).GetAmount())
+}
+
+func TestCalculateFromCsvStakingValidator(t *testing.T) {
+	const (
+		colAsset       = 5
+		colAddress     = 18
+		colValidatorID = 19
+	)
+	req := stakingRequest(t, func(rows [][]string) {
+		for _, row := range rows[1:] {
+			if row[colAsset] == "HASH" {
+				row[colValidatorID], row[colAddress] = "val-grpc", "hashvaloper1grpc"
+			}
+		}
+	})
+
+	resp, err := newClient(t).CalculateFromCsv(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := hashLine(t, resp)
+	assert.Equal(t, "val-grpc", line.GetValidator())
+	assert.Equal(t, "Validator val-grpc", line.GetItemDescription())
+}
+
+func TestCalculateFromCsvExplained(t *testing.T) {
+	req := csvRequest(t)
+	req.Options.Explain = true
+
+	resp, err := newClient(t).CalculateFromCsv(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := resp.GetSummary().GetOrganizations()[1].GetAccounts()[1].GetAssets()[0]
+	explanation := line.GetExplanation()
+	if assert.NotNil(t, explanation) {
+		assert.NotEmpty(t, explanation.GetMethod())
+		assert.NotEmpty(t, explanation.GetTiers())
+		values := []string{}
+		for _, step := range explanation.GetSteps() {
+			values = append(values, step.GetValue())
+		}
+		assert.Contains(t, values, line.GetAmount())
+	}
+}
+
+// newClient serves the billingcalc service in memory, so the messages go
+// through the same encoding as a real call.
+func newClient(t *testing.T) billingcalcpb.BillingCalcClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	grpcapi.Register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return billingcalcpb.NewBillingCalcClient(conn)
+}
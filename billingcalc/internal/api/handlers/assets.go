@@ -34,19 +34,41 @@ func initClient() {
 	}
 }
 
-func GetAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+// configBucketName holds the managed configuration files, assets and entities.
+const configBucketName = "billingcalc-data"
+
+// ReadAssetsFile returns the asset configuration stored at filePath.
+func ReadAssetsFile(ctx context.Context, filePath string) ([]byte, error) {
+	if filePath == "" {
+		return nil, errors.New("filePath parameter is required")
+	}
+
 	initClient()
+	return getDataFromBucket(ctx, configBucketName, filePath)
+}
 
-	bucketName := "billingcalc-data"
-	queryValues := r.URL.Query()
-	filePath := queryValues.Get("filePath")
+// WriteAssetsFile replaces the asset configuration stored at filePath, data
+// must be a JSON list of assets.
+func WriteAssetsFile(ctx context.Context, filePath string, data []byte) error {
+	if filePath == "" {
+		return errors.New("filePath parameter is required")
+	}
+	if !validBody(data) {
+		return errors.New("Invalid body request")
+	}
 
+	initClient()
+	return sendDataToBucket(ctx, configBucketName, filePath, data)
+}
+
+func GetAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filePath := r.URL.Query().Get("filePath")
 	if filePath == "" {
 		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
 		return
 	}
 
-	data, err := getDataFromBucket(bucketName, filePath)
+	data, err := ReadAssetsFile(r.Context(), filePath)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -59,12 +81,7 @@ func GetAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 }
 
 func UpdateAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	initClient()
-
-	bucketName := "billingcalc-data"
-	queryValues := r.URL.Query()
-	filePath := queryValues.Get("filePath")
-
+	filePath := r.URL.Query().Get("filePath")
 	if filePath == "" {
 		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
 		return
@@ -81,12 +98,7 @@ func UpdateAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		}
 	}()
 
-	if !validBody(modifiedData) {
-		http.Error(w, "Invalid body request", http.StatusInternalServerError)
-		return
-	}
-
-	if err := sendDataToBucket(bucketName, filePath, modifiedData); err != nil {
+	if err := WriteAssetsFile(r.Context(), filePath, modifiedData); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -98,8 +110,7 @@ func UpdateAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	}
 }
 
-func getDataFromBucket(bucketName, filePath string) ([]byte, error) {
-	ctx := context.Background()
+func getDataFromBucket(ctx context.Context, bucketName, filePath string) ([]byte, error) {
 	bucket := client.Bucket(bucketName)
 
 	obj := bucket.Object(filePath)
@@ -121,8 +132,7 @@ func getDataFromBucket(bucketName, filePath string) ([]byte, error) {
 	return data, nil
 }
 
-func sendDataToBucket(bucketName, filePath string, data []byte) error {
-	ctx := context.Background()
+func sendDataToBucket(ctx context.Context, bucketName, filePath string, data []byte) error {
 	bucket := client.Bucket(bucketName)
 
 	obj := bucket.Object(filePath)
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+// The exported Calculate* functions are the handler layer shared by the HTTP
+// routes and the gRPC service. They expect the debug trace in ctx and return
+// the calculation error as is, each transport decides how to report it.
+
+// newInvoicing returns how the invoices of a calculation are assembled. A run
+// without a runId is numbered from firstExternalId, it only gets a new run ID
+// to remember its one-time charges.
+func newInvoicing(ctx context.Context, params common.DefaultAPIParams) (fees.Invoicing, error) {
+	registry, err := loadEntities(ctx)
+	if err != nil {
+		return fees.Invoicing{}, err
+	}
+	validators, err := loadValidatorFees(ctx)
+	if err != nil {
+		return fees.Invoicing{}, err
+	}
+
+	invoicing := fees.Invoicing{
+		Grouping:          params.InvoiceGrouping,
+		FirstExternalId:   params.FirstExternalId,
+		Entities:          registry,
+		Calculators:       calculatorNames(params),
+		MinimumFeeLevel:   params.MinimumFeeLevel,
+		StakedBalanceFill: params.StakedBalanceFill,
+		ValidatorFees:     validators,
+	}
+	if sequences == nil && oneTimeCharges == nil {
+		return invoicing, nil
+	}
+
+	runID := params.RunId
+	if runID == "" {
+		// Only a run named by its caller reserves invoice numbers, nobody
+		// would know to release the ones of a run named here.
+		if oneTimeCharges == nil {
+			return invoicing, nil
+		}
+		sequences = nil
+		if runID, err = sequence.NewRunID(); err != nil {
+			return fees.Invoicing{}, err
+		}
+	}
+	invoicing.Sequences = sequences
+	invoicing.Charges = oneTimeCharges
+	invoicing.RunID = runID
+	return invoicing, nil
+}
+
+// withExplain turns on explain mode when the request asks for it.
+func withExplain(ctx context.Context, params common.DefaultAPIParams) context.Context {
+	if params.Explain == "" {
+		return ctx
+	}
+	return explain.NewContext(ctx)
+}
+
+// calculatorNames splits the comma separated calculators of the request.
+func calculatorNames(params common.DefaultAPIParams) []string {
+	var names []string
+	for _, value := range params.Calculators {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ValidateParams reports the request values the calculation would reject,
+// before it is started.
+func ValidateParams(params common.DefaultAPIParams) error {
+	switch params.Explain {
+	case "", common.ExplainJSON, common.ExplainText:
+	default:
+		return errors.New(fmt.Sprintf("Invalid explain value %q, expected %q or %q", params.Explain, common.ExplainJSON, common.ExplainText))
+	}
+
+	if _, err := fees.ParseInvoiceGrouping(string(params.InvoiceGrouping)); err != nil {
+		return err
+	}
+	if _, err := fees.ParseMinimumFeeLevel(string(params.MinimumFeeLevel)); err != nil {
+		return err
+	}
+	if _, err := fees.ParseStakedBalanceFill(string(params.StakedBalanceFill)); err != nil {
+		return err
+	}
+	return fees.CheckCalculators(calculatorNames(params))
+}
+
+// runCalculation wraps a calculation with the debug messages every
+// transport reports.
+func runCalculation(ctx context.Context, name string, calculation jobs.Calculation) (*fees.CalculatedFees, error) {
+	debug.NewMessageContext(ctx, "Starting "+name)
+
+	result, err := calculation(ctx)
+	if err != nil {
+		debug.NewMessageContext(ctx, "Error "+name+": "+err.Error())
+		debug.NewMessageContext(ctx, "Finishing "+name)
+		return nil, err
+	}
+
+	debug.NewMessageContext(ctx, "Success "+name)
+	debug.NewMessageContext(ctx, "Finishing "+name)
+
+	return result, nil
+}
+
+// writeCalculation runs the calculation, or starts it as a job when async is
+// set, and writes the HTTP response.
+func writeCalculation(ctx context.Context, w http.ResponseWriter, params common.DefaultAPIParams, name string, calculation jobs.Calculation) {
+	if err := ValidateParams(params); err != nil {
+		common.WriteErr(ctx, w, err)
+		return
+	}
+
+	if params.Async {
+		startJob(ctx, w, name, calculation)
+		return
+	}
+
+	result, err := calculation(ctx)
+	var parseErrs *databind.ParseErrors
+	if errors.As(err, &parseErrs) {
+		// Bad values in the reports are sent back in full so they can all be
+		// fixed before trying again.
+		resp := &common.Response{
+			Data:  parseErrs.Errors,
+			Warn:  "",
+			Debug: debug.GetAllMessages(ctx),
+			Err:   "Invalid values found in the reports.",
+		}
+		resp.Write(w)
+		return
+	}
+	if err != nil {
+		common.WriteErr(ctx, w, errors.New("Failed to calculate fees."))
+		return
+	}
+
+	if result.RunID != "" {
+		// The summary is the response data, the run to release the reserved
+		// identifiers with is sent aside.
+		w.Header().Set("X-Run-Id", result.RunID)
+	}
+
+	if params.Explain == common.ExplainText {
+		writeExplanations(ctx, w, result.Summary)
+		return
+	}
+
+	resp := &common.Response{
+		Data:  result.Summary,
+		Warn:  result.Warns,
+		Debug: debug.GetAllMessages(ctx),
+		Err:   "",
+	}
+	resp.Write(w)
+}
+
+func writeExplanations(ctx context.Context, w http.ResponseWriter, summary fees.StakingSummary) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := fees.WriteExplanations(w, summary); err != nil {
+		debug.NewMessageContext(ctx, "Error writing the explanations: "+err.Error())
+	}
+}
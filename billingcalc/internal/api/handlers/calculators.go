@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+)
+
+// GetCalculators lists the fee calculators a request can select.
+func GetCalculators(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	resp := &common.Response{Data: fees.CalculatorNames(), Warn: "", Debug: "", Err: ""}
+	resp.Write(w)
+}
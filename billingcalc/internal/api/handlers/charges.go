@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+)
+
+// oneTimeCharges remembers the onboarding fees already billed. The server
+// always sets it, when it is nil the fees are billed with a warning that they
+// were not recorded.
+var oneTimeCharges *charges.Tracker
+
+var errNoCharges = errors.New("One-time charges are not configured.")
+
+// SetChargeStore makes every calculation record the one-time fees it bills in
+// store. It must be called before the server starts handling requests.
+func SetChargeStore(store charges.Store) {
+	oneTimeCharges = charges.NewTracker(store)
+}
+
+// GetCharges lists the one-time fees billed by every run.
+func GetCharges(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if oneTimeCharges == nil {
+		common.WriteErr(r.Context(), w, errNoCharges)
+		return
+	}
+
+	ledger, err := oneTimeCharges.Ledger(r.Context())
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	resp := &common.Response{Data: ledger, Warn: "", Debug: "", Err: ""}
+	resp.Write(w)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+)
+
+// entitiesFile is the registry used by the calculations, a path in the
+// config bucket. When empty the registry embedded in the binary is used.
+var entitiesFile string
+
+// SetEntitiesFile makes the calculations read the entity registry from
+// filePath in the config bucket, the file managed with POST /entities. It is
+// read again by every calculation so updates apply right away.
+func SetEntitiesFile(filePath string) {
+	entitiesFile = filePath
+}
+
+func loadEntities(ctx context.Context) (*entities.Registry, error) {
+	if entitiesFile == "" {
+		return entities.Default()
+	}
+
+	data, err := ReadEntitiesFile(ctx, entitiesFile)
+	if err != nil {
+		return nil, err
+	}
+	return entities.Parse(data)
+}
+
+// ReadEntitiesFile returns the entity registry stored at filePath.
+func ReadEntitiesFile(ctx context.Context, filePath string) ([]byte, error) {
+	if filePath == "" {
+		return nil, errors.New("filePath parameter is required")
+	}
+
+	initClient()
+	return getDataFromBucket(ctx, configBucketName, filePath)
+}
+
+// WriteEntitiesFile replaces the entity registry stored at filePath, data
+// must be a valid registry.
+func WriteEntitiesFile(ctx context.Context, filePath string, data []byte) error {
+	if filePath == "" {
+		return errors.New("filePath parameter is required")
+	}
+	if _, err := entities.Parse(data); err != nil {
+		return err
+	}
+
+	initClient()
+	return sendDataToBucket(ctx, configBucketName, filePath, data)
+}
+
+func GetEntities(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filePath := r.URL.Query().Get("filePath")
+	if filePath == "" {
+		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := ReadEntitiesFile(r.Context(), filePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, "Error writing response", http.StatusInternalServerError)
+	}
+}
+
+func UpdateEntities(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filePath := r.URL.Query().Get("filePath")
+	if filePath == "" {
+		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	modifiedData, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if closeErr := r.Body.Close(); closeErr != nil {
+			log.Printf("Error closing request body: %v", closeErr)
+		}
+	}()
+
+	if err := WriteEntitiesFile(r.Context(), filePath, modifiedData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, err = w.Write([]byte("File updated successfully"))
+	if err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, "Error writing response", http.StatusInternalServerError)
+	}
+}
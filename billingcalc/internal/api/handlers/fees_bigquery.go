@@ -1,9 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"time"
 
@@ -11,13 +11,11 @@ import (
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
 )
 
 type BigQueryAPIParams struct {
 	common.DefaultAPIParams
-	MfrFile     string    `schema:"mfr"`
 	MfrTab      string    `schema:"mfrTab"`
 	SheetId     string    `schema:"sheetID"`
 	Token       string    `schema:"token"`
@@ -25,43 +23,40 @@ type BigQueryAPIParams struct {
 	PeriodEnd   time.Time `schema:"periodEnd"`
 }
 
-func CalcFeesFromBigQuery(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	err := r.ParseMultipartForm(math.MaxInt64)
+// CalculateFromBigQuery reads the MFR from the mfr rows when given, otherwise
+// from the sheet in params.
+func CalculateFromBigQuery(ctx context.Context, params *BigQueryAPIParams, mfr [][]string) (*fees.CalculatedFees, error) {
+	ctx = withExplain(ctx, params.DefaultAPIParams)
+	invoicing, err := newInvoicing(ctx, params.DefaultAPIParams)
 	if err != nil {
-		common.WriteErr(w, errors.New(err.Error()))
-		return
+		return nil, err
 	}
 
-	bqParams := &BigQueryAPIParams{}
-	err = common.SetValuesFromForm(bqParams, r.PostForm)
+	return runCalculation(ctx, "CalculateFromBigQuery", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return fees.CalculateFromBigQuery(ctx, mfr, params.SheetId, params.MfrTab, params.Token, params.PeriodBegin, params.PeriodEnd, invoicing, params.InvoiceDate)
+	})
+}
+
+func CalcFeesFromBigQuery(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var mfr [][]string
+	form, err := readUpload(r, map[string]*[][]string{"mfr": &mfr})
 	if err != nil {
-		common.WriteErr(w, errors.New(err.Error()))
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
 		return
 	}
 
-	mfr := converter.FromStringBase64ToIoReader(bqParams.MfrFile)
-
-	debug.Init(bqParams.Debug)
-	debug.NewMessage("Starting CalculateFromBigQuery")
-
-	debug.NewMessage("Parameters: " + fmt.Sprintf("%#v", bqParams))
-
-	result, err := fees.CalculateFromBigQuery(r.Context(), mfr, bqParams.SheetId, bqParams.MfrTab, bqParams.Token, bqParams.PeriodBegin, bqParams.PeriodEnd, bqParams.FirstExternalId, bqParams.InvoiceDate)
+	bqParams := &BigQueryAPIParams{}
+	err = common.SetValuesFromForm(bqParams, form)
 	if err != nil {
-		debug.NewMessage("Error CalculateFromBigQuery: " + err.Error())
-		common.WriteErr(w, errors.New("Failed to calculate fees."))
-		debug.NewMessage("Finishing CalculateFromBigQuery")
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
 		return
 	}
 
-	debug.NewMessage("Success CalculateFromBigQuery")
-	debug.NewMessage("Finishing CalculateFromBigQuery")
+	ctx := debug.NewContext(r.Context(), debug.NewTrace(bqParams.Debug))
 
-	resp := &common.Response{
-		Data:  result.Summary,
-		Warn:  result.Warns,
-		Debug: debug.GetAllMessages(),
-		Err:   "",
-	}
-	resp.Write(w)
+	debug.NewMessageContext(ctx, "Parameters: "+fmt.Sprintf("%#v", bqParams))
+
+	writeCalculation(ctx, w, bqParams.DefaultAPIParams, "CalculateFromBigQuery", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return CalculateFromBigQuery(ctx, bqParams, mfr)
+	})
 }
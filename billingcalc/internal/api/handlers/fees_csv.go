@@ -1,76 +1,104 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
 )
 
+// CsvAPIParams are the values of a /fees-csv request. The reports themselves
+// are read as they are uploaded, see readUpload.
 type CsvAPIParams struct {
 	common.DefaultAPIParams
-	MfrFile            string `schema:"mfr,required"`
-	RewardsFile        string `schema:"rewards,required"`
-	UnclaimedFile      string `schema:"unclaimed,required"`
-	BalanceAdjustments string `schema:"balanceAdjustments,required"`
-	OperationsStatuses string `schema:"operationsStatuses,required"`
-	DailyBalances      string `schema:"dailyBalances,required"`
 }
 
-func CalcFeesFromCsv(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	err := r.ParseMultipartForm(math.MaxInt64)
+// csvFiles are the form fields of the reports. The trades file is never
+// required: the brokerage fees are opt-in, without trades none are billed.
+var csvFiles = map[datasource.Dataset]string{
+	datasource.DatasetMfr:                "mfr",
+	datasource.DatasetRewards:            "rewards",
+	datasource.DatasetUnclaimedBalances:  "unclaimed",
+	datasource.DatasetBalanceAdjustments: "balanceAdjustments",
+	datasource.DatasetOperationsStatuses: "operationsStatuses",
+	datasource.DatasetDailyBalances:      "dailyBalances",
+}
+
+// requiredFiles lists the files the selected calculators read.
+func requiredFiles(calculators []string) ([]string, error) {
+	datasets, err := fees.NeededDatasets(calculators)
 	if err != nil {
-		common.WriteErr(w, errors.New(err.Error()))
-		return
+		return nil, err
 	}
+	var names []string
+	for _, dataset := range datasets {
+		if name, ok := csvFiles[dataset]; ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
 
-	csvParams := &CsvAPIParams{}
-	err = common.SetValuesFromForm(csvParams, r.PostForm)
+func CalculateFromCsv(ctx context.Context, params common.DefaultAPIParams, reports fees.CsvReports) (*fees.CalculatedFees, error) {
+	ctx = withExplain(ctx, params)
+	invoicing, err := newInvoicing(ctx, params)
 	if err != nil {
-		common.WriteErr(w, errors.New(err.Error()))
-		return
+		return nil, err
 	}
 
-	debug.Init(csvParams.Debug)
-	debug.NewMessage("Starting CalculateFromCsv")
-
-	debug.NewMessage("Values From Form: " + fmt.Sprintf("%#v", csvParams))
-
-	mfr := converter.FromStringBase64ToIoReader(csvParams.MfrFile)
-
-	rewards := converter.FromStringBase64ToIoReader(csvParams.RewardsFile)
-
-	unclaimed := converter.FromStringBase64ToIoReader(csvParams.UnclaimedFile)
+	return runCalculation(ctx, "CalculateFromCsv", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return fees.CalculateFromCsv(ctx, reports, invoicing, params.InvoiceDate)
+	})
+}
 
-	balanceAdjustments := converter.FromStringBase64ToIoReader(csvParams.BalanceAdjustments)
+func CalcFeesFromCsv(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	reports := fees.CsvReports{}
+	files := map[string]*[][]string{
+		"mfr":                &reports.Mfr,
+		"rewards":            &reports.Rewards,
+		"unclaimed":          &reports.UnclaimedBalances,
+		"balanceAdjustments": &reports.BalanceAdjustments,
+		"operationsStatuses": &reports.OperationsStatuses,
+		"dailyBalances":      &reports.DailyBalances,
+		"trades":             &reports.Trades,
+	}
 
-	operationsStatuses := converter.FromStringBase64ToIoReader(csvParams.OperationsStatuses)
+	form, err := readUpload(r, files)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
 
-	dailyBalances := converter.FromStringBase64ToIoReader(csvParams.DailyBalances)
+	csvParams := &CsvAPIParams{}
+	err = common.SetValuesFromForm(csvParams, form)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
 
-	result, err := fees.CalculateFromCsv(r.Context(), mfr, rewards, unclaimed, balanceAdjustments, operationsStatuses, dailyBalances, csvParams.FirstExternalId, csvParams.InvoiceDate)
+	required, err := requiredFiles(csvParams.Calculators)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+	err = missingFiles(required, files)
 	if err != nil {
-		debug.NewMessage("Error CalculateFromCsv: " + err.Error())
-		common.WriteErr(w, errors.New("Failed to calculate fees."))
-		debug.NewMessage("Finishing CalculateFromCsv")
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
 		return
 	}
 
-	debug.NewMessage("Success CalculateFromCsv")
-	debug.NewMessage("Finishing CalculateFromCsv")
+	ctx := debug.NewContext(r.Context(), debug.NewTrace(csvParams.Debug))
 
-	resp := &common.Response{
-		Data:  result.Summary,
-		Warn:  result.Warns,
-		Debug: debug.GetAllMessages(),
-		Err:   "",
-	}
-	resp.Write(w)
+	debug.NewMessageContext(ctx, "Values From Form: "+fmt.Sprintf("%#v", csvParams))
+
+	writeCalculation(ctx, w, csvParams.DefaultAPIParams, "CalculateFromCsv", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return CalculateFromCsv(ctx, csvParams.DefaultAPIParams, reports)
+	})
 }
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -20,48 +21,45 @@ type GSheetAPIParams struct {
 	BalanceAdjustmentsTab string `schema:"balanceAdjustmentTab"`
 	OperationsStatusesTab string `schema:"operationsStatusesTab,required"`
 	DailyBalancesTab      string `schema:"dailyBalancesTab,required"`
+	TradesTab             string `schema:"tradesTab"`
 	SheetId               string `schema:"sheetID"`
 	Token                 string `schema:"token"`
 }
 
+func CalculateFromGSheets(ctx context.Context, params *GSheetAPIParams) (*fees.CalculatedFees, error) {
+	ctx = withExplain(ctx, params.DefaultAPIParams)
+	invoicing, err := newInvoicing(ctx, params.DefaultAPIParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return runCalculation(ctx, "CalculateFromGSheets", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return fees.CalculateFromGSheets(ctx, params.SheetId, params.MfrTab, params.RewardsTab, params.UnclaimedBalancesTab, params.BalanceAdjustmentsTab, params.OperationsStatusesTab, params.DailyBalancesTab, params.TradesTab, params.Token, invoicing, params.InvoiceDate)
+	})
+}
+
 func CalcFeesFromGSheets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	debug.NewMessage("Parsing form")
+	debug.NewMessageContext(r.Context(), "Parsing form")
 	err := r.ParseForm()
 	if err != nil {
-		debug.NewMessage(err.Error())
-		common.WriteErr(w, errors.New(err.Error()))
+		debug.NewMessageContext(r.Context(), err.Error())
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
 		return
 	}
 
 	params := &GSheetAPIParams{}
 	err = common.SetValuesFromForm(params, r.PostForm)
 	if err != nil {
-		debug.NewMessage(err.Error())
-		common.WriteErr(w, errors.New(err.Error()))
+		debug.NewMessageContext(r.Context(), err.Error())
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
 		return
 	}
 
-	debug.Init(params.Debug)
-	debug.NewMessage("Starting CalculateFromGSheets")
+	ctx := debug.NewContext(r.Context(), debug.NewTrace(params.Debug))
 
-	debug.NewMessage("Parameters: " + fmt.Sprintf("%#v", params))
+	debug.NewMessageContext(ctx, "Parameters: "+fmt.Sprintf("%#v", params))
 
-	result, err := fees.CalculateFromGSheets(r.Context(), params.SheetId, params.MfrTab, params.RewardsTab, params.UnclaimedBalancesTab, params.BalanceAdjustmentsTab, params.OperationsStatusesTab, params.DailyBalancesTab, params.Token, params.FirstExternalId, params.InvoiceDate)
-	if err != nil {
-		debug.NewMessage("Error CalculateFromGSheets: " + err.Error())
-		common.WriteErr(w, errors.New("Failed to calculate fees."))
-		debug.NewMessage("Finishing CalculateFromGSheets")
-		return
-	}
-
-	debug.NewMessage("Success CalculateFromGSheets")
-	debug.NewMessage("Finishing CalculateFromGSheets")
-
-	resp := &common.Response{
-		Data:  result.Summary,
-		Warn:  result.Warns,
-		Debug: debug.GetAllMessages(),
-		Err:   "",
-	}
-	resp.Write(w)
+	writeCalculation(ctx, w, params.DefaultAPIParams, "CalculateFromGSheets", func(ctx context.Context) (*fees.CalculatedFees, error) {
+		return CalculateFromGSheets(ctx, params)
+	})
 }
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+var jobRunner = jobs.NewRunner(jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs))
+
+// SetJobStore replaces the default in-memory job store. It must be called
+// before the server starts handling requests.
+func SetJobStore(store jobs.Store) {
+	jobRunner = jobs.NewRunner(store)
+}
+
+// GetJob writes the job with its result once finished. The default in-memory
+// store only keeps finished jobs for jobs.DefaultJobTTL and at most
+// jobs.DefaultMaxJobs of them, older jobs are not found.
+func GetJob(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	job, err := jobRunner.Get(r.Context(), ps.ByName("id"))
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	writeJob(w, job)
+}
+
+func CancelJob(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	job, err := jobRunner.Cancel(r.Context(), ps.ByName("id"))
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	writeJob(w, job)
+}
+
+// startJob runs the calculation in the background and answers right away with
+// the job, whose ID is used to poll GET /jobs/{id}.
+func startJob(ctx context.Context, w http.ResponseWriter, name string, calculation jobs.Calculation) {
+	job, err := jobRunner.Start(debug.FromContext(ctx), calculation)
+	if err != nil {
+		debug.NewMessageContext(ctx, fmt.Sprintf("Error starting %s: %s", name, err.Error()))
+		common.WriteErr(ctx, w, errors.New("Failed to start the calculation job."))
+		return
+	}
+
+	debug.NewMessageContext(ctx, fmt.Sprintf("Started %s as job %s", name, job.Id))
+
+	resp := &common.Response{
+		Data:  job,
+		Warn:  "",
+		Debug: debug.GetAllMessages(ctx),
+		Err:   "",
+	}
+	resp.Write(w)
+}
+
+func writeJob(w http.ResponseWriter, job *jobs.Job) {
+	var warns interface{} = ""
+	if job.Result != nil {
+		warns = job.Result.Warns
+	}
+
+	resp := &common.Response{
+		Data:  job,
+		Warn:  warns,
+		Debug: job.Debug,
+		Err:   job.Err,
+	}
+	resp.Write(w)
+}
+
+// GetJobExplanations writes the explanations of a finished job started with
+// explain mode as a plain text report.
+func GetJobExplanations(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	job, err := jobRunner.Get(r.Context(), ps.ByName("id"))
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+	if job.Result == nil {
+		common.WriteErr(r.Context(), w, errors.New(fmt.Sprintf("Job %s has no result yet, its status is %s.", job.Id, job.Status)))
+		return
+	}
+
+	writeExplanations(r.Context(), w, job.Result.Summary)
+}
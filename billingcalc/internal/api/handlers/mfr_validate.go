@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/mfrlint"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+// MfrValidateAPIParams point to the MFR tab to lint when no mfr file is
+// uploaded.
+type MfrValidateAPIParams struct {
+	MfrTab  string `schema:"mfrTab"`
+	SheetId string `schema:"sheetID"`
+	Token   string `schema:"token"`
+	Debug   bool   `schema:"debug"`
+}
+
+// LintMfr loads the MFR from the uploaded rows, or from Google Sheets when
+// there are none, and lints it.
+func LintMfr(ctx context.Context, params *MfrValidateAPIParams, mfrRows [][]string) (*mfrlint.Report, error) {
+	var masterFeeRates *mfr.MasterFeeRates
+	var err error
+	if len(mfrRows) > 0 {
+		masterFeeRates, err = mfr.ParseMfrCsv(mfrRows)
+	} else {
+		masterFeeRates, err = mfr.ProcessMfr(ctx, nil, params.SheetId, params.MfrTab, params.Token)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := loadEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mfrlint.Lint(masterFeeRates, registry)
+}
+
+func ValidateMfr(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var mfrRows [][]string
+	var form url.Values
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		form, err = readUpload(r, map[string]*[][]string{"mfr": &mfrRows})
+	} else {
+		err = r.ParseForm()
+		form = r.PostForm
+	}
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	params := &MfrValidateAPIParams{}
+	err = common.SetValuesFromForm(params, form)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	ctx := debug.NewContext(r.Context(), debug.NewTrace(params.Debug))
+
+	report, err := LintMfr(ctx, params, mfrRows)
+	var parseErrs *databind.ParseErrors
+	if errors.As(err, &parseErrs) {
+		resp := &common.Response{
+			Data:  parseErrs.Errors,
+			Warn:  "",
+			Debug: debug.GetAllMessages(ctx),
+			Err:   "Invalid values found in the reports.",
+		}
+		resp.Write(w)
+		return
+	}
+	if err != nil {
+		common.WriteErr(ctx, w, errors.New(err.Error()))
+		return
+	}
+
+	resp := &common.Response{
+		Data:  report,
+		Warn:  "",
+		Debug: debug.GetAllMessages(ctx),
+		Err:   "",
+	}
+	resp.Write(w)
+}
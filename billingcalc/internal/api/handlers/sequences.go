@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
+)
+
+// sequences reserves the invoice identifiers of each run. When it is nil the
+// invoices are numbered from the firstExternalId of the request.
+var sequences *sequence.Allocator
+
+var errNoSequences = errors.New("Invoice sequences are not configured.")
+
+// SetSequenceStore makes every calculation reserve its invoice identifiers in
+// store. It must be called before the server starts handling requests.
+func SetSequenceStore(store sequence.Store) {
+	sequences = sequence.NewAllocator(store)
+}
+
+func GetSequence(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if sequences == nil {
+		common.WriteErr(r.Context(), w, errNoSequences)
+		return
+	}
+
+	ledger, err := sequences.Ledger(r.Context(), ps.ByName("sequence"))
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	resp := &common.Response{Data: ledger, Warn: "", Debug: "", Err: ""}
+	resp.Write(w)
+}
+
+// SeedSequence moves a sequence to the number given in the next field, so it
+// continues the invoice numbers already used in NetSuite.
+func SeedSequence(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if sequences == nil {
+		common.WriteErr(r.Context(), w, errNoSequences)
+		return
+	}
+
+	next, err := strconv.Atoi(r.FormValue("next"))
+	if err != nil || next < 1 {
+		common.WriteErr(r.Context(), w, errors.New("next must be a positive number"))
+		return
+	}
+
+	ledger, err := sequences.Seed(r.Context(), ps.ByName("sequence"), next)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	resp := &common.Response{Data: ledger, Warn: "", Debug: "", Err: ""}
+	resp.Write(w)
+}
+
+// ReleasedRun is what a discarded run gave back.
+type ReleasedRun struct {
+	Reservations []sequence.Reservation `json:"reservations"`
+	Charges      []charges.Charge       `json:"charges"`
+}
+
+// ReleaseRun releases the identifiers reserved and the one-time fees charged
+// by a discarded run.
+func ReleaseRun(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if sequences == nil && oneTimeCharges == nil {
+		common.WriteErr(r.Context(), w, errNoSequences)
+		return
+	}
+
+	released := ReleasedRun{Reservations: []sequence.Reservation{}, Charges: []charges.Charge{}}
+	if sequences != nil {
+		reservations, err := sequences.Release(r.Context(), ps.ByName("runId"))
+		if err != nil {
+			common.WriteErr(r.Context(), w, errors.New(err.Error()))
+			return
+		}
+		released.Reservations = reservations
+	}
+	if oneTimeCharges != nil {
+		charged, err := oneTimeCharges.Release(r.Context(), ps.ByName("runId"))
+		if err != nil {
+			common.WriteErr(r.Context(), w, errors.New(err.Error()))
+			return
+		}
+		released.Charges = charged
+	}
+
+	resp := &common.Response{Data: released, Warn: "", Debug: "", Err: ""}
+	resp.Write(w)
+}
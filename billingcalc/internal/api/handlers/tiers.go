@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/common"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+// TierPreviewAPIParams name the MFR asset type whose tiers are applied to Auc.
+// The MFR is read from the MFR tab when no mfr file is uploaded.
+type TierPreviewAPIParams struct {
+	MfrTab      string `schema:"mfrTab"`
+	SheetId     string `schema:"sheetID"`
+	Token       string `schema:"token"`
+	Debug       bool   `schema:"debug"`
+	AccountId   string `schema:"accountId"`
+	AssetTypeId int    `schema:"assetTypeId"`
+	Auc         string `schema:"auc"`
+}
+
+// PreviewTierSchedule loads the MFR from the uploaded rows, or from Google Sheets
+// when there are none, and charges the AUC with the tiers of the asset type.
+func PreviewTierSchedule(ctx context.Context, params *TierPreviewAPIParams, mfrRows [][]string) (tiers.Result, error) {
+	auc, err := databind.ParseDecimal(params.Auc)
+	if err != nil {
+		return tiers.Result{}, errors.New(fmt.Sprintf("Invalid auc %q: %v", params.Auc, err))
+	}
+	if auc.IsNegative() {
+		return tiers.Result{}, errors.New(fmt.Sprintf("Invalid auc %q: the AUC cannot be negative", params.Auc))
+	}
+
+	var masterFeeRates *mfr.MasterFeeRates
+	if len(mfrRows) > 0 {
+		masterFeeRates, err = mfr.ParseMfrCsv(mfrRows)
+	} else {
+		masterFeeRates, err = mfr.ProcessMfr(ctx, nil, params.SheetId, params.MfrTab, params.Token)
+	}
+	if err != nil {
+		return tiers.Result{}, err
+	}
+
+	org, account, ok := masterFeeRates.FindAccountById(databind.AccountID(params.AccountId))
+	if !ok {
+		return tiers.Result{}, errors.New(fmt.Sprintf("Account %q not found in the MFR", params.AccountId))
+	}
+	for _, assetType := range masterFeeRates.GetSortedAssetTypes(org.Id, account.Id) {
+		if assetType.Id != mfr.AssetID(params.AssetTypeId) {
+			continue
+		}
+		schedule, err := assetType.Schedule()
+		if err != nil {
+			return tiers.Result{}, errors.New(fmt.Sprintf("Asset type %d of account %q: %v", params.AssetTypeId, params.AccountId, err))
+		}
+		return schedule.Apply(auc), nil
+	}
+
+	return tiers.Result{}, errors.New(fmt.Sprintf("Asset type %d not found for account %q", params.AssetTypeId, params.AccountId))
+}
+
+// PreviewTiers returns the band by band custody fee of a hypothetical AUC.
+func PreviewTiers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var mfrRows [][]string
+	var form url.Values
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		form, err = readUpload(r, map[string]*[][]string{"mfr": &mfrRows})
+	} else {
+		err = r.ParseForm()
+		form = r.PostForm
+	}
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	params := &TierPreviewAPIParams{}
+	err = common.SetValuesFromForm(params, form)
+	if err != nil {
+		common.WriteErr(r.Context(), w, errors.New(err.Error()))
+		return
+	}
+
+	ctx := debug.NewContext(r.Context(), debug.NewTrace(params.Debug))
+
+	result, err := PreviewTierSchedule(ctx, params, mfrRows)
+	if err != nil {
+		common.WriteErr(ctx, w, errors.New(err.Error()))
+		return
+	}
+
+	resp := &common.Response{
+		Data:  result,
+		Warn:  "",
+		Debug: debug.GetAllMessages(ctx),
+		Err:   "",
+	}
+	resp.Write(w)
+}
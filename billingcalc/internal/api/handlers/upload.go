@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/file"
+)
+
+const (
+	defaultMaxFileSize = 32 << 20
+	maxFormValueSize   = 1 << 20
+)
+
+// maxFileSize is the largest report accepted per uploaded file.
+var maxFileSize int64 = defaultMaxFileSize
+
+// SetMaxFileSize changes the largest report, in bytes, accepted per uploaded
+// file.
+func SetMaxFileSize(size int64) {
+	maxFileSize = size
+}
+
+// readUpload streams a multipart request. Every part named in files is read
+// straight into its CSV rows, either from a file part or from a base64
+// encoded value, so no report is buffered before being parsed. The other
+// values are returned as the form.
+func readUpload(r *http.Request, files map[string]*[][]string) (url.Values, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	form := url.Values{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New(err.Error())
+		}
+
+		name := part.FormName()
+		rows, isFile := files[name]
+		if !isFile {
+			value, err := io.ReadAll(file.LimitReader(part, maxFormValueSize))
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("Error while reading %s: %v", name, err))
+			}
+			form.Add(name, string(value))
+			continue
+		}
+
+		var content io.Reader = part
+		if part.FileName() == "" {
+			content = base64.NewDecoder(base64.StdEncoding, part)
+		}
+
+		*rows, err = file.ReadCsv(file.LimitReader(content, maxFileSize))
+		if errors.Is(err, file.ErrFileTooLarge) {
+			return nil, errors.New(fmt.Sprintf("The %s file is larger than %d bytes.", name, maxFileSize))
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error while reading %s file: %v", name, err))
+		}
+	}
+
+	return form, nil
+}
+
+// missingFiles lists the names, in order, of the files that were not uploaded.
+func missingFiles(names []string, files map[string]*[][]string) error {
+	missing := []string{}
+	for _, name := range names {
+		if len(*files[name]) == 0 {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New(fmt.Sprintf("Missing files: %v", missing))
+	}
+
+	return nil
+}
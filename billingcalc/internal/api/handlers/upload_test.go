@@ -0,0 +1,89 @@
+//go:build !selectTest || unitTest
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/api/handlers"
+)
+
+func postCsv(t *testing.T, write func(mw *multipart.Writer)) map[string]interface{} {
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	write(mw)
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/fees-csv", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handlers.CalcFeesFromCsv(w, r, nil)
+
+	resp := map[string]interface{}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// writeParams writes the required values of a /fees-csv request.
+func writeParams(mw *multipart.Writer) {
+	mw.WriteField("firstExternalId", "1")
+	mw.WriteField("invoiceDate", "2023-06-30")
+	mw.WriteField("debug", "false")
+}
+
+func TestCalcFeesFromCsvUpload(t *testing.T) {
+	t.Run("File parts and base64 fields", func(t *testing.T) {
+		resp := postCsv(t, func(mw *multipart.Writer) {
+			writeParams(mw)
+			part, _ := mw.CreateFormFile("mfr", "mfr.csv")
+			part.Write([]byte("a,b\n"))
+			mw.WriteField("rewards", base64.StdEncoding.EncodeToString([]byte("a,b\n")))
+		})
+
+		assert.Equal(t, "Missing files: [unclaimed balanceAdjustments operationsStatuses dailyBalances]", resp["err"])
+	})
+
+	t.Run("Only the files of the selected calculators", func(t *testing.T) {
+		resp := postCsv(t, func(mw *multipart.Writer) {
+			writeParams(mw)
+			mw.WriteField("calculators", "custody")
+			mw.WriteField("mfr", base64.StdEncoding.EncodeToString([]byte("a,b\n")))
+		})
+
+		assert.Equal(t, "Missing files: [rewards dailyBalances]", resp["err"])
+	})
+
+	t.Run("File over the size limit", func(t *testing.T) {
+		handlers.SetMaxFileSize(4)
+		defer handlers.SetMaxFileSize(32 << 20)
+
+		resp := postCsv(t, func(mw *multipart.Writer) {
+			part, _ := mw.CreateFormFile("dailyBalances", "daily_balances.csv")
+			part.Write([]byte("a,b\n1,2\n"))
+		})
+
+		assert.Equal(t, "The dailyBalances file is larger than 4 bytes.", resp["err"])
+	})
+
+	t.Run("Not a multipart request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/fees-csv", nil)
+		w := httptest.NewRecorder()
+
+		handlers.CalcFeesFromCsv(w, r, nil)
+
+		assert.Contains(t, w.Body.String(), "multipart")
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+// validatorFeesFile is the validator fee registry used by the calculations, a
+// path in the config bucket. When empty the registry embedded in the binary
+// is used.
+var validatorFeesFile string
+
+// SetValidatorFeesFile makes the calculations read the validator fees from
+// filePath in the config bucket, the file managed with POST /validators/fees.
+// It is read again by every calculation so updates apply right away.
+func SetValidatorFeesFile(filePath string) {
+	validatorFeesFile = filePath
+}
+
+func loadValidatorFees(ctx context.Context) (*validatorfees.Registry, error) {
+	if validatorFeesFile == "" {
+		return validatorfees.Default()
+	}
+
+	data, err := ReadValidatorFeesFile(ctx, validatorFeesFile)
+	if err != nil {
+		return nil, err
+	}
+	return validatorfees.Parse(data)
+}
+
+// ReadValidatorFeesFile returns the validator fee registry stored at filePath.
+func ReadValidatorFeesFile(ctx context.Context, filePath string) ([]byte, error) {
+	if filePath == "" {
+		return nil, errors.New("filePath parameter is required")
+	}
+
+	initClient()
+	return getDataFromBucket(ctx, configBucketName, filePath)
+}
+
+// WriteValidatorFeesFile replaces the validator fee registry stored at
+// filePath, data must be a valid registry.
+func WriteValidatorFeesFile(ctx context.Context, filePath string, data []byte) error {
+	if filePath == "" {
+		return errors.New("filePath parameter is required")
+	}
+	if _, err := validatorfees.Parse(data); err != nil {
+		return err
+	}
+
+	initClient()
+	return sendDataToBucket(ctx, configBucketName, filePath, data)
+}
+
+func GetValidatorFees(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filePath := r.URL.Query().Get("filePath")
+	if filePath == "" {
+		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	data, err := ReadValidatorFeesFile(r.Context(), filePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, "Error writing response", http.StatusInternalServerError)
+	}
+}
+
+func UpdateValidatorFees(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	filePath := r.URL.Query().Get("filePath")
+	if filePath == "" {
+		http.Error(w, "filePath parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	modifiedData, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		if closeErr := r.Body.Close(); closeErr != nil {
+			log.Printf("Error closing request body: %v", closeErr)
+		}
+	}()
+
+	if err := WriteValidatorFeesFile(r.Context(), filePath, modifiedData); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, err = w.Write([]byte("File updated successfully"))
+	if err != nil {
+		log.Printf("Error writing response: %v", err)
+		http.Error(w, "Error writing response", http.StatusInternalServerError)
+	}
+}
@@ -25,5 +25,19 @@ func Install(r *httprouter.Router) {
 	r.POST("/fees", handlers.CalcFeesFromGSheets)
 	r.POST("/fees-csv", handlers.CalcFeesFromCsv)
 	r.POST("/fees-bq", handlers.CalcFeesFromBigQuery)
+	r.GET("/calculators", handlers.GetCalculators)
 	r.POST("/assets", handlers.UpdateAssets)
+	r.GET("/entities", handlers.GetEntities)
+	r.POST("/entities", handlers.UpdateEntities)
+	r.GET("/validators/fees", handlers.GetValidatorFees)
+	r.POST("/validators/fees", handlers.UpdateValidatorFees)
+	r.POST("/mfr/validate", handlers.ValidateMfr)
+	r.POST("/tiers/preview", handlers.PreviewTiers)
+	r.GET("/jobs/:id", handlers.GetJob)
+	r.DELETE("/jobs/:id", handlers.CancelJob)
+	r.GET("/jobs/:id/explain", handlers.GetJobExplanations)
+	r.GET("/sequences/:sequence", handlers.GetSequence)
+	r.PUT("/sequences/:sequence", handlers.SeedSequence)
+	r.GET("/charges", handlers.GetCharges)
+	r.DELETE("/runs/:runId", handlers.ReleaseRun)
 }
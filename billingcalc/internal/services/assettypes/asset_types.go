@@ -59,6 +59,11 @@ func (atl *AssetTypeList) GetTypeByAssetName(assetName string, assetId int) (*As
 	return nil, errors.New(fmt.Sprintf("Asset Name '%s' not found in Asset Types ID '%v'", assetName, assetId))
 }
 
+// HasAssetId reports whether assetId is one of the configured asset types.
+func (atl *AssetTypeList) HasAssetId(assetId int) bool {
+	return searchForId(assetId, atl.assetTypes) != nil
+}
+
 func listAssetTypes() ([]AssetType, error) {
 	fileContent, err := static.Files.ReadFile("asset_types.json")
 	if err != nil {
@@ -0,0 +1,179 @@
+// package charges remembers the one-time fees already billed to each
+// account, e.g. the onboarding fee, so recalculating a period or billing a
+// later one never charges them twice.
+package charges
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Status string
+
+const (
+	StatusCharged  Status = "charged"
+	StatusReleased Status = "released"
+)
+
+// ErrConflict is returned by Store.Save when the ledger changed since it was
+// loaded.
+var ErrConflict = errors.New("Charges ledger changed concurrently.")
+
+// maxAttempts bounds the retries of an update losing the race against other
+// runs.
+const maxAttempts = 10
+
+// Charge is a one-time fee billed to an account by a run.
+type Charge struct {
+	AccountID   string          `json:"accountId"`
+	Fee         string          `json:"fee"`
+	Amount      decimal.Decimal `json:"amount"`
+	InvoiceDate time.Time       `json:"invoiceDate"`
+	RunID       string          `json:"runId"`
+	Status      Status          `json:"status"`
+	ChargedAt   time.Time       `json:"chargedAt"`
+	ReleasedAt  *time.Time      `json:"releasedAt,omitempty"`
+}
+
+type Ledger struct {
+	Charges []Charge `json:"charges"`
+}
+
+func newLedger() *Ledger {
+	return &Ledger{Charges: []Charge{}}
+}
+
+// Store keeps the ledger. Save must only succeed when the stored version is
+// still the one returned by Load, so two runs never both charge a fee.
+type Store interface {
+	// Load returns the ledger and its version. A ledger never saved is empty
+	// at version 0.
+	Load(ctx context.Context) (*Ledger, int64, error)
+	// Save replaces the ledger stored at version, or returns ErrConflict.
+	Save(ctx context.Context, ledger *Ledger, version int64) error
+}
+
+type Tracker struct {
+	store Store
+}
+
+func NewTracker(store Store) *Tracker {
+	return &Tracker{store: store}
+}
+
+// errUnchanged stops an update without saving the ledger.
+var errUnchanged = errors.New("unchanged")
+
+// Claim records the fees of candidates for runID. It returns the ones the
+// run bills, including the ones it already charged in an earlier
+// calculation, and the charges made by other runs, which must not be billed
+// again.
+func (t *Tracker) Claim(ctx context.Context, runID string, candidates []Charge) (claimed []Charge, chargedBefore []Charge, err error) {
+	if runID == "" {
+		return nil, nil, errors.New("A run ID is required to charge one-time fees.")
+	}
+	if len(candidates) == 0 {
+		return nil, nil, nil
+	}
+
+	_, err = t.update(ctx, func(ledger *Ledger) error {
+		claimed, chargedBefore = nil, nil
+		changed := false
+		for _, candidate := range candidates {
+			existing, found := ledger.find(candidate.AccountID, candidate.Fee)
+			switch {
+			case found && existing.RunID == runID:
+				claimed = append(claimed, existing)
+			case found:
+				chargedBefore = append(chargedBefore, existing)
+			default:
+				candidate.RunID = runID
+				candidate.Status = StatusCharged
+				candidate.ChargedAt = time.Now().UTC()
+				ledger.Charges = append(ledger.Charges, candidate)
+				claimed = append(claimed, candidate)
+				changed = true
+			}
+		}
+		if !changed {
+			return errUnchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return claimed, chargedBefore, nil
+}
+
+// Release cancels the charges of a discarded run, the fees are billed again
+// by the next run that calculates their period.
+func (t *Tracker) Release(ctx context.Context, runID string) ([]Charge, error) {
+	released := []Charge{}
+	_, err := t.update(ctx, func(ledger *Ledger) error {
+		released = released[:0]
+		now := time.Now().UTC()
+		for i := range ledger.Charges {
+			c := &ledger.Charges[i]
+			if c.RunID == runID && c.Status == StatusCharged {
+				c.Status = StatusReleased
+				c.ReleasedAt = &now
+				released = append(released, *c)
+			}
+		}
+		if len(released) == 0 {
+			return errUnchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// Ledger returns every charge made.
+func (t *Tracker) Ledger(ctx context.Context) (*Ledger, error) {
+	ledger, _, err := t.store.Load(ctx)
+	return ledger, err
+}
+
+// find returns the charge of fee to the account that is not released.
+func (l *Ledger) find(accountID, fee string) (Charge, bool) {
+	for _, c := range l.Charges {
+		if c.AccountID == accountID && c.Fee == fee && c.Status == StatusCharged {
+			return c, true
+		}
+	}
+	return Charge{}, false
+}
+
+// update applies change to the latest ledger and saves it, again on a fresh
+// copy when another run saved it in the meantime.
+func (t *Tracker) update(ctx context.Context, change func(ledger *Ledger) error) (*Ledger, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		ledger, version, err := t.store.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := change(ledger); errors.Is(err, errUnchanged) {
+			return ledger, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		err = t.store.Save(ctx, ledger, version)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ledger, nil
+	}
+	return nil, errors.New("Failed to update the charges: too many concurrent updates")
+}
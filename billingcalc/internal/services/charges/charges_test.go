@@ -0,0 +1,101 @@
+//go:build !selectTest || unitTest
+
+package charges_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+)
+
+func newTracker(t *testing.T) *charges.Tracker {
+	store, err := charges.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return charges.NewTracker(store)
+}
+
+func onboarding(accountID string) charges.Charge {
+	return charges.Charge{AccountID: accountID, Fee: "Onboarding Fee", Amount: decimal.NewFromInt(5000)}
+}
+
+func TestClaim(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should charge a fee only once", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		claimed, before, err := tracker.Claim(ctx, "run-1", []charges.Charge{onboarding("acc-1")})
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Empty(t, before)
+
+		claimed, before, err = tracker.Claim(ctx, "run-2", []charges.Charge{onboarding("acc-1"), onboarding("acc-2")})
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Equal(t, "acc-2", claimed[0].AccountID)
+		assert.Len(t, before, 1)
+		assert.Equal(t, "run-1", before[0].RunID)
+	})
+
+	t.Run("Should bill again the fees of a run calculated again", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		_, _, _ = tracker.Claim(ctx, "run-1", []charges.Charge{onboarding("acc-1")})
+		claimed, before, err := tracker.Claim(ctx, "run-1", []charges.Charge{onboarding("acc-1")})
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Empty(t, before)
+
+		ledger, err := tracker.Ledger(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, ledger.Charges, 1)
+	})
+
+	t.Run("Should charge again the fees of a released run", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		_, _, _ = tracker.Claim(ctx, "run-1", []charges.Charge{onboarding("acc-1")})
+		released, err := tracker.Release(ctx, "run-1")
+		assert.NoError(t, err)
+		assert.Len(t, released, 1)
+
+		claimed, before, err := tracker.Claim(ctx, "run-2", []charges.Charge{onboarding("acc-1")})
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 1)
+		assert.Empty(t, before)
+	})
+
+	t.Run("Should require a run ID", func(t *testing.T) {
+		_, _, err := newTracker(t).Claim(ctx, "", []charges.Charge{onboarding("acc-1")})
+		assert.EqualError(t, err, "A run ID is required to charge one-time fees.")
+	})
+
+	t.Run("Should charge a fee to one of concurrent runs", func(t *testing.T) {
+		tracker := newTracker(t)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		charged := 0
+		for _, runID := range []string{"run-1", "run-2", "run-3", "run-4"} {
+			wg.Add(1)
+			go func(runID string) {
+				defer wg.Done()
+				claimed, _, err := tracker.Claim(ctx, runID, []charges.Charge{onboarding("acc-1")})
+				assert.NoError(t, err)
+				mu.Lock()
+				charged += len(claimed)
+				mu.Unlock()
+			}(runID)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, charged)
+	})
+}
@@ -0,0 +1,86 @@
+package charges
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore keeps the ledger in a JSON file, for local runs. The version
+// check is only safe within one server process.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+type fileLedger struct {
+	Version int64   `json:"version"`
+	Ledger  *Ledger `json:"ledger"`
+}
+
+// NewFileStore keeps the ledger in the charges.json file of dir.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to create the charges directory %s: %v", dir, err))
+	}
+	return &FileStore{path: filepath.Join(dir, "charges.json")}, nil
+}
+
+func (s *FileStore) Load(_ context.Context) (*Ledger, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.read()
+	if err != nil {
+		return nil, 0, err
+	}
+	return stored.Ledger, stored.Version, nil
+}
+
+func (s *FileStore) Save(_ context.Context, ledger *Ledger, version int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.read()
+	if err != nil {
+		return err
+	}
+	if stored.Version != version {
+		return ErrConflict
+	}
+
+	data, err := json.MarshalIndent(fileLedger{Version: version + 1, Ledger: ledger}, "", "  ")
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to encode the charges: %v", err))
+	}
+
+	// Write to a temporary file first so a reader never sees a partial ledger.
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write the charges: %v", err))
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write the charges: %v", err))
+	}
+	return nil
+}
+
+func (s *FileStore) read() (*fileLedger, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &fileLedger{Ledger: newLedger()}, nil
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to read the charges: %v", err))
+	}
+
+	stored := &fileLedger{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to decode the charges: %v", err))
+	}
+	return stored, nil
+}
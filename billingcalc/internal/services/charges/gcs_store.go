@@ -0,0 +1,73 @@
+package charges
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+)
+
+// GCSStore keeps the ledger in one JSON object. The object generation is the
+// ledger version, writes are conditioned on it so Cloud Run instances never
+// overwrite each other.
+type GCSStore struct {
+	object *storage.ObjectHandle
+}
+
+func NewGCSStore(client *storage.Client, bucket, object string) *GCSStore {
+	return &GCSStore{object: client.Bucket(bucket).Object(object)}
+}
+
+func (s *GCSStore) Load(ctx context.Context) (*Ledger, int64, error) {
+	rc, err := s.object.NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return newLedger(), 0, nil
+	}
+	if err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to read the charges: %v", err))
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to read the charges: %v", err))
+	}
+
+	ledger := &Ledger{}
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to decode the charges: %v", err))
+	}
+	return ledger, rc.Attrs.Generation, nil
+}
+
+func (s *GCSStore) Save(ctx context.Context, ledger *Ledger, version int64) error {
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to encode the charges: %v", err))
+	}
+
+	conditions := storage.Conditions{GenerationMatch: version}
+	if version == 0 {
+		conditions = storage.Conditions{DoesNotExist: true}
+	}
+
+	wc := s.object.If(conditions).NewWriter(ctx)
+	wc.ContentType = "application/json"
+	if _, err := wc.Write(data); err != nil {
+		wc.Close()
+		return errors.New(fmt.Sprintf("Failed to write the charges: %v", err))
+	}
+	if err := wc.Close(); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			return ErrConflict
+		}
+		return errors.New(fmt.Sprintf("Failed to write the charges: %v", err))
+	}
+	return nil
+}
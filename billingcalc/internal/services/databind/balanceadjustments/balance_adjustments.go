@@ -7,7 +7,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
@@ -22,6 +22,18 @@ const (
 	ColStakingAdjustment databind.Column = 18
 )
 
+// Schema finds the columns of the balance adjustments export by their header.
+var Schema = databind.Schema{
+	{Col: ColOrganization, Header: "Operations Organization Name"},
+	{Col: ColAccount, Header: "Operations Account Name"},
+	{Col: ColBusinessDay, Header: "Business Day", Aliases: []string{"Operations Business Day"}, DateFormat: date.MonthFirst},
+	{Col: ColOperation, Header: "Operations Type"},
+	{Col: ColAsset, Header: "Operations Asset Type"},
+	{Col: ColTotalUSD, Header: "Operations Total USD Value"},
+	{Col: ColAssetQuantity, Header: "Operations Total Asset Quantity", Optional: true},
+	{Col: ColStakingAdjustment, Header: "Operations Staking Adjustment", Aliases: []string{"Staking Adjustment", "Staking Adjustment?"}, Optional: true},
+}
+
 type BalanceAdjustments struct {
 	Organizations map[string]Organization
 }
@@ -61,20 +73,24 @@ func isEmptyField(row []string, requiredFields []databind.Column) bool {
 	return false
 }
 
-func NewBalanceAdjustments(table [][]string) *BalanceAdjustments {
+// NewBalanceAdjustments binds the data rows, skipping the incomplete ones.
+// Every bad value is collected and returned together as a
+// *databind.ParseErrors.
+func NewBalanceAdjustments(table [][]string) (*BalanceAdjustments, error) {
 	balanceAdjustments := &BalanceAdjustments{
 		Organizations: make(map[string]Organization),
 	}
+	errs := &databind.ParseErrors{}
 
 	requiredFields := []databind.Column{ColOrganization, ColAccount, ColAsset, ColBusinessDay}
-	for _, row := range table {
+	for i, row := range table {
 		if isEmptyField(row, requiredFields) {
 			continue
 		}
+		c := databind.Cells{Row: row, Line: i + 1, Schema: Schema, Errs: errs}
 		organizationName := sanitization.SanitizeName(row[ColOrganization])
 		accountName := sanitization.SanitizeName(row[ColAccount])
 		assetName := row[ColAsset]
-		businessDay := row[ColBusinessDay]
 
 		org, exists := balanceAdjustments.Organizations[organizationName]
 		if !exists {
@@ -101,8 +117,8 @@ func NewBalanceAdjustments(table [][]string) *BalanceAdjustments {
 		}
 
 		DailyBalanceAdjustments := DailyBalanceAdjustments{
-			UsdValue:          converter.FromStrToDecimal(row[ColTotalUSD]),
-			BusinessDay:       converter.FromStrToTimeStamp(businessDay),
+			UsdValue:          c.Decimal(ColTotalUSD),
+			BusinessDay:       c.Date(ColBusinessDay),
 			OperationType:     row[ColOperation],
 			StakingAdjustment: row[ColStakingAdjustment],
 		}
@@ -112,7 +128,12 @@ func NewBalanceAdjustments(table [][]string) *BalanceAdjustments {
 		org.Accounts[accountName] = acc
 		balanceAdjustments.Organizations[organizationName] = org
 	}
-	return balanceAdjustments
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return balanceAdjustments, nil
 }
 
 func (b *Asset) GetSortedDailyBalanceAdjustments() []DailyBalanceAdjustments {
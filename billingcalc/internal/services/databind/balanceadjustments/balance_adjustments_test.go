@@ -18,7 +18,8 @@ func TestNewBalanceAdjustments(t *testing.T) {
 		{"Org1", "Account1", "", "", "2023-01-02", "Operation2", "Asset1", "", "", "", "", "", "", "", "", "", "150.00", "10", "No"},
 	}
 
-	balanceAdjustments := balanceadjustments.NewBalanceAdjustments(table)
+	balanceAdjustments, err := balanceadjustments.NewBalanceAdjustments(table)
+	assert.NoError(t, err)
 
 	assert.NotNil(t, balanceAdjustments.Organizations["Org1"])
 	assert.NotNil(t, balanceAdjustments.Organizations["Org1"].Accounts["Account1"])
@@ -46,7 +47,8 @@ func TestNewBalanceAdjustments_DuplicateEntries(t *testing.T) {
 		{"Org1", "Account1", "", "", "2023-01-01", "Operation1", "Asset1", "", "", "", "", "", "", "", "", "", "100.00", "5", "Yes"},
 	}
 
-	balanceAdjustments := balanceadjustments.NewBalanceAdjustments(table)
+	balanceAdjustments, err := balanceadjustments.NewBalanceAdjustments(table)
+	assert.NoError(t, err)
 
 	assert.Equal(t, 1, len(balanceAdjustments.Organizations["Org1"].Accounts["Account1"].Assets["Asset1"].DailyBalanceAdjustments))
 }
@@ -57,7 +59,8 @@ func TestNewBalanceAdjustments_MultipleOrgsAndAccounts(t *testing.T) {
 		{"Org2", "Account2", "", "", "2023-01-02", "Operation2", "Asset2", "", "", "", "", "", "", "", "", "", "200.00", "10", "No"},
 	}
 
-	balanceAdjustments := balanceadjustments.NewBalanceAdjustments(table)
+	balanceAdjustments, err := balanceadjustments.NewBalanceAdjustments(table)
+	assert.NoError(t, err)
 
 	assert.NotNil(t, balanceAdjustments.Organizations["Org1"])
 	assert.NotNil(t, balanceAdjustments.Organizations["Org2"])
@@ -109,7 +112,8 @@ func TestNewBalanceAdjustmentsWithEmptyRequiredField(t *testing.T) {
 		{"Organization2", "Account1", "", "", "2023-01-01", "Operation2", "Asset2", "", "", "", "", "", "", "", "", "", "100.00", "5", "Y"},
 	}
 
-	balanceAdjustments := balanceadjustments.NewBalanceAdjustments(table)
+	balanceAdjustments, err := balanceadjustments.NewBalanceAdjustments(table)
+	assert.NoError(t, err)
 
 	_, org1Exists := balanceAdjustments.Organizations["Organization1"]
 	_, org2Exists := balanceAdjustments.Organizations["Organization2"]
@@ -126,7 +130,8 @@ func TestSumDailyBalanceAdjustments(t *testing.T) {
 		{"Org1", "Account1", "", "", "2023-01-03", "Operation2", "Asset2", "", "", "", "", "", "", "", "", "", "150.00", "10", "N"},
 	}
 
-	balanceAdjustments := balanceadjustments.NewBalanceAdjustments(table)
+	balanceAdjustments, err := balanceadjustments.NewBalanceAdjustments(table)
+	assert.NoError(t, err)
 
 	expected := decimal.NewFromFloat(300.00)
 	result := balanceAdjustments.SumDailyBalanceAdjustments("Org1", "Account1", "Asset1")
@@ -0,0 +1,141 @@
+package balanceadjustmentsbq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustments"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/slices"
+)
+
+// ColAccountInternalID is not part of the spreadsheet export. It is appended
+// after the report columns so AssignMfrAccounts can resolve the MFR names.
+const ColAccountInternalID databind.Column = 19
+
+type BalanceAdjustments struct {
+	OPERATIONS_ORGANIZATION_NAME    bigquery.NullString `bigquery:"operations_organization_name"`
+	OPERATIONS_ACCOUNT_NAME         bigquery.NullString `bigquery:"operations_account_name"`
+	BUSINESS_DAY                    bigquery.NullString `bigquery:"business_day"`
+	OPERATIONS_TYPE                 bigquery.NullString `bigquery:"operations_type"`
+	OPERATIONS_ASSET_TYPE           bigquery.NullString `bigquery:"operations_asset_type"`
+	OPERATIONS_TOTAL_USD_VALUE      bigquery.NullString `bigquery:"operations_total_usd_value"`
+	OPERATIONS_TOTAL_ASSET_QUANTITY bigquery.NullString `bigquery:"operations_total_asset_quantity"`
+	OPERATIONS_STAKING_ADJUSTMENT   bigquery.NullString `bigquery:"operations_staking_adjustment"`
+	OPERATIONS_ACCOUNT_INTERNAL_ID  bigquery.NullString `bigquery:"operations_client_internal_id"`
+}
+
+func GetDataQuery(day_start time.Time, day_end time.Time) string {
+	day_start_str := day_start.Format(time.RFC3339)
+	day_end_str := day_end.Format(time.RFC3339)
+
+	// Only reward adjustments change the staking rewards, they are flagged
+	// the same way Finance flags them in the "Staking adjustment?" column.
+	query := fmt.Sprintf(`
+	WITH operations AS (SELECT
+		ops.* EXCEPT (account_name, organization_name),
+		COALESCE(dp.parent_affiliate_id, a.affiliate_id) AS client_internal_id,
+		orgs.org_name AS organization_name,
+		a.name AS account_name
+	  FROM
+		client_operations.operations_confidential ops
+	  LEFT JOIN
+		client_operations.accounts_confidential a
+	  ON
+		ops.account_id = a.account_id
+	  LEFT JOIN
+		kyc_operations.duplicate_affiliates dp
+	  ON
+		a.affiliate_id = dp.affiliate_id
+	  LEFT JOIN
+		client_operations.organizations_confidential orgs
+	  ON
+		ops.org_id = orgs.org_id
+	  WHERE
+		((( ops.end_time ) >= (TIMESTAMP('%s')) AND ( ops.end_time ) < (TIMESTAMP('%s'))))
+	)
+	SELECT
+		operations.organization_name  AS operations_organization_name,
+		operations.account_name  AS operations_account_name,
+		(FORMAT_TIMESTAMP('%%F', operations.end_time )) AS business_day,
+		operations.type  AS operations_type,
+		shared.ASSET_SYMBOL_MAP(operations.asset_type) AS operations_asset_type,
+		operations.client_internal_id AS operations_client_internal_id,
+		IF(operations.type = 'Reward Adjustment', 'Y', 'N') AS operations_staking_adjustment,
+		CAST(COALESCE(SUM(operations.asset_usd_value ), 0) as STRING) AS operations_total_usd_value,
+		CAST(COALESCE(SUM(operations.asset_quantity ), 0) as STRING) AS operations_total_asset_quantity
+		FROM operations
+	WHERE (operations.operation_state ) = 'COMPLETE' AND (operations.type ) IN ('Balance Adjustment', 'Reward Adjustment')
+	GROUP BY
+		1,
+		2,
+		3,
+		4,
+		5,
+		6,
+		7
+	ORDER BY
+		1,
+		2,
+		3 DESC,
+		4,
+		5
+	`, day_start_str, day_end_str)
+
+	return query
+}
+
+// StructToSlice converts the query rows into the "Balance Adjustments Report"
+// layout, plus the account internal ID in ColAccountInternalID.
+func StructToSlice(iter bigqueryutils.ResultIterator) ([][]string, error) {
+	table := [][]string{}
+
+	for {
+		bigqueryRow := BalanceAdjustments{}
+		err := iter.Next(&bigqueryRow)
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			errorMessage := "Error iterating over. " + err.Error()
+			return nil, errors.New(errorMessage)
+		}
+
+		newRow := []string{}
+
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColOrganization), bigqueryRow.OPERATIONS_ORGANIZATION_NAME.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColAccount), bigqueryRow.OPERATIONS_ACCOUNT_NAME.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColBusinessDay), bigqueryRow.BUSINESS_DAY.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColOperation), bigqueryRow.OPERATIONS_TYPE.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColAsset), bigqueryRow.OPERATIONS_ASSET_TYPE.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColTotalUSD), bigqueryRow.OPERATIONS_TOTAL_USD_VALUE.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColAssetQuantity), bigqueryRow.OPERATIONS_TOTAL_ASSET_QUANTITY.StringVal)
+		newRow = slices.Insert(newRow, int(balanceadjustments.ColStakingAdjustment), bigqueryRow.OPERATIONS_STAKING_ADJUSTMENT.StringVal)
+		newRow = slices.Insert(newRow, int(ColAccountInternalID), bigqueryRow.OPERATIONS_ACCOUNT_INTERNAL_ID.StringVal)
+
+		table = append(table, newRow)
+	}
+
+	return table, nil
+}
+
+// AssignMfrAccounts replaces the organization and account names of each row
+// with the MFR ones, looked up by the account internal ID, since the staking
+// calculation matches the adjustments by MFR names. Rows whose account is not
+// in the MFR keep the names from BigQuery.
+func AssignMfrAccounts(table [][]string, lookup func(accountId string) (orgName string, accountName string, ok bool)) [][]string {
+	for _, row := range table {
+		if orgName, accountName, ok := lookup(row[ColAccountInternalID]); ok {
+			row[balanceadjustments.ColOrganization] = orgName
+			row[balanceadjustments.ColAccount] = accountName
+		}
+	}
+
+	return table
+}
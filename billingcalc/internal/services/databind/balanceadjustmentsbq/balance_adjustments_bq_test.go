@@ -0,0 +1,119 @@
+//go:build !selectTest || unitTest
+
+package balanceadjustmentsbq_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustmentsbq"
+)
+
+type MockResultIterator struct {
+	Data  []balanceadjustmentsbq.BalanceAdjustments
+	Index int
+}
+
+// Mock for the Big Querry Iterator
+func (m *MockResultIterator) Next(dst interface{}) error {
+	if m.Index >= len(m.Data) {
+		return iterator.Done
+	}
+	result, ok := dst.(*balanceadjustmentsbq.BalanceAdjustments)
+	if !ok {
+		return errors.New("type assertion to *balanceadjustmentsbq.BalanceAdjustments failed")
+	}
+	*result = m.Data[m.Index]
+	m.Index++
+	return nil
+}
+
+func TestGetDataQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	got := balanceadjustmentsbq.GetDataQuery(start, end)
+
+	assert.Contains(t, got, "operations_confidential")
+	assert.Contains(t, got, "duplicate_affiliates")
+	assert.Contains(t, got, "orgs.org_name")
+
+	assert.Contains(t, got, start.Format(time.RFC3339))
+	assert.Contains(t, got, end.Format(time.RFC3339))
+
+	assert.Contains(t, got, "IN ('Balance Adjustment', 'Reward Adjustment')")
+}
+
+func TestStructToSlice(t *testing.T) {
+	mockData := []balanceadjustmentsbq.BalanceAdjustments{
+		{
+			OPERATIONS_ORGANIZATION_NAME:    bigquery.NullString{StringVal: "Org1", Valid: true},
+			OPERATIONS_ACCOUNT_NAME:         bigquery.NullString{StringVal: "Account1", Valid: true},
+			BUSINESS_DAY:                    bigquery.NullString{StringVal: "2024-01-01", Valid: true},
+			OPERATIONS_TYPE:                 bigquery.NullString{StringVal: "Reward Adjustment", Valid: true},
+			OPERATIONS_ASSET_TYPE:           bigquery.NullString{StringVal: "ATOM", Valid: true},
+			OPERATIONS_TOTAL_USD_VALUE:      bigquery.NullString{StringVal: "100.00", Valid: true},
+			OPERATIONS_TOTAL_ASSET_QUANTITY: bigquery.NullString{StringVal: "10", Valid: true},
+			OPERATIONS_STAKING_ADJUSTMENT:   bigquery.NullString{StringVal: "Y", Valid: true},
+			OPERATIONS_ACCOUNT_INTERNAL_ID:  bigquery.NullString{StringVal: "AccId1", Valid: true},
+		},
+	}
+
+	iter := &MockResultIterator{Data: mockData}
+
+	got, err := balanceadjustmentsbq.StructToSlice(iter)
+	if err != nil {
+		t.Fatalf("StructToSlice returned an error: %v", err)
+	}
+
+	expected := [][]string{
+		{
+			"Org1",
+			"Account1",
+			"",
+			"",
+			"2024-01-01",
+			"Reward Adjustment",
+			"ATOM",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"100.00",
+			"10",
+			"Y",
+			"AccId1",
+		},
+	}
+
+	assert.NoError(t, err, "StructToSlice should not return an error")
+	assert.Equal(t, expected, got, "Expected and actual outcomes should match")
+}
+
+func TestAssignMfrAccounts(t *testing.T) {
+	table := [][]string{
+		{"Org1", "Account1", "", "", "2024-01-01", "Reward Adjustment", "ATOM", "", "", "", "", "", "", "", "", "", "100.00", "10", "Y", "AccId1"},
+		{"Org2", "Account2", "", "", "2024-01-01", "Reward Adjustment", "ATOM", "", "", "", "", "", "", "", "", "", "100.00", "10", "Y", "Unknown"},
+	}
+
+	got := balanceadjustmentsbq.AssignMfrAccounts(table, func(accountId string) (string, string, bool) {
+		if accountId == "AccId1" {
+			return "mfrorg1", "mfraccount1", true
+		}
+		return "", "", false
+	})
+
+	assert.Equal(t, "mfrorg1", got[0][0])
+	assert.Equal(t, "mfraccount1", got[0][1])
+	assert.Equal(t, "Org2", got[1][0], "Accounts not found in the MFR should keep the BigQuery names")
+	assert.Equal(t, "Account2", got[1][1])
+}
@@ -0,0 +1,67 @@
+package databind
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+// Cells reads the values of one bound row, collecting every bad value in Errs
+// so the whole report is checked in one pass. Line is the row number reported
+// in the errors, see ParseErrors.Locate.
+type Cells struct {
+	Row    []string
+	Line   int
+	Report string
+	Schema Schema
+	Errs   *ParseErrors
+}
+
+// Decimal reads col with ParseDecimal, a bad value is reported and read as 0.
+func (c Cells) Decimal(col Column) decimal.Decimal {
+	d, err := ParseDecimal(c.Row[col])
+	if err != nil {
+		c.fail(col, err.Error())
+	}
+	return d
+}
+
+// Percent reads col with ParsePercent, a bad value is reported and read as 0.
+func (c Cells) Percent(col Column) decimal.Decimal {
+	d, err := ParsePercent(c.Row[col])
+	if err != nil {
+		c.fail(col, err.Error())
+	}
+	return d
+}
+
+// Int reads col with ParseInt, a bad value is reported and read as 0.
+func (c Cells) Int(col Column) int {
+	i, err := ParseInt(c.Row[col])
+	if err != nil {
+		c.fail(col, err.Error())
+	}
+	return i
+}
+
+// Date reads the day of col in the DateFormat the schema declares for it, a
+// bad value is reported and read as the zero time.
+func (c Cells) Date(col Column) time.Time {
+	t, err := date.Parse(c.Row[col], c.Schema.spec(col).DateFormat)
+	if err != nil {
+		c.fail(col, err.Error())
+	}
+	return t
+}
+
+func (c Cells) fail(col Column, reason string) {
+	c.Errs.Add(CellError{
+		Report: c.Report,
+		Row:    c.Line,
+		Column: c.Schema.Header(col),
+		Value:  c.Row[col],
+		Reason: reason,
+	})
+}
@@ -2,37 +2,60 @@
 // "Daily Balances Report" spreadsheet.
 //
 // Notice that even though it has "daily" in its name, the spreadsheet actually
-// contains only the average daily balance from a given period of time.
+// contains only the average daily balance from a given period of time. The
+// BigQuery export has one row per day instead, with its date.
 package dailybalances
 
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
 const (
-	colMsaID                      databind.Column = 0
-	colOrgName                    databind.Column = 1
-	colAccountName                databind.Column = 2
-	colAnchorEntity               databind.Column = 3
-	colOrgId                      databind.Column = 4
-	colAssetName                  databind.Column = 5
-	colAccountId                  databind.Column = 6
-	colDailyAssetTotal            databind.Column = 7
-	colDailyAssetPrice            databind.Column = 8
-	colDailyUsdTotal              databind.Column = 9
-	colDailyTotalFromAddresses    databind.Column = 10
-	colUnclaimedRewardsBalanceUsd databind.Column = 11
-	colTotalAucUsd                databind.Column = 12
-	colIsGraduated                databind.Column = 13
+	ColMsaID                      databind.Column = 0
+	ColOrgName                    databind.Column = 1
+	ColAccountName                databind.Column = 2
+	ColAnchorEntity               databind.Column = 3
+	ColOrgId                      databind.Column = 4
+	ColAssetName                  databind.Column = 5
+	ColAccountId                  databind.Column = 6
+	ColDailyAssetTotal            databind.Column = 7
+	ColDailyAssetPrice            databind.Column = 8
+	ColDailyUsdTotal              databind.Column = 9
+	ColDailyTotalFromAddresses    databind.Column = 10
+	ColUnclaimedRewardsBalanceUsd databind.Column = 11
+	ColTotalAucUsd                databind.Column = 12
+	ColIsGraduated                databind.Column = 13
+	ColDate                       databind.Column = 14
 )
 
+// Schema finds the columns of the daily balances export by their header. The
+// aliases are the names used by the Looker export.
+var Schema = databind.Schema{
+	{Col: ColMsaID, Header: "MSA ID", Aliases: []string{"MSA ID (from MFRS)"}},
+	{Col: ColOrgName, Header: "Org Name", Aliases: []string{"Daily Balances (AUC) Org Name"}, Optional: true},
+	{Col: ColAccountName, Header: "Account Name", Aliases: []string{"Daily Balances (AUC) Account Name"}},
+	{Col: ColAnchorEntity, Header: "Anchorage Entity", Aliases: []string{"Daily Balances (AUC) Anchorage Entity"}, Optional: true},
+	{Col: ColOrgId, Header: "Org ID", Aliases: []string{"Daily Balances (AUC) Org ID"}, Optional: true},
+	{Col: ColAssetName, Header: "Asset Type", Aliases: []string{"Daily Balances (AUC) Asset Type"}},
+	{Col: ColAccountId, Header: "Account Internal ID", Aliases: []string{"Daily Balances (AUC) Account Internal ID"}, Optional: true},
+	{Col: ColDailyAssetTotal, Header: "Total Quantity", Aliases: []string{"Daily Balances (AUC) Total Quantity"}},
+	{Col: ColDailyAssetPrice, Header: "Unit Price USD", Aliases: []string{"Daily Balances (AUC) Unit Price USD"}, Optional: true},
+	{Col: ColDailyUsdTotal, Header: "Total USD Value", Aliases: []string{"Daily Balances (AUC) Total USD Value"}},
+	{Col: ColDailyTotalFromAddresses, Header: "Total Quantity From Addresses", Aliases: []string{"Daily Balances (AUC) Total Quantity From Addresses"}, Optional: true},
+	{Col: ColUnclaimedRewardsBalanceUsd, Header: "Unclaimed Rewards USD", Aliases: []string{"Unclaimed rewards balances (and other adj) in USD"}},
+	{Col: ColTotalAucUsd, Header: "Total AUC in USD", Aliases: []string{"Total AUC in USD (including unclaimed rewards)"}},
+	{Col: ColIsGraduated, Header: "Graduated tier?", Optional: true},
+	{Col: ColDate, Header: "Date", Aliases: []string{"Daily Balances (AUC) Date", "Daily Balances Date"}, Optional: true, DateFormat: date.MonthFirst},
+}
+
 type DailyBalance struct {
 	organizations map[string]organization
 }
@@ -50,17 +73,40 @@ type Balance struct {
 	UsdBalance                 decimal.Decimal
 	UnclaimedRewardsBalanceUsd decimal.Decimal
 	TotalAucUsd                decimal.Decimal
+	// DailyAucUsd is the Total AUC in USD of each day, nil when a row of the
+	// balance has no date.
+	DailyAucUsd map[time.Time]decimal.Decimal
+	rows        int
+}
+
+// TotalAucUsdBetween sums the Total AUC in USD of the days from first to last,
+// both included. ok is false when a row of the balance has no date, the days
+// its total was held are not known then.
+func (b Balance) TotalAucUsdBetween(first, last time.Time) (total decimal.Decimal, ok bool) {
+	if b.DailyAucUsd == nil {
+		return decimal.Zero, false
+	}
+	for day, auc := range b.DailyAucUsd {
+		if !day.Before(first) && !day.After(last) {
+			total = total.Add(auc)
+		}
+	}
+	return total, true
 }
 
-func NewDailyBalance(table [][]string) *DailyBalance {
+// NewDailyBalance binds the data rows. Every bad value is collected and
+// returned together as a *databind.ParseErrors.
+func NewDailyBalance(table [][]string) (*DailyBalance, error) {
 	db := DailyBalance{
 		organizations: make(map[string]organization),
 	}
+	errs := &databind.ParseErrors{}
 
-	for _, row := range table {
-		msaID := row[colMsaID]
-		accountName := sanitization.SanitizeName(row[colAccountName])
-		assetName := row[colAssetName]
+	for i, row := range table {
+		c := databind.Cells{Row: row, Line: i + 1, Schema: Schema, Errs: errs}
+		msaID := row[ColMsaID]
+		accountName := sanitization.SanitizeName(row[ColAccountName])
+		assetName := row[ColAssetName]
 
 		if _, ok := db.organizations[msaID]; !ok {
 			db.organizations[msaID] = organization{
@@ -77,20 +123,35 @@ func NewDailyBalance(table [][]string) *DailyBalance {
 			db.organizations[msaID].accounts[accountName].balances[assetName] = Balance{}
 		}
 
-		prevAssetBalance := db.organizations[msaID].accounts[accountName].balances[assetName].AssetBalance
-		prevUsdBalance := db.organizations[msaID].accounts[accountName].balances[assetName].UsdBalance
-		prevUnclaimedRewardsBalanceUsd := db.organizations[msaID].accounts[accountName].balances[assetName].UnclaimedRewardsBalanceUsd
-		prevTotalAucUsd := db.organizations[msaID].accounts[accountName].balances[assetName].TotalAucUsd
+		prev := db.organizations[msaID].accounts[accountName].balances[assetName]
+		totalAucUsd := c.Decimal(ColTotalAucUsd)
 
-		db.organizations[msaID].accounts[accountName].balances[assetName] = Balance{
-			AssetBalance:               prevAssetBalance.Add(converter.FromStrToDecimal(row[colDailyAssetTotal])),
-			UsdBalance:                 prevUsdBalance.Add(converter.FromStrToDecimal(row[colDailyUsdTotal])),
-			UnclaimedRewardsBalanceUsd: prevUnclaimedRewardsBalanceUsd.Add(converter.FromStrToDecimal(row[colUnclaimedRewardsBalanceUsd])),
-			TotalAucUsd:                prevTotalAucUsd.Add(converter.FromStrToDecimal(row[colTotalAucUsd])),
+		balance := Balance{
+			AssetBalance:               prev.AssetBalance.Add(c.Decimal(ColDailyAssetTotal)),
+			UsdBalance:                 prev.UsdBalance.Add(c.Decimal(ColDailyUsdTotal)),
+			UnclaimedRewardsBalanceUsd: prev.UnclaimedRewardsBalanceUsd.Add(c.Decimal(ColUnclaimedRewardsBalanceUsd)),
+			TotalAucUsd:                prev.TotalAucUsd.Add(totalAucUsd),
+			rows:                       prev.rows + 1,
+		}
+		// The spreadsheet export has no date, such a balance is only known
+		// as a total over the period.
+		dated := int(ColDate) < len(row) && row[ColDate] != ""
+		if dated && (prev.rows == 0 || prev.DailyAucUsd != nil) {
+			balance.DailyAucUsd = prev.DailyAucUsd
+			if balance.DailyAucUsd == nil {
+				balance.DailyAucUsd = make(map[time.Time]decimal.Decimal)
+			}
+			day := c.Date(ColDate)
+			balance.DailyAucUsd[day] = balance.DailyAucUsd[day].Add(totalAucUsd)
 		}
+		db.organizations[msaID].accounts[accountName].balances[assetName] = balance
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 
-	return &db
+	return &db, nil
 }
 
 func (db *DailyBalance) GetAssetBalance(msaID, accountName, assetName string) (decimal.Decimal, error) {
@@ -6,6 +6,7 @@ import (
 	"encoding/csv"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +35,8 @@ func TestNewDailyBalance(t *testing.T) {
 		t.Fatalf("Failed to read the CSV file: %v", err)
 	}
 
-	db := dailybalances.NewDailyBalance(table)
+	db, err := dailybalances.NewDailyBalance(table)
+	assert.NoError(t, err)
 	var msaId, acc, asset string
 	var expected decimal.Decimal
 
@@ -85,7 +87,8 @@ func TestGetAccountBalances(t *testing.T) {
 		t.Fatalf("Failed to read the CSV file: %v", err)
 	}
 
-	db := dailybalances.NewDailyBalance(table)
+	db, err := dailybalances.NewDailyBalance(table)
+	assert.NoError(t, err)
 
 	t.Run("Should return all balances for an specific account", func(t *testing.T) {
 		msaId := "11111" // MSAID Following mfr_test_calc.csv
@@ -137,7 +140,8 @@ func TestGetAverageUsdBalanceByOrg(t *testing.T) {
 		t.Fatal("Error while reading Daily Balances CSV.")
 	}
 
-	db := dailybalances.NewDailyBalance(dbTable[1:]) // skip header
+	db, err := dailybalances.NewDailyBalance(dbTable[1:]) // skip header
+	assert.NoError(t, err)
 	daysInInvoiceDate := 30
 
 	testCases := []struct {
@@ -162,3 +166,37 @@ func TestGetAverageUsdBalanceByOrg(t *testing.T) {
 		}
 	}
 }
+
+func TestTotalAucUsdBetween(t *testing.T) {
+	row := func(date, auc string) []string {
+		return []string{"22222", "", "Account", "", "", "SOL", "", "1", "", auc, "", "0", auc, "", date}
+	}
+	june := func(day int) time.Time { return time.Date(2023, time.June, day, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Should sum the days between both dates", func(t *testing.T) {
+		db, err := dailybalances.NewDailyBalance([][]string{row("2023-06-01", "100"), row("2023-06-15", "200"), row("6/30/2023", "400")})
+		assert.NoError(t, err)
+		balances, err := db.GetAccountBalances("22222", "Account")
+		assert.NoError(t, err)
+
+		total, ok := balances["SOL"].TotalAucUsdBetween(june(15), june(30))
+		assert.True(t, ok)
+		assert.Equal(t, "600", total.String())
+		assert.Equal(t, "700", balances["SOL"].TotalAucUsd.String())
+	})
+
+	t.Run("Should not tell the days of a balance with an undated row", func(t *testing.T) {
+		db, err := dailybalances.NewDailyBalance([][]string{row("2023-06-01", "100"), row("", "200")})
+		assert.NoError(t, err)
+		balances, err := db.GetAccountBalances("22222", "Account")
+		assert.NoError(t, err)
+
+		_, ok := balances["SOL"].TotalAucUsdBetween(june(1), june(30))
+		assert.False(t, ok)
+	})
+
+	t.Run("Should report a bad date", func(t *testing.T) {
+		_, err := dailybalances.NewDailyBalance([][]string{row("June 1st", "100")})
+		assert.Error(t, err)
+	})
+}
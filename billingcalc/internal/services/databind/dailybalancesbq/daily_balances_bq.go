@@ -0,0 +1,177 @@
+package dailybalancesbq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/slices"
+)
+
+type DailyBalances struct {
+	DAILY_BALANCES_DATE               bigquery.NullString `bigquery:"daily_balances_date"`
+	ORGANIZATIONS_ORG_NAME            bigquery.NullString `bigquery:"organizations_org_name"`
+	DAILY_BALANCES_ACCOUNT_NAME       bigquery.NullString `bigquery:"daily_balances_account_name"`
+	DAILY_BALANCES_ANCHORAGE_ENTITY   bigquery.NullString `bigquery:"daily_balances_anchorage_entity"`
+	DAILY_BALANCES_ORG_ID             bigquery.NullString `bigquery:"daily_balances_org_id"`
+	DAILY_BALANCES_ASSET_TYPE         bigquery.NullString `bigquery:"daily_balances_asset_type"`
+	DAILY_BALANCES_CLIENT_INTERNAL_ID bigquery.NullString `bigquery:"daily_balances_client_internal_id"`
+	DAILY_BALANCES_TOTAL_QUANTITY     bigquery.NullString `bigquery:"daily_balances_total_quantity"`
+	DAILY_BALANCES_UNIT_PRICE_USD     bigquery.NullString `bigquery:"daily_balances_unit_price_usd"`
+	DAILY_BALANCES_TOTAL_USD_VALUE    bigquery.NullString `bigquery:"daily_balances_total_usd_value"`
+	UNCLAIMED_REWARDS_USD_VALUE       bigquery.NullString `bigquery:"unclaimed_rewards_usd_value"`
+	TOTAL_AUC_USD_VALUE               bigquery.NullString `bigquery:"total_auc_usd_value"`
+}
+
+func GetDataQuery(business_day_start time.Time, business_day_end time.Time) string {
+	business_day_start_str := business_day_start.Format(time.RFC3339)
+	business_day_end_str := business_day_end.Format(time.RFC3339)
+
+	query := fmt.Sprintf(`
+	WITH daily_balances AS (SELECT
+		db.date,
+		db.asset_type,
+		db.quantity,
+		db.unit_price_usd,
+		a.name AS account_name,
+		a.anchorage_entity,
+		a.org_id,
+		COALESCE(dp.parent_affiliate_id, a.affiliate_id) AS client_internal_id
+	  FROM
+		client_operations.daily_balances_confidential AS db
+	  LEFT JOIN
+		client_operations.accounts_confidential AS a
+	  ON
+		db.account_id = a.account_id
+	  LEFT JOIN
+		kyc_operations.duplicate_affiliates AS dp
+	  ON
+		a.affiliate_id = dp.affiliate_id
+	  WHERE
+		((( db.date ) >= (DATE('%s')) AND ( db.date ) < (DATE('%s'))))
+	)
+	, unclaimed_rewards AS (SELECT
+		hdbb.business_day,
+		shared.ASSET_SYMBOL_MAP(hdbb.asset_type_id) AS asset_type,
+		COALESCE(dp.parent_affiliate_id, a.affiliate_id) AS client_internal_id,
+		SUM(CAST(hdbb.balance_str AS FLOAT64)) AS quantity
+	  FROM
+		adb_operations.historic_daily_blockchain_balances AS hdbb
+	  LEFT JOIN
+		client_operations.address_details AS addresses
+	  ON
+		hdbb.address = addresses.address
+	  LEFT JOIN
+		client_operations.vaults_metadata AS vm
+	  ON
+		addresses.organization_key_id = vm.organization_key_id
+		AND addresses.vault_sub_id = vm.vault_sub_id
+	  LEFT JOIN
+		client_operations.accounts_confidential AS a
+	  ON
+		vm.system_account_id = a.account_id
+	  LEFT JOIN
+		kyc_operations.duplicate_affiliates AS dp
+	  ON
+		a.affiliate_id = dp.affiliate_id
+	  WHERE
+		hdbb.balance_type = 'DELEGATION_REWARDS'
+		AND ((( hdbb.business_day ) >= (DATE('%s')) AND ( hdbb.business_day ) < (DATE('%s'))))
+	  GROUP BY
+		1,
+		2,
+		3
+	)
+	SELECT
+		CAST(daily_balances.date AS STRING) AS daily_balances_date,
+		organizations.org_name AS organizations_org_name,
+		daily_balances.account_name AS daily_balances_account_name,
+		daily_balances.anchorage_entity AS daily_balances_anchorage_entity,
+		daily_balances.org_id AS daily_balances_org_id,
+		shared.ASSET_SYMBOL_MAP(daily_balances.asset_type) AS daily_balances_asset_type,
+		daily_balances.client_internal_id AS daily_balances_client_internal_id,
+		CAST(SUM(daily_balances.quantity) AS STRING) AS daily_balances_total_quantity,
+		CAST(AVG(daily_balances.unit_price_usd) AS STRING) AS daily_balances_unit_price_usd,
+		CAST(ROUND(SUM(daily_balances.quantity * daily_balances.unit_price_usd), 8) AS STRING) AS daily_balances_total_usd_value,
+		CAST(ROUND(COALESCE(ANY_VALUE(unclaimed_rewards.quantity), 0) * AVG(daily_balances.unit_price_usd), 8) AS STRING) AS unclaimed_rewards_usd_value,
+		CAST(ROUND(SUM(daily_balances.quantity * daily_balances.unit_price_usd) + COALESCE(ANY_VALUE(unclaimed_rewards.quantity), 0) * AVG(daily_balances.unit_price_usd), 8) AS STRING) AS total_auc_usd_value
+	FROM daily_balances
+	LEFT JOIN client_operations.organizations_confidential AS organizations ON daily_balances.org_id = organizations.org_id
+	LEFT JOIN unclaimed_rewards ON (
+		daily_balances.date = unclaimed_rewards.business_day
+		AND shared.ASSET_SYMBOL_MAP(daily_balances.asset_type) = unclaimed_rewards.asset_type
+		AND daily_balances.client_internal_id = unclaimed_rewards.client_internal_id
+	)
+	GROUP BY
+		1,
+		2,
+		3,
+		4,
+		5,
+		6,
+		7
+	ORDER BY
+		1 DESC
+	`, business_day_start_str, business_day_end_str, business_day_start_str, business_day_end_str)
+
+	return query
+}
+
+// StructToSlice converts the query rows into the "Daily Balances Report" layout.
+// BigQuery does not know the MSA ID of an account, so that column is left
+// empty and must be filled with AssignMfrAccounts.
+func StructToSlice(iter bigqueryutils.ResultIterator) ([][]string, error) {
+	table := [][]string{}
+
+	for {
+		bigqueryRow := DailyBalances{}
+		err := iter.Next(&bigqueryRow)
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			errorMessage := "Error iterating over. " + err.Error()
+			return nil, errors.New(errorMessage)
+		}
+
+		newRow := []string{}
+		newRow = slices.Insert(newRow, int(dailybalances.ColMsaID), "")
+		newRow = slices.Insert(newRow, int(dailybalances.ColOrgName), bigqueryRow.ORGANIZATIONS_ORG_NAME.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColAccountName), bigqueryRow.DAILY_BALANCES_ACCOUNT_NAME.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColAnchorEntity), bigqueryRow.DAILY_BALANCES_ANCHORAGE_ENTITY.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColOrgId), bigqueryRow.DAILY_BALANCES_ORG_ID.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColAssetName), bigqueryRow.DAILY_BALANCES_ASSET_TYPE.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColAccountId), bigqueryRow.DAILY_BALANCES_CLIENT_INTERNAL_ID.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColDailyAssetTotal), bigqueryRow.DAILY_BALANCES_TOTAL_QUANTITY.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColDailyAssetPrice), bigqueryRow.DAILY_BALANCES_UNIT_PRICE_USD.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColDailyUsdTotal), bigqueryRow.DAILY_BALANCES_TOTAL_USD_VALUE.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColUnclaimedRewardsBalanceUsd), bigqueryRow.UNCLAIMED_REWARDS_USD_VALUE.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColTotalAucUsd), bigqueryRow.TOTAL_AUC_USD_VALUE.StringVal)
+		newRow = slices.Insert(newRow, int(dailybalances.ColDate), bigqueryRow.DAILY_BALANCES_DATE.StringVal)
+
+		table = append(table, newRow)
+	}
+
+	return table, nil
+}
+
+// AssignMfrAccounts fills the MSA ID and the account name of each row from the
+// MFR, looked up by the account internal ID, so the balances are keyed the same
+// way as the spreadsheet export. Rows whose account is not in the MFR are kept
+// without an MSA ID and are not billed.
+func AssignMfrAccounts(table [][]string, lookup func(accountId string) (msaId string, accountName string, ok bool)) [][]string {
+	for _, row := range table {
+		if msaId, accountName, ok := lookup(row[dailybalances.ColAccountId]); ok {
+			row[dailybalances.ColMsaID] = msaId
+			row[dailybalances.ColAccountName] = accountName
+		}
+	}
+
+	return table
+}
@@ -0,0 +1,118 @@
+//go:build !selectTest || unitTest
+
+package dailybalancesbq_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalancesbq"
+)
+
+type MockResultIterator struct {
+	Data  []dailybalancesbq.DailyBalances
+	Index int
+}
+
+// Mock for the Big Querry Iterator
+func (m *MockResultIterator) Next(dst interface{}) error {
+	if m.Index >= len(m.Data) {
+		return iterator.Done
+	}
+	result, ok := dst.(*dailybalancesbq.DailyBalances)
+	if !ok {
+		return errors.New("type assertion to *dailybalancesbq.DailyBalances failed")
+	}
+	*result = m.Data[m.Index]
+	m.Index++
+	return nil
+}
+
+func TestGetDataQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	got := dailybalancesbq.GetDataQuery(start, end)
+
+	assert.Contains(t, got, "daily_balances_confidential")
+	assert.Contains(t, got, "historic_daily_blockchain_balances")
+	assert.Contains(t, got, "organizations.org_name")
+	assert.Contains(t, got, "client_internal_id")
+
+	assert.Contains(t, got, start.Format(time.RFC3339))
+	assert.Contains(t, got, end.Format(time.RFC3339))
+
+	assert.Contains(t, got, "hdbb.balance_type = 'DELEGATION_REWARDS'")
+}
+
+func TestStructToSlice(t *testing.T) {
+	mockData := []dailybalancesbq.DailyBalances{
+		{
+			DAILY_BALANCES_DATE:               bigquery.NullString{StringVal: "2024-01-01", Valid: true},
+			ORGANIZATIONS_ORG_NAME:            bigquery.NullString{StringVal: "Org1", Valid: true},
+			DAILY_BALANCES_ACCOUNT_NAME:       bigquery.NullString{StringVal: "Account1", Valid: true},
+			DAILY_BALANCES_ANCHORAGE_ENTITY:   bigquery.NullString{StringVal: "TRUST_COMPANY", Valid: true},
+			DAILY_BALANCES_ORG_ID:             bigquery.NullString{StringVal: "OrgId1", Valid: true},
+			DAILY_BALANCES_ASSET_TYPE:         bigquery.NullString{StringVal: "ETH", Valid: true},
+			DAILY_BALANCES_CLIENT_INTERNAL_ID: bigquery.NullString{StringVal: "AccId1", Valid: true},
+			DAILY_BALANCES_TOTAL_QUANTITY:     bigquery.NullString{StringVal: "10", Valid: true},
+			DAILY_BALANCES_UNIT_PRICE_USD:     bigquery.NullString{StringVal: "2000", Valid: true},
+			DAILY_BALANCES_TOTAL_USD_VALUE:    bigquery.NullString{StringVal: "20000", Valid: true},
+			UNCLAIMED_REWARDS_USD_VALUE:       bigquery.NullString{StringVal: "100", Valid: true},
+			TOTAL_AUC_USD_VALUE:               bigquery.NullString{StringVal: "20100", Valid: true},
+		},
+	}
+
+	iter := &MockResultIterator{Data: mockData}
+
+	got, err := dailybalancesbq.StructToSlice(iter)
+	if err != nil {
+		t.Fatalf("StructToSlice returned an error: %v", err)
+	}
+
+	expected := [][]string{
+		{
+			"",
+			"Org1",
+			"Account1",
+			"TRUST_COMPANY",
+			"OrgId1",
+			"ETH",
+			"AccId1",
+			"10",
+			"2000",
+			"20000",
+			"",
+			"100",
+			"20100",
+			"",
+			"2024-01-01",
+		},
+	}
+
+	assert.NoError(t, err, "StructToSlice should not return an error")
+	assert.Equal(t, expected, got, "Expected and actual outcomes should match")
+}
+
+func TestAssignMfrAccounts(t *testing.T) {
+	table := [][]string{
+		{"", "Org1", "Account1", "", "", "ETH", "AccId1", "10", "2000", "20000", "", "100", "20100"},
+		{"", "Org2", "Account2", "", "", "ETH", "Unknown", "10", "2000", "20000", "", "100", "20100"},
+	}
+
+	got := dailybalancesbq.AssignMfrAccounts(table, func(accountId string) (string, string, bool) {
+		if accountId == "AccId1" {
+			return "11111", "mfraccount1", true
+		}
+		return "", "", false
+	})
+
+	assert.Equal(t, "11111", got[0][0])
+	assert.Equal(t, "mfraccount1", got[0][2])
+	assert.Equal(t, "", got[1][0], "Accounts not found in the MFR should keep an empty MSA ID")
+	assert.Equal(t, "Account2", got[1][2])
+}
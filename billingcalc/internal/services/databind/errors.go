@@ -0,0 +1,59 @@
+package databind
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellError is a bad value found while parsing a report. Row is the row
+// number as shown in the spreadsheet and Column the header of the column.
+type CellError struct {
+	Report string `json:"report"`
+	Row    int    `json:"row"`
+	Column string `json:"column"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("%s row %d, column %q: invalid value %q: %s", e.Report, e.Row, e.Column, e.Value, e.Reason)
+}
+
+// ParseErrors collects every bad value found while parsing a report, so they
+// can all be fixed in one go instead of one at a time.
+type ParseErrors struct {
+	Errors []CellError `json:"errors"`
+}
+
+func (e *ParseErrors) Add(err CellError) {
+	e.Errors = append(e.Errors, err)
+}
+
+// Locate sets the report of the errors that have none and shifts their rows
+// by firstRow, the rows above the data. Parsers number the data rows from 1,
+// the source knows where they start in the spreadsheet.
+func (e *ParseErrors) Locate(report string, firstRow int) {
+	for i := range e.Errors {
+		if e.Errors[i].Report == "" {
+			e.Errors[i].Report = report
+			e.Errors[i].Row += firstRow
+		}
+	}
+}
+
+// Err returns e only when it holds errors, so it can be returned as an error
+// without being a non-nil interface over an empty list.
+func (e *ParseErrors) Err() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ParseErrors) Error() string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		lines = append(lines, err.Error())
+	}
+	return fmt.Sprintf("%d invalid values found:\n%s", len(e.Errors), strings.Join(lines, "\n"))
+}
@@ -7,74 +7,166 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/googlesheetsutils"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
 // MFR Columns
 const (
-	colMinimumFeeType     databind.Column = 3
-	colAccountFromRDB     databind.Column = 4
-	colEntityID           databind.Column = 7
-	colOrgName            databind.Column = 8
-	colLegalName          databind.Column = 9
-	colBillingTerms       databind.Column = 11
-	colAssetType          databind.Column = 19
-	colMinimumCharge      databind.Column = 29
-	col1stTierFloor       databind.Column = 30
-	col1stTierRate        databind.Column = 31
-	col2ndTierFloor       databind.Column = 32
-	col2ndTierRate        databind.Column = 33
-	col3rdTierFloor       databind.Column = 34
-	col3rdTierRate        databind.Column = 35
-	col4thTierFloor       databind.Column = 36
-	col4thTierRate        databind.Column = 37
-	col5thTierFloor       databind.Column = 38
-	col5thTierRate        databind.Column = 39
-	col6thTierFloor       databind.Column = 40
-	col6thTierRate        databind.Column = 41
-	col7thTierFloor       databind.Column = 42
-	col7thTierRate        databind.Column = 43
-	col8thTierFloor       databind.Column = 44
-	col8thTierRate        databind.Column = 45
-	col9thTierFloor       databind.Column = 46
-	col9thTierRate        databind.Column = 47
-	col10thTierFloor      databind.Column = 48
-	col10thTierRate       databind.Column = 49
-	colCeloFeeAnchorage   databind.Column = 53
-	colCeloFeeThirdParty  databind.Column = 54
-	colFlowFeeAnchorage   databind.Column = 56
-	colFlowFeeThirdParty  databind.Column = 57
-	colOsmoFeeThirdParty  databind.Column = 58
-	colRoseFeeAnchorage   databind.Column = 60
-	colRoseFeeThirdParty  databind.Column = 61
-	colEthFeeAnchorage    databind.Column = 62
-	colAxlFeeThirdParty   databind.Column = 64
-	colAptFeeThirdParty   databind.Column = 65
-	colAtomFeeThirdParty  databind.Column = 67
-	colHashFeeThirdParty  databind.Column = 69
-	colEvmosFeeThirdParty databind.Column = 71
-	colAptFeeAnchorage    databind.Column = 72
-	colSolFeeThirdParty   databind.Column = 73
-	colSuiFeeAnchorage    databind.Column = 75
-	colSuiFeeThirdParty   databind.Column = 76
-	colAssetID            databind.Column = 87
-	colMSAID              databind.Column = 88
-	colGraduatedTier      databind.Column = 89
-	colCustomerID         databind.Column = 90 // NetSuite Account ID
-	colBillingID          databind.Column = 91
-	colRDBAccountID       databind.Column = 92
+	colMinimumFeeType        databind.Column = 3
+	colAccountFromRDB        databind.Column = 4
+	colEntityID              databind.Column = 7
+	colOrgName               databind.Column = 8
+	colLegalName             databind.Column = 9
+	colBillingTerms          databind.Column = 11
+	colStatus                databind.Column = 13
+	colAssetType             databind.Column = 19
+	colOnboardingDate        databind.Column = 27
+	colFeeAccrualDate        databind.Column = 28
+	colMinimumCharge         databind.Column = 29
+	col1stTierFloor          databind.Column = 30
+	col1stTierRate           databind.Column = 31
+	col2ndTierFloor          databind.Column = 32
+	col2ndTierRate           databind.Column = 33
+	col3rdTierFloor          databind.Column = 34
+	col3rdTierRate           databind.Column = 35
+	col4thTierFloor          databind.Column = 36
+	col4thTierRate           databind.Column = 37
+	col5thTierFloor          databind.Column = 38
+	col5thTierRate           databind.Column = 39
+	col6thTierFloor          databind.Column = 40
+	col6thTierRate           databind.Column = 41
+	col7thTierFloor          databind.Column = 42
+	col7thTierRate           databind.Column = 43
+	col8thTierFloor          databind.Column = 44
+	col8thTierRate           databind.Column = 45
+	col9thTierFloor          databind.Column = 46
+	col9thTierRate           databind.Column = 47
+	col10thTierFloor         databind.Column = 48
+	col10thTierRate          databind.Column = 49
+	colOnboardingFee         databind.Column = 50
+	colCeloFeeAnchorage      databind.Column = 53
+	colCeloFeeThirdParty     databind.Column = 54
+	colFlowFeeAnchorage      databind.Column = 56
+	colFlowFeeThirdParty     databind.Column = 57
+	colOsmoFeeThirdParty     databind.Column = 58
+	colOsmoFeeStakedBalance  databind.Column = 59
+	colRoseFeeAnchorage      databind.Column = 60
+	colRoseFeeThirdParty     databind.Column = 61
+	colEthFeeAnchorage       databind.Column = 62
+	colAxlFeeThirdParty      databind.Column = 64
+	colAxlFeeStakedBalance   databind.Column = 63
+	colAptFeeThirdParty      databind.Column = 65
+	colAtomFeeThirdParty     databind.Column = 67
+	colAtomFeeStakedBalance  databind.Column = 66
+	colHashFeeThirdParty     databind.Column = 69
+	colHashFeeStakedBalance  databind.Column = 68
+	colEvmosFeeThirdParty    databind.Column = 71
+	colEvmosFeeStakedBalance databind.Column = 70
+	colAptFeeAnchorage       databind.Column = 72
+	colSolFeeThirdParty      databind.Column = 73
+	colSolFeeStakedBalance   databind.Column = 74
+	colSuiFeeAnchorage       databind.Column = 75
+	colSuiFeeThirdParty      databind.Column = 76
+	colSuiFeeStakedBalance   databind.Column = 77
+	colRmoFeeThirdParty      databind.Column = 78
+	colRmoFeeStakedBalance   databind.Column = 79
+	colBrokerageClient       databind.Column = 81
+	colBrokerageFee          databind.Column = 82
+	colAssetID               databind.Column = 87
+	colMSAID                 databind.Column = 88
+	colGraduatedTier         databind.Column = 89
+	colCustomerID            databind.Column = 90 // NetSuite Account ID
+	colBillingID             databind.Column = 91
+	colRDBAccountID          databind.Column = 92
+	colTerminationDate       databind.Column = 93
 
 	mfrHeaderRow = 3
+	reportName   = "Master Fee Rates"
 )
 
+// schema finds the MFR columns by their header, so inserting a column in the
+// sheet doesn't shift the values read by the parser.
+var schema = databind.Schema{
+	{Col: colMinimumFeeType, Header: "Minimum Fee Type"},
+	{Col: colAccountFromRDB, Header: "Account ID from RDB", Optional: true},
+	{Col: colEntityID, Header: "Anchorage Entity ID"},
+	{Col: colOrgName, Header: "Org Name"},
+	{Col: colLegalName, Header: "Entity Legal Name"},
+	{Col: colBillingTerms, Header: "Billing Terms"},
+	{Col: colStatus, Header: "Status", Optional: true},
+	{Col: colAssetType, Header: "Asset Type", Optional: true},
+	{Col: colOnboardingDate, Header: "Onboarding Date", DateFormat: date.MonthFirst, Optional: true},
+	{Col: colFeeAccrualDate, Header: "Fee Accrual Date", DateFormat: date.MonthFirst, Optional: true},
+	{Col: colMinimumCharge, Header: "Minimum Charge (Customer Level)", Aliases: []string{"Minimum Charge"}},
+	{Col: col1stTierFloor, Header: "1st Tier Floor"},
+	{Col: col1stTierRate, Header: "1st Tier Rate"},
+	{Col: col2ndTierFloor, Header: "2nd Tier Floor"},
+	{Col: col2ndTierRate, Header: "2nd Tier Rate"},
+	{Col: col3rdTierFloor, Header: "3rd Tier Floor"},
+	{Col: col3rdTierRate, Header: "3rd Tier Rate"},
+	{Col: col4thTierFloor, Header: "4th Tier Floor"},
+	{Col: col4thTierRate, Header: "4th Tier Rate"},
+	{Col: col5thTierFloor, Header: "5th Tier Floor"},
+	{Col: col5thTierRate, Header: "5th Tier Rate"},
+	{Col: col6thTierFloor, Header: "6th Tier Floor"},
+	{Col: col6thTierRate, Header: "6th Tier Rate"},
+	{Col: col7thTierFloor, Header: "7th Tier Floor"},
+	{Col: col7thTierRate, Header: "7th Tier Rate"},
+	{Col: col8thTierFloor, Header: "8th Tier Floor"},
+	{Col: col8thTierRate, Header: "8th Tier Rate"},
+	{Col: col9thTierFloor, Header: "9th Tier Floor"},
+	{Col: col9thTierRate, Header: "9th Tier Rate"},
+	{Col: col10thTierFloor, Header: "10th Tier Floor"},
+	{Col: col10thTierRate, Header: "10th Tier Rate"},
+	// The amount column of the "Onboarding Fee" section.
+	{Col: colOnboardingFee, Header: "Total Amount", Aliases: []string{"Onboarding Fee"}, Optional: true},
+	{Col: colCeloFeeAnchorage, Header: "Celo Fee % - Anchorage validator"},
+	{Col: colCeloFeeThirdParty, Header: "Celo Fee % - third party validator"},
+	{Col: colFlowFeeAnchorage, Header: "FLOW Fee % - Anchorage validator"},
+	{Col: colFlowFeeThirdParty, Header: "FLOW Fee % - third party validator"},
+	{Col: colOsmoFeeThirdParty, Header: "OSMO Fee % - third party validator"},
+	{Col: colOsmoFeeStakedBalance, Header: "OSMO Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colRoseFeeAnchorage, Header: "ROSE Fee % - Anchorage validator"},
+	{Col: colRoseFeeThirdParty, Header: "ROSE Fee % - third party validator"},
+	{Col: colEthFeeAnchorage, Header: "ETH Fee % - Anchorage validator"},
+	{Col: colAxlFeeThirdParty, Header: "AXL Fee % - third party validator"},
+	{Col: colAxlFeeStakedBalance, Header: "AXL Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colAptFeeThirdParty, Header: "APT Fee % - third party validator"},
+	{Col: colAtomFeeThirdParty, Header: "ATOM Fee % - third party validator"},
+	{Col: colAtomFeeStakedBalance, Header: "ATOM Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colHashFeeThirdParty, Header: "HASH Fee % - third party validator"},
+	{Col: colHashFeeStakedBalance, Header: "HASH Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colEvmosFeeThirdParty, Header: "EVMOS Fee % - third party validator"},
+	{Col: colEvmosFeeStakedBalance, Header: "EVMOS Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colAptFeeAnchorage, Header: "APT Fee % - Anchorage validator"},
+	{Col: colSolFeeThirdParty, Header: "SOL Fee % - third party validator"},
+	{Col: colSolFeeStakedBalance, Header: "SOL Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colSuiFeeAnchorage, Header: "SUI Fee % - Anchorage validator"},
+	{Col: colSuiFeeThirdParty, Header: "SUI Fee % - third party validator"},
+	{Col: colSuiFeeStakedBalance, Header: "SUI Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colRmoFeeThirdParty, Header: "RMO Fee % - third party validator", Optional: true},
+	{Col: colRmoFeeStakedBalance, Header: "RMO Staking Fee % - 100% commission validator (fee on staked balance)", Optional: true},
+	{Col: colBrokerageClient, Header: "Brokerage Client? (yes/no)", Aliases: []string{"Brokerage Client?", "Brokerage Client"}, Optional: true},
+	{Col: colBrokerageFee, Header: "Brokerage Fee", Optional: true},
+	{Col: colAssetID, Header: "Asset ID"},
+	{Col: colMSAID, Header: "MSA ID"},
+	{Col: colGraduatedTier, Header: "Graduated tier?"},
+	{Col: colCustomerID, Header: "Netsuite Account ID"},
+	{Col: colBillingID, Header: "Billing ID", Optional: true},
+	{Col: colRDBAccountID, Header: "RDB Account ID"},
+	{Col: colTerminationDate, Header: "Termination Date", DateFormat: date.MonthFirst},
+}
+
 type (
 	MSAID   string
 	AssetID int
@@ -82,6 +174,18 @@ type (
 
 type MasterFeeRates struct {
 	organizations map[MSAID]Organization
+	skipped       []SkippedAccount
+}
+
+// SkippedAccount is an account of the MFR that is not billed, Reason tells
+// what is missing to bill it.
+type SkippedAccount struct {
+	Line        int
+	MsaID       MSAID
+	OrgName     string
+	AccountID   databind.AccountID
+	AccountName string
+	Reason      string
 }
 
 type Organization struct {
@@ -98,7 +202,51 @@ type Account struct {
 	DisplayName  string
 	CustomerId   string
 	BillingTerms string
-	assetTypes   map[AssetID]AssetType
+	Brokerage    Brokerage
+	Onboarding   Onboarding
+	// FeeAccrualDate is the first day fees accrue, zero when the MFR has
+	// none.
+	FeeAccrualDate time.Time
+	// TerminationDate is the last day of a terminated account, zero while it
+	// is active.
+	TerminationDate time.Time
+	assetTypes      map[AssetID]AssetType
+}
+
+// AccrualStart is the first day the account is billed: the Fee Accrual Date,
+// or the Onboarding Date without one. It is zero when both are missing.
+func (a Account) AccrualStart() time.Time {
+	if !a.FeeAccrualDate.IsZero() {
+		return a.FeeAccrualDate
+	}
+	return a.Onboarding.Date
+}
+
+// TerminatedBefore tells whether the account was terminated before the month
+// of invoiceDate, so it has nothing left to bill.
+func (a Account) TerminatedBefore(invoiceDate time.Time) bool {
+	if a.TerminationDate.IsZero() {
+		return false
+	}
+	monthStart := time.Date(invoiceDate.Year(), invoiceDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return a.TerminationDate.Before(monthStart)
+}
+
+// Onboarding is the one-time fee billed on the first invoice of the period
+// containing Date. Fee is zero when the account pays none, Date is zero when
+// the MFR has none.
+type Onboarding struct {
+	Fee  decimal.Decimal
+	Date time.Time
+}
+
+// Brokerage is the brokerage terms of an account. Clients on the Standard
+// schedule pay the rate stated on each order, the others pay Rate, in
+// percent units, on the notional of every trade.
+type Brokerage struct {
+	Client   bool
+	Standard bool
+	Rate     decimal.Decimal
 }
 
 type AssetType struct {
@@ -115,10 +263,15 @@ type TierData struct {
 	Rate  decimal.Decimal
 }
 
+// StakingFee holds the staking fee rates of an asset, in percent.
+// StakedBalanceFee is the annual fee on the balance staked to a validator
+// keeping a commission on the rewards, see the "100% commission validator"
+// columns.
 type StakingFee struct {
-	AssetName     string
-	AnchorageFee  decimal.Decimal
-	ThirdPartyFee decimal.Decimal
+	AssetName        string
+	AnchorageFee     decimal.Decimal
+	ThirdPartyFee    decimal.Decimal
+	StakedBalanceFee decimal.Decimal
 }
 
 type MinimumFee struct {
@@ -141,9 +294,78 @@ func (r *MasterFeeRates) GetAssetTypes(msaId MSAID, account databind.AccountID)
 	return acc.assetTypes
 }
 
+// FindAccountById returns the account with the given RDB account ID and the
+// organization it belongs to.
+func (r *MasterFeeRates) FindAccountById(accountId databind.AccountID) (Organization, Account, bool) {
+	for _, org := range r.GetSortedOrganizations() {
+		if acc, ok := org.accounts[accountId]; ok {
+			return org, acc, true
+		}
+	}
+
+	return Organization{}, Account{}, false
+}
+
+// GetSortedOrganizations returns the organizations ordered by MSA ID, the
+// order every calculation walks the MFR in.
+func (r *MasterFeeRates) GetSortedOrganizations() []Organization {
+	ids := make([]MSAID, 0, len(r.organizations))
+	for id := range r.organizations {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	orgs := make([]Organization, 0, len(ids))
+	for _, id := range ids {
+		orgs = append(orgs, r.organizations[id])
+	}
+	return orgs
+}
+
+// GetSortedAccounts returns the accounts of the organization ordered by RDB
+// account ID.
+func (r *MasterFeeRates) GetSortedAccounts(msaId MSAID) []Account {
+	accounts := r.GetAccounts(msaId)
+	ids := make([]databind.AccountID, 0, len(accounts))
+	for id := range accounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	result := make([]Account, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, accounts[id])
+	}
+	return result
+}
+
+// GetSortedAssetTypes returns the asset types of the account ordered by asset
+// ID.
+func (r *MasterFeeRates) GetSortedAssetTypes(msaId MSAID, account databind.AccountID) []AssetType {
+	org := r.organizations[msaId]
+	acc := org.accounts[account]
+	return acc.sortedAssetTypes()
+}
+
+func (a *Account) sortedAssetTypes() []AssetType {
+	ids := make([]AssetID, 0, len(a.assetTypes))
+	for id := range a.assetTypes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	assetTypes := make([]AssetType, 0, len(ids))
+	for _, id := range ids {
+		assetTypes = append(assetTypes, a.assetTypes[id])
+	}
+	return assetTypes
+}
+
+// GetAssetStakingFees returns the staking fees of the asset from the first
+// asset type, by asset ID, that has them.
 func (a *Account) GetAssetStakingFees(assetName string) StakingFee {
 	// For staking we don't look for different assetTypes
-	for _, atypes := range a.assetTypes {
+	for _, atypes := range a.sortedAssetTypes() {
 		for _, s := range atypes.stakingFees {
 			if s.AssetName == assetName {
 				return s
@@ -161,6 +383,12 @@ func (r *MasterFeeRates) GetStakingFees(msaId MSAID, account databind.AccountID,
 	return a.stakingFees
 }
 
+// GetSkippedAccounts returns the accounts left out of the billing, once each
+// in the order of the sheet.
+func (r *MasterFeeRates) GetSkippedAccounts() []SkippedAccount {
+	return r.skipped
+}
+
 func (r *MasterFeeRates) IsEmpty() bool {
 	return len(r.organizations) == 0
 }
@@ -195,6 +423,16 @@ func (at *AssetType) FindAllTiers(usdBalance decimal.Decimal) ([]TierData, error
 	}
 }
 
+// Schedule returns the fee tiers of the asset type, split as its "Graduated
+// tier?" column says.
+func (at *AssetType) Schedule() (tiers.Schedule, error) {
+	schedule := make([]tiers.Tier, 0, len(at.TierData))
+	for _, tier := range at.TierData {
+		schedule = append(schedule, tiers.Tier{Floor: tier.Floor, Rate: tier.Rate})
+	}
+	return tiers.NewSchedule(tiers.ParseMode(at.GraduatedTier), schedule)
+}
+
 func (at *AssetType) getGraduatedTier() (bool, error) {
 	return strings.ToUpper(at.GraduatedTier) == "GRADUATED", nil
 }
@@ -222,17 +460,31 @@ func (mf *MinimumFee) IsGreaterOf() bool {
 	return strings.ToUpper(mf.MinimumFeeType) == "GREATEROF"
 }
 
-func NewMasterFeeRates(table [][]string) *MasterFeeRates {
+// NewMasterFeeRates binds the MFR data rows. Every bad value is collected and
+// returned together as a *databind.ParseErrors.
+func NewMasterFeeRates(table [][]string) (*MasterFeeRates, error) {
 	mfr := &MasterFeeRates{
 		organizations: make(map[MSAID]Organization),
 	}
+	errs := &databind.ParseErrors{}
 
-	for _, row := range table {
-		if strings.ToUpper(row[colRDBAccountID]) == "TERMINATED" {
-			continue
-		}
+	for i, row := range table {
+		row = padRow(row)
+		c := databind.Cells{Row: row, Line: mfrHeaderRow + i + 1, Report: reportName, Schema: schema, Errs: errs}
 		msaId := MSAID(sanitization.SanitizeName(row[colMSAID]))
 		orgName := sanitization.SanitizeName(row[colOrgName])
+		accountId, terminationDate, skipReason := accountStatus(c)
+		if skipReason != "" {
+			mfr.skip(SkippedAccount{
+				Line:        c.Line,
+				MsaID:       msaId,
+				OrgName:     row[colOrgName],
+				AccountID:   accountId,
+				AccountName: row[colLegalName],
+				Reason:      skipReason,
+			})
+			continue
+		}
 		org, exists := mfr.organizations[msaId]
 		if !exists {
 			org = Organization{
@@ -245,7 +497,6 @@ func NewMasterFeeRates(table [][]string) *MasterFeeRates {
 		}
 
 		legalName := sanitization.SanitizeName(row[colLegalName])
-		accountId := databind.AccountID(row[colRDBAccountID])
 		billingTerms := sanitization.SanitizeIntegerString(row[colBillingTerms])
 		if billingTerms == "" || billingTerms == "0" {
 			log.Print("Billing Terms is empty/invalid for orgName: " + orgName + " and legalName: " + legalName + " .\nSetting it to 0")
@@ -258,20 +509,27 @@ func NewMasterFeeRates(table [][]string) *MasterFeeRates {
 				DisplayName:  row[colLegalName],
 				CustomerId:   row[colCustomerID],
 				BillingTerms: billingTerms,
+				Brokerage:    parseBrokerage(c),
 				assetTypes:   make(map[AssetID]AssetType),
 			}
+			if !isBlank(row[colFeeAccrualDate]) {
+				acc.FeeAccrualDate = c.Date(colFeeAccrualDate)
+			}
+			acc.TerminationDate = terminationDate
 		}
-
-		assetId, err := strconv.Atoi(sanitization.SanitizeFloatString(row[colAssetID]))
-		if err != nil {
-			panic(errors.New(fmt.Sprintf("Column AssetID has invalid value: %s", err)))
+		// The onboarding terms are repeated on each asset row, the first row
+		// with a fee sets them.
+		if acc.Onboarding.Fee.IsZero() {
+			acc.Onboarding = parseOnboarding(c)
 		}
+
+		assetId := c.Int(colAssetID)
 		graduatedTier := row[colGraduatedTier]
-		tierData := parseTierData(row)
+		tierData := parseTierData(c)
 
 		minimumFee := MinimumFee{
 			MinimumFeeType: sanitization.SanitizeName(row[colMinimumFeeType]),
-			MinimumCharge:  converter.FromStrToDecimal(row[colMinimumCharge]),
+			MinimumCharge:  c.Decimal(colMinimumCharge),
 		}
 
 		assetType, exists := acc.assetTypes[AssetID(assetId)]
@@ -286,7 +544,7 @@ func NewMasterFeeRates(table [][]string) *MasterFeeRates {
 			}
 		}
 
-		stakingFee := parseStakingFees(row)
+		stakingFee := parseStakingFees(c)
 
 		assetType.stakingFees = stakingFee
 		acc.assetTypes[AssetID(assetId)] = assetType
@@ -294,7 +552,11 @@ func NewMasterFeeRates(table [][]string) *MasterFeeRates {
 		mfr.organizations[msaId] = org
 	}
 
-	return mfr
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return mfr, nil
 }
 
 func ProcessMfr(ctx context.Context, mfrFile io.Reader, sheetId string, mfrTab string, token string) (*MasterFeeRates, error) {
@@ -302,7 +564,7 @@ func ProcessMfr(ctx context.Context, mfrFile io.Reader, sheetId string, mfrTab s
 	if mfrFile != nil {
 		mfr, err := parseMfrFile(mfrFile)
 		if err != nil {
-			return nil, errors.New(err.Error())
+			return nil, err
 		}
 		return mfr, nil
 	}
@@ -342,98 +604,183 @@ func ProcessMfr(ctx context.Context, mfrFile io.Reader, sheetId string, mfrTab s
 		return nil, errors.New(err.Error())
 	}
 
-	mfr, err := parseMfrGSheet(mfrArrString)
+	if len(mfrValues) < mfrHeaderRow {
+		return nil, errors.New("Error while reading MFR data from sheet.")
+	}
+
+	mfr, err := parseMfrGSheet(mfrValues[mfrHeaderRow-1], mfrArrString)
 	if err != nil {
-		return nil, errors.New(err.Error())
+		return nil, err
 	}
 
 	return mfr, nil
 }
 
-func parseStakingFees(row []string) map[string]StakingFee {
+func parseStakingFees(c databind.Cells) map[string]StakingFee {
 	return map[string]StakingFee{
 		"CELO": {
 			AssetName:     "CELO",
-			AnchorageFee:  parseFee(row[colCeloFeeAnchorage]),
-			ThirdPartyFee: parseFee(row[colCeloFeeThirdParty]),
+			AnchorageFee:  c.Percent(colCeloFeeAnchorage),
+			ThirdPartyFee: c.Percent(colCeloFeeThirdParty),
 		},
 		"FLOW": {
 			AssetName:     "FLOW",
-			AnchorageFee:  parseFee(row[colFlowFeeAnchorage]),
-			ThirdPartyFee: parseFee(row[colFlowFeeThirdParty]),
+			AnchorageFee:  c.Percent(colFlowFeeAnchorage),
+			ThirdPartyFee: c.Percent(colFlowFeeThirdParty),
 		},
 		"ROSE": {
 			AssetName:     "ROSE",
-			AnchorageFee:  parseFee(row[colRoseFeeAnchorage]),
-			ThirdPartyFee: parseFee(row[colRoseFeeThirdParty]),
+			AnchorageFee:  c.Percent(colRoseFeeAnchorage),
+			ThirdPartyFee: c.Percent(colRoseFeeThirdParty),
 		},
 		"APT": {
 			AssetName:     "APT",
-			AnchorageFee:  parseFee(row[colAptFeeAnchorage]),
-			ThirdPartyFee: parseFee(row[colAptFeeThirdParty]),
+			AnchorageFee:  c.Percent(colAptFeeAnchorage),
+			ThirdPartyFee: c.Percent(colAptFeeThirdParty),
 		},
 		"SOL": {
-			AssetName:     "SOL",
-			ThirdPartyFee: parseFee(row[colSolFeeThirdParty]),
+			AssetName:        "SOL",
+			ThirdPartyFee:    c.Percent(colSolFeeThirdParty),
+			StakedBalanceFee: c.Percent(colSolFeeStakedBalance),
 		},
 		"OSMO": {
-			AssetName:     "OSMO",
-			ThirdPartyFee: parseFee(row[colOsmoFeeThirdParty]),
+			AssetName:        "OSMO",
+			ThirdPartyFee:    c.Percent(colOsmoFeeThirdParty),
+			StakedBalanceFee: c.Percent(colOsmoFeeStakedBalance),
 		},
 		"HASH": {
-			AssetName:     "HASH",
-			ThirdPartyFee: parseFee(row[colHashFeeThirdParty]),
+			AssetName:        "HASH",
+			ThirdPartyFee:    c.Percent(colHashFeeThirdParty),
+			StakedBalanceFee: c.Percent(colHashFeeStakedBalance),
 		},
 		"ATOM": {
-			AssetName:     "ATOM",
-			ThirdPartyFee: parseFee(row[colAtomFeeThirdParty]),
+			AssetName:        "ATOM",
+			ThirdPartyFee:    c.Percent(colAtomFeeThirdParty),
+			StakedBalanceFee: c.Percent(colAtomFeeStakedBalance),
 		},
 		"AXL": {
-			AssetName:     "AXL",
-			ThirdPartyFee: parseFee(row[colAxlFeeThirdParty]),
+			AssetName:        "AXL",
+			ThirdPartyFee:    c.Percent(colAxlFeeThirdParty),
+			StakedBalanceFee: c.Percent(colAxlFeeStakedBalance),
 		},
 		"EVMOS": {
-			AssetName:     "EVMOS",
-			ThirdPartyFee: parseFee(row[colEvmosFeeThirdParty]),
+			AssetName:        "EVMOS",
+			ThirdPartyFee:    c.Percent(colEvmosFeeThirdParty),
+			StakedBalanceFee: c.Percent(colEvmosFeeStakedBalance),
 		},
 		"SUI": {
-			AssetName:     "SUI",
-			AnchorageFee:  parseFee(row[colSuiFeeAnchorage]),
-			ThirdPartyFee: parseFee(row[colSuiFeeThirdParty]),
+			AssetName:        "SUI",
+			AnchorageFee:     c.Percent(colSuiFeeAnchorage),
+			ThirdPartyFee:    c.Percent(colSuiFeeThirdParty),
+			StakedBalanceFee: c.Percent(colSuiFeeStakedBalance),
+		},
+		"RMO": {
+			AssetName:        "RMO",
+			ThirdPartyFee:    c.Percent(colRmoFeeThirdParty),
+			StakedBalanceFee: c.Percent(colRmoFeeStakedBalance),
 		},
 		"ETH": {
 			AssetName:    "ETH",
-			AnchorageFee: parseFee(row[colEthFeeAnchorage]),
+			AnchorageFee: c.Percent(colEthFeeAnchorage),
 		},
 	}
 }
 
-func parseTierData(row []string) []TierData {
-	return []TierData{
-		{Floor: getDecimalValue(row[col1stTierFloor]), Rate: getDecimalValue(row[col1stTierRate])},
-		{Floor: getDecimalValue(row[col2ndTierFloor]), Rate: getDecimalValue(row[col2ndTierRate])},
-		{Floor: getDecimalValue(row[col3rdTierFloor]), Rate: getDecimalValue(row[col3rdTierRate])},
-		{Floor: getDecimalValue(row[col4thTierFloor]), Rate: getDecimalValue(row[col4thTierRate])},
-		{Floor: getDecimalValue(row[col5thTierFloor]), Rate: getDecimalValue(row[col5thTierRate])},
-		{Floor: getDecimalValue(row[col6thTierFloor]), Rate: getDecimalValue(row[col6thTierRate])},
-		{Floor: getDecimalValue(row[col7thTierFloor]), Rate: getDecimalValue(row[col7thTierRate])},
-		{Floor: getDecimalValue(row[col8thTierFloor]), Rate: getDecimalValue(row[col8thTierRate])},
-		{Floor: getDecimalValue(row[col9thTierFloor]), Rate: getDecimalValue(row[col9thTierRate])},
-		{Floor: getDecimalValue(row[col10thTierFloor]), Rate: getDecimalValue(row[col10thTierRate])},
+// parseBrokerage reads the brokerage columns. A Brokerage Fee starting with
+// "Standard", or left empty, is the Standard schedule.
+func parseBrokerage(c databind.Cells) Brokerage {
+	client := strings.ToLower(strings.TrimSpace(c.Row[colBrokerageClient]))
+	if client != "yes" && client != "y" {
+		return Brokerage{}
+	}
+
+	fee := strings.TrimSpace(c.Row[colBrokerageFee])
+	switch upper := strings.ToUpper(fee); {
+	case upper == "" || upper == "N/A" || strings.HasPrefix(upper, "STANDARD"):
+		return Brokerage{Client: true, Standard: true}
+	default:
+		return Brokerage{Client: true, Rate: c.Percent(colBrokerageFee)}
 	}
 }
 
-func getDecimalValue(value string) decimal.Decimal {
-	return converter.FromStrToDecimal(value)
+// parseOnboarding reads the onboarding fee and date. An empty or N/A date is
+// the zero time.
+func parseOnboarding(c databind.Cells) Onboarding {
+	onboarding := Onboarding{}
+	if !isBlank(c.Row[colOnboardingFee]) {
+		onboarding.Fee = c.Decimal(colOnboardingFee)
+	}
+	if !isBlank(c.Row[colOnboardingDate]) {
+		onboarding.Date = c.Date(colOnboardingDate)
+	}
+	return onboarding
 }
 
-func parseFee(fee string) decimal.Decimal {
-	fee = sanitization.SanitizeFloatString(fee)
-	f, err := decimal.NewFromString(fee)
-	if err != nil {
-		panic(err)
+// accountStatus returns the RDB account ID of a row and the termination date
+// of a terminated account. An account is terminated when its Status is
+// "Terminated", or when "Terminated" replaces its RDB Account ID, the ID is
+// then read from "Account ID from RDB". Terminated accounts are billed until
+// their Termination Date, skipReason tells why one can't be billed.
+func accountStatus(c databind.Cells) (accountId databind.AccountID, terminationDate time.Time, skipReason string) {
+	accountId = databind.AccountID(c.Row[colRDBAccountID])
+	markedId := strings.EqualFold(strings.TrimSpace(c.Row[colRDBAccountID]), "TERMINATED")
+	terminated := markedId || strings.EqualFold(strings.TrimSpace(c.Row[colStatus]), "TERMINATED")
+	if !terminated {
+		return accountId, time.Time{}, ""
+	}
+
+	if markedId {
+		accountId = databind.AccountID(strings.TrimSpace(c.Row[colAccountFromRDB]))
+		if accountId == "" {
+			return "", time.Time{}, "Terminated account without an Account ID from RDB, it is not billed"
+		}
+	}
+	if isBlank(c.Row[colTerminationDate]) {
+		return accountId, time.Time{}, "Terminated account without a Termination Date, its last month is not billed"
+	}
+	return accountId, c.Date(colTerminationDate), ""
+}
+
+// skip records a skipped account once, its asset rows all repeat the reason.
+func (r *MasterFeeRates) skip(account SkippedAccount) {
+	for _, skipped := range r.skipped {
+		if skipped.MsaID == account.MsaID && skipped.AccountID == account.AccountID && skipped.AccountName == account.AccountName {
+			return
+		}
+	}
+	r.skipped = append(r.skipped, account)
+}
+
+// padRow extends a row shorter than the schema, the missing columns read as
+// empty.
+func padRow(row []string) []string {
+	if len(row) > int(colTerminationDate) {
+		return row
+	}
+	padded := make([]string, int(colTerminationDate)+1)
+	copy(padded, row)
+	return padded
+}
+
+func isBlank(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "" || strings.EqualFold(value, "N/A")
+}
+
+// parseTierData reads the tier floors in USD and the rates in percent units.
+func parseTierData(c databind.Cells) []TierData {
+	return []TierData{
+		{Floor: c.Decimal(col1stTierFloor), Rate: c.Percent(col1stTierRate)},
+		{Floor: c.Decimal(col2ndTierFloor), Rate: c.Percent(col2ndTierRate)},
+		{Floor: c.Decimal(col3rdTierFloor), Rate: c.Percent(col3rdTierRate)},
+		{Floor: c.Decimal(col4thTierFloor), Rate: c.Percent(col4thTierRate)},
+		{Floor: c.Decimal(col5thTierFloor), Rate: c.Percent(col5thTierRate)},
+		{Floor: c.Decimal(col6thTierFloor), Rate: c.Percent(col6thTierRate)},
+		{Floor: c.Decimal(col7thTierFloor), Rate: c.Percent(col7thTierRate)},
+		{Floor: c.Decimal(col8thTierFloor), Rate: c.Percent(col8thTierRate)},
+		{Floor: c.Decimal(col9thTierFloor), Rate: c.Percent(col9thTierRate)},
+		{Floor: c.Decimal(col10thTierFloor), Rate: c.Percent(col10thTierRate)},
 	}
-	return f
 }
 
 func parseMfrFile(mfrFile io.Reader) (*MasterFeeRates, error) {
@@ -442,13 +789,28 @@ func parseMfrFile(mfrFile io.Reader) (*MasterFeeRates, error) {
 		return nil, errors.New("Error while reading MFR data from file.")
 	}
 
-	mfr := NewMasterFeeRates(mfrFileData[mfrHeaderRow:])
+	return ParseMfrCsv(mfrFileData)
+}
 
-	return mfr, nil
+// ParseMfrCsv binds the rows of an MFR CSV export, header rows included.
+func ParseMfrCsv(mfrFileData [][]string) (*MasterFeeRates, error) {
+	if len(mfrFileData) < mfrHeaderRow {
+		return nil, errors.New("Error while reading MFR data from file.")
+	}
+
+	table, err := schema.Bind(mfrFileData[mfrHeaderRow-1], mfrFileData[mfrHeaderRow:])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading MFR: %v", err))
+	}
+
+	return NewMasterFeeRates(table)
 }
 
-func parseMfrGSheet(mfrGSheetData [][]string) (*MasterFeeRates, error) {
-	mfr := NewMasterFeeRates(mfrGSheetData[mfrHeaderRow:])
+func parseMfrGSheet(header []string, mfrGSheetData [][]string) (*MasterFeeRates, error) {
+	table, err := schema.Bind(header, mfrGSheetData[mfrHeaderRow:])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading MFR: %v", err))
+	}
 
-	return mfr, nil
+	return NewMasterFeeRates(table)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ import (
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
 )
 
 var mfrAll [][]string
@@ -45,7 +47,8 @@ func TestNewMasterFeeRates(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 
 	assert.Falsef(t, mfrBind.IsEmpty(), "Organization map should not be empty")
 }
@@ -56,7 +59,8 @@ func TestLookupFunctions(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 
 	t.Run("Test GetOrganizations", func(t *testing.T) {
 		orgs := mfrBind.GetOrganizations()
@@ -111,6 +115,14 @@ func TestLookupFunctions(t *testing.T) {
 
 		assert.Truef(t, expAnchorFee.Equal(asset.AnchorageFee), "Expected Anchorage Fee for SUI = %s, but found %s", expAnchorFee, asset.AnchorageFee)
 		assert.Truef(t, expThirdFee.Equal(asset.ThirdPartyFee), "Expected Third Party Fee for SUI = %s, but found %s", expThirdFee, asset.ThirdPartyFee)
+
+		// RMO is only billed on third party and 100% commission validators
+		asset = stakingFees["RMO"]
+		expThirdFee, _ = decimal.NewFromString("3")
+		expStakedBalanceFee, _ := decimal.NewFromString("1")
+
+		assert.Truef(t, expThirdFee.Equal(asset.ThirdPartyFee), "Expected Third Party Fee for RMO = %s, but found %s", expThirdFee, asset.ThirdPartyFee)
+		assert.Truef(t, expStakedBalanceFee.Equal(asset.StakedBalanceFee), "Expected Staked Balance Fee for RMO = %s, but found %s", expStakedBalanceFee, asset.StakedBalanceFee)
 	})
 
 	t.Run("Test GetAssetStakingFees", func(t *testing.T) {
@@ -138,6 +150,17 @@ func TestLookupFunctions(t *testing.T) {
 
 		assert.True(t, stakingFees.AssetName == "", "Expected empty AssetName, but got %s", stakingFees.AssetName)
 	})
+
+	t.Run("Test FindAccountById", func(t *testing.T) {
+		org, account, ok := mfrBind.FindAccountById(databind.AccountID("accountIdFor2222"))
+
+		assert.True(t, ok, "Expected to find AccountId=accountIdFor2222")
+		assert.Equal(t, mfr.MSAID("22222"), org.Id)
+		assert.Equal(t, databind.AccountID("accountIdFor2222"), account.Id)
+
+		_, _, ok = mfrBind.FindAccountById(databind.AccountID("MY_ACCOUNT"))
+		assert.False(t, ok, "Expected not to find AccountId=MY_ACCOUNT")
+	})
 }
 
 func TestFindAllTiersGraduatedClient(t *testing.T) {
@@ -146,7 +169,8 @@ func TestFindAllTiersGraduatedClient(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 	msaId := mfr.MSAID("22222")
 	accountId := databind.AccountID("accountIdFor2222")
 	assetId := mfr.AssetID(10)
@@ -182,7 +206,8 @@ func TestFindAllTiersNotGraduated(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 	msaId := mfr.MSAID("11111")
 	accountId := databind.AccountID("2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22")
 	assetId := mfr.AssetID(10)
@@ -206,13 +231,33 @@ func TestFindAllTiersNotGraduated(t *testing.T) {
 	assert.NotEmpty(t, tiers, "Returned tiers slice should not be empty for valid data")
 }
 
+func TestScheduleWithoutGraduatedTier(t *testing.T) {
+	err := readCsvFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
+	// MSA 11111 leaves "Graduated tier?" blank, its first floor is $5,000,000.
+	accountId := databind.AccountID("2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22")
+	asset := mfrBind.GetAssetTypes(mfr.MSAID("11111"), accountId)[mfr.AssetID(10)]
+
+	schedule, err := asset.Schedule()
+	assert.NoError(t, err)
+	assert.Equal(t, tiers.BandWidth, schedule.Mode)
+
+	result := schedule.Apply(decimal.NewFromInt(2000000))
+	assert.Equal(t, "58333.33", result.MonthlyFee.StringFixed(2))
+}
+
 func TestFindAllTiersNegativeValue(t *testing.T) {
 	err := readCsvFile()
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 	msaId := mfr.MSAID("22222")
 	accountId := databind.AccountID("accountIdFor2222")
 	assetId := mfr.AssetID(10)
@@ -236,7 +281,8 @@ func TestSkipsTerminatedAccounts(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	mfrBind := mfr.NewMasterFeeRates(mfrAll)
+	mfrBind, err := mfr.NewMasterFeeRates(mfrAll)
+	assert.NoError(t, err)
 	msaId := mfr.MSAID("22222")
 
 	accounts := mfrBind.GetAccounts(msaId)
@@ -250,7 +296,8 @@ func TestSkipsTerminatedAccounts(t *testing.T) {
 func TestNewMasterFeeRatesWithEmptyData(t *testing.T) {
 	emptyData := [][]string{}
 
-	mfrBind := mfr.NewMasterFeeRates(emptyData)
+	mfrBind, err := mfr.NewMasterFeeRates(emptyData)
+	assert.NoError(t, err)
 	assert.True(t, mfrBind.IsEmpty(), "MasterFeeRates should be empty when initialized with empty data")
 }
 
@@ -265,3 +312,259 @@ func TestProcessMfrWithInvalidGoogleSheet(t *testing.T) {
 	assert.Error(t, err, "ProcessMfr should return an error for invalid Google Sheet request")
 	assert.Contains(t, err.Error(), "Failed to fetch data from mfr endpoint", "Error message should indicate failure to fetch data")
 }
+
+func TestParseMfrCsvColumnsByHeader(t *testing.T) {
+	mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvData, err := csv.NewReader(mfrFile).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should read the columns after an inserted one", func(t *testing.T) {
+		shifted := make([][]string, 0, len(csvData))
+		for _, row := range csvData {
+			shifted = append(shifted, append([]string{"inserted"}, row...))
+		}
+
+		expected, err := mfr.ParseMfrCsv(csvData)
+		assert.NoError(t, err)
+
+		mfrBind, err := mfr.ParseMfrCsv(shifted)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetOrganizations(), mfrBind.GetOrganizations())
+	})
+
+	t.Run("Should report missing columns by name", func(t *testing.T) {
+		missingFile, err := static.Files.Open("gsheet/mfrParseMissing.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mfr.ProcessMfr(context.Background(), missingFile, "", "", "")
+
+		assert.ErrorContains(t, err, "Minimum Fee Type")
+		assert.ErrorContains(t, err, "RDB Account ID")
+		assert.ErrorContains(t, err, "Termination Date")
+	})
+}
+
+func TestNewMasterFeeRatesWithInvalidValues(t *testing.T) {
+	err := readCsvFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		colCeloFeeAnchorage = 53
+		colAssetID          = 87
+	)
+
+	table := make([][]string, 0, len(mfrAll))
+	for _, row := range mfrAll {
+		table = append(table, append([]string{}, row...))
+	}
+	table[0][colAssetID] = "1.5"
+	table[2][colCeloFeeAnchorage] = "1.2.3"
+
+	_, err = mfr.NewMasterFeeRates(table)
+
+	var parseErrs *databind.ParseErrors
+	if assert.True(t, errors.As(err, &parseErrs), "expected *databind.ParseErrors, got %v", err) {
+		assert.Equal(t, []databind.CellError{
+			{Report: "Master Fee Rates", Row: 4, Column: "Asset ID", Value: "1.5", Reason: "not an integer"},
+			{Report: "Master Fee Rates", Row: 6, Column: "Celo Fee % - Anchorage validator", Value: "1.2.3", Reason: "not a number"},
+		}, parseErrs.Errors)
+	}
+}
+
+func TestBrokerageTerms(t *testing.T) {
+	err := readCsvFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		colBrokerageFee = 82
+		colRDBAccountID = 92
+	)
+
+	table := make([][]string, 0, len(mfrAll))
+	for _, row := range mfrAll {
+		table = append(table, append([]string{}, row...))
+	}
+	table[3][colBrokerageFee] = "0.25%"
+
+	mfrBind, err := mfr.NewMasterFeeRates(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should read a client that is not brokerage", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById(databind.AccountID(table[0][colRDBAccountID]))
+		assert.Equal(t, mfr.Brokerage{}, acc.Brokerage)
+	})
+
+	t.Run("Should read the Standard schedule", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById("accountIdFor2222")
+		assert.True(t, acc.Brokerage.Client)
+		assert.True(t, acc.Brokerage.Standard)
+	})
+
+	t.Run("Should read a client rate in percent units", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById(databind.AccountID(table[3][colRDBAccountID]))
+		assert.True(t, acc.Brokerage.Client)
+		assert.False(t, acc.Brokerage.Standard)
+		assert.Equal(t, "0.25", acc.Brokerage.Rate.String())
+	})
+
+	t.Run("Should report a bad client rate", func(t *testing.T) {
+		table[3][colBrokerageFee] = "a quarter"
+		_, err := mfr.NewMasterFeeRates(table)
+		assert.ErrorContains(t, err, "Brokerage Fee")
+	})
+}
+
+func TestOnboardingTerms(t *testing.T) {
+	err := readCsvFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		colOnboardingDate = 27
+		colOnboardingFee  = 50
+		colRDBAccountID   = 92
+	)
+
+	table := make([][]string, 0, len(mfrAll))
+	for _, row := range mfrAll {
+		table = append(table, append([]string{}, row...))
+	}
+	table[2][colOnboardingFee] = "5,000"
+	table[3][colOnboardingFee] = "2500"
+	table[3][colOnboardingDate] = "N/A"
+
+	mfrBind, err := mfr.NewMasterFeeRates(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should read no fee for an empty or zero amount", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById(databind.AccountID(table[0][colRDBAccountID]))
+		assert.True(t, acc.Onboarding.Fee.IsZero())
+	})
+
+	t.Run("Should read the fee from any row of the account", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById("accountIdFor2222")
+		assert.Equal(t, "5000", acc.Onboarding.Fee.String())
+		assert.Equal(t, "2022-09-14", acc.Onboarding.Date.Format("2006-01-02"))
+	})
+
+	t.Run("Should read an N/A date as no date", func(t *testing.T) {
+		_, acc, _ := mfrBind.FindAccountById(databind.AccountID(table[3][colRDBAccountID]))
+		assert.Equal(t, "2500", acc.Onboarding.Fee.String())
+		assert.True(t, acc.Onboarding.Date.IsZero())
+	})
+
+	t.Run("Should report a bad onboarding date", func(t *testing.T) {
+		table[2][colOnboardingDate] = "next week"
+		_, err := mfr.NewMasterFeeRates(table)
+		assert.ErrorContains(t, err, "Onboarding Date")
+	})
+}
+
+func TestAccountStatus(t *testing.T) {
+	err := readCsvFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		colAccountFromRDB  = 4
+		colStatus          = 13
+		colFeeAccrualDate  = 28
+		colRDBAccountID    = 92
+		colTerminationDate = 93
+	)
+
+	newTable := func() [][]string {
+		table := make([][]string, 0, len(mfrAll))
+		for _, row := range mfrAll {
+			table = append(table, append(append([]string{}, row...), ""))
+		}
+		return table
+	}
+	alphaId := databind.AccountID(mfrAll[0][colRDBAccountID])
+
+	t.Run("Should read the fee accrual date and fall back to the onboarding date", func(t *testing.T) {
+		mfrBind, err := mfr.NewMasterFeeRates(newTable())
+		assert.NoError(t, err)
+
+		_, beta, _ := mfrBind.FindAccountById("accountIdFor2222")
+		assert.Equal(t, "2022-09-22", beta.AccrualStart().Format("2006-01-02"))
+
+		_, alpha, _ := mfrBind.FindAccountById(alphaId)
+		assert.True(t, alpha.FeeAccrualDate.IsZero())
+		assert.Equal(t, "2020-06-09", alpha.AccrualStart().Format("2006-01-02"))
+	})
+
+	t.Run("Should keep a terminated account until its termination date", func(t *testing.T) {
+		table := newTable()
+		table[0][colStatus] = "Terminated"
+		table[0][colTerminationDate] = "6/10/2023"
+
+		mfrBind, err := mfr.NewMasterFeeRates(table)
+		assert.NoError(t, err)
+
+		_, alpha, found := mfrBind.FindAccountById(alphaId)
+		if assert.True(t, found) {
+			assert.False(t, alpha.TerminatedBefore(time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)))
+			assert.True(t, alpha.TerminatedBefore(time.Date(2023, time.July, 31, 0, 0, 0, 0, time.UTC)))
+		}
+	})
+
+	t.Run("Should read the ID of an account marked Terminated from Account ID from RDB", func(t *testing.T) {
+		table := newTable()
+		table[4][colTerminationDate] = "6/10/2023"
+
+		mfrBind, err := mfr.NewMasterFeeRates(table)
+		assert.NoError(t, err)
+
+		_, acc, found := mfrBind.FindAccountById(databind.AccountID(table[4][colAccountFromRDB]))
+		if assert.True(t, found) {
+			assert.Equal(t, "2023-06-10", acc.TerminationDate.Format("2006-01-02"))
+		}
+	})
+
+	t.Run("Should skip a terminated account without a termination date and tell why", func(t *testing.T) {
+		table := newTable()
+		table[0][colStatus] = "Terminated"
+
+		mfrBind, err := mfr.NewMasterFeeRates(table)
+		assert.NoError(t, err)
+
+		_, _, found := mfrBind.FindAccountById(alphaId)
+		assert.False(t, found)
+		_, _, found = mfrBind.FindAccountById(databind.AccountID(table[4][colAccountFromRDB]))
+		assert.False(t, found)
+
+		skipped := mfrBind.GetSkippedAccounts()
+		if assert.Len(t, skipped, 2) {
+			assert.Equal(t, alphaId, skipped[0].AccountID)
+			assert.Equal(t, 4, skipped[0].Line)
+			assert.Contains(t, skipped[0].Reason, "Termination Date")
+			assert.Equal(t, databind.AccountID(table[4][colAccountFromRDB]), skipped[1].AccountID)
+		}
+	})
+
+	t.Run("Should report a bad fee accrual date", func(t *testing.T) {
+		table := newTable()
+		table[1][colFeeAccrualDate] = "when assets arrive"
+		_, err := mfr.NewMasterFeeRates(table)
+		assert.ErrorContains(t, err, "Fee Accrual Date")
+	})
+}
@@ -0,0 +1,102 @@
+package databind
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// number matches a plain number once the sign, currency and percent sign are
+// removed. Thousands separators are only accepted in groups of three.
+var number = regexp.MustCompile(`^(\d{1,3}(,\d{3})+|\d*)(\.\d*)?([eE][+-]?\d+)?$`)
+
+// spreadsheetErrors are the values Sheets and Excel show for a formula that
+// failed, they must not be read as 0.
+var spreadsheetErrors = map[string]bool{
+	"#N/A":    true,
+	"#REF!":   true,
+	"#VALUE!": true,
+	"#DIV/0!": true,
+	"#NAME?":  true,
+	"#NUM!":   true,
+	"#NULL!":  true,
+	"#ERROR!": true,
+}
+
+// ParseDecimal reads a number formatted as a spreadsheet shows it:
+// "-1,234.50", "(1,234.50)", "$1,234" or "3%", which is read as 0.03. An
+// empty cell and the accounting "-" are 0.
+func ParseDecimal(value string) (decimal.Decimal, error) {
+	d, isPercent, err := parseNumber(value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if isPercent {
+		return d.Shift(-2), nil
+	}
+	return d, nil
+}
+
+// ParsePercent reads a rate kept in percent units, as the MFR fee columns do:
+// "3%" and "3" are both 3.
+func ParsePercent(value string) (decimal.Decimal, error) {
+	d, _, err := parseNumber(value)
+	return d, err
+}
+
+// ParseInt reads a whole number, with the same formats as ParseDecimal.
+func ParseInt(value string) (int, error) {
+	d, err := ParseDecimal(value)
+	if err != nil {
+		return 0, err
+	}
+	if !d.IsInteger() {
+		return 0, errors.New("not an integer")
+	}
+	return int(d.IntPart()), nil
+}
+
+func parseNumber(value string) (decimal.Decimal, bool, error) {
+	v := strings.TrimSpace(value)
+	if v == "" {
+		return decimal.Zero, false, nil
+	}
+	if spreadsheetErrors[strings.ToUpper(v)] {
+		return decimal.Zero, false, errors.New("spreadsheet error " + v)
+	}
+
+	v = strings.NewReplacer("$", "", "€", "", "£", "", " ", "", "−", "-").Replace(v)
+	if v == "-" {
+		// Accounting format for 0
+		return decimal.Zero, false, nil
+	}
+
+	negative := false
+	if strings.HasPrefix(v, "(") && strings.HasSuffix(v, ")") {
+		negative = true
+		v = v[1 : len(v)-1]
+	}
+	if strings.HasPrefix(v, "-") || strings.HasPrefix(v, "+") {
+		negative = negative != (v[0] == '-')
+		v = v[1:]
+	}
+
+	isPercent := strings.HasSuffix(v, "%")
+	v = strings.TrimSuffix(v, "%")
+
+	if !number.MatchString(v) || strings.Trim(v, ".") == "" {
+		return decimal.Zero, false, errors.New("not a number")
+	}
+
+	d, err := decimal.NewFromString(strings.ReplaceAll(v, ",", ""))
+	if err != nil {
+		return decimal.Zero, false, errors.New("not a number")
+	}
+	if negative {
+		d = d.Neg()
+	}
+
+	return d, isPercent, nil
+}
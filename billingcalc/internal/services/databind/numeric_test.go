@@ -0,0 +1,82 @@
+//go:build !selectTest || unitTest
+
+package databind_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "0"},
+		{"1234.5", "1234.5"},
+		{"-1,234.50", "-1234.5"},
+		{"(1,234.50)", "-1234.5"},
+		{"$1,234", "1234"},
+		{"-$1,234", "-1234"},
+		{"$ (12.00)", "-12"},
+		{"3%", "0.03"},
+		{"-0.35%", "-0.0035"},
+		{" 12 ", "12"},
+		{"$ -", "0"},
+		{"1.2e+06", "1200000"},
+		{".5", "0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := databind.ParseDecimal(tt.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d.String())
+		})
+	}
+}
+
+func TestParseDecimalInvalid(t *testing.T) {
+	tests := []struct {
+		input  string
+		reason string
+	}{
+		{"abc", "not a number"},
+		{"1.2.3", "not a number"},
+		{"12,34", "not a number"},
+		{"1,2345", "not a number"},
+		{".", "not a number"},
+		{"#N/A", "spreadsheet error #N/A"},
+		{"#DIV/0!", "spreadsheet error #DIV/0!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := databind.ParseDecimal(tt.input)
+
+			assert.EqualError(t, err, tt.reason)
+		})
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	for input, expected := range map[string]string{"3%": "3", "3": "3", "0.35%": "0.35", "": "0"} {
+		d, err := databind.ParsePercent(input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, d.String(), input)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	i, err := databind.ParseInt("1,024")
+	assert.NoError(t, err)
+	assert.Equal(t, 1024, i)
+
+	_, err = databind.ParseInt("1.5")
+	assert.EqualError(t, err, "not an integer")
+}
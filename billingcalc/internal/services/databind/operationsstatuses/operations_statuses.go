@@ -1,14 +1,15 @@
 package operationsstatuses
 
 import (
-	"slices"
+	"errors"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
@@ -17,9 +18,24 @@ const (
 	ColStatusesActiveDelegatedValue databind.Column = 2 // USD
 	ColStatusesAssetType            databind.Column = 5
 	ColStatusesDate                 databind.Column = 7
+	ColStatusesIsAnchorageValidator databind.Column = 8
+	ColStatusesValidatorAddress     databind.Column = 18
+	ColStatusesValidatorID          databind.Column = 19
 	ColCosmosValidatorsRate         databind.Column = 24
 )
 
+// Schema finds the columns of the operations statuses export by their header.
+var Schema = databind.Schema{
+	{Col: ColStatusesAccountName, Header: "Delegation Statuses Account Name"},
+	{Col: ColStatusesActiveDelegatedValue, Header: "Delegation Statuses Active Delegated Value USD"},
+	{Col: ColStatusesAssetType, Header: "Delegation Statuses Asset Type"},
+	{Col: ColStatusesDate, Header: "Delegation Statuses Date Date", Aliases: []string{"Delegation Statuses Date"}, DateFormat: date.MonthFirst},
+	{Col: ColStatusesIsAnchorageValidator, Header: "Delegation Statuses Is Anchorage Validator (Yes / No)", Optional: true},
+	{Col: ColStatusesValidatorAddress, Header: "Delegation Statuses Validator Address", Optional: true},
+	{Col: ColStatusesValidatorID, Header: "Delegation Statuses Validator ID", Optional: true},
+	{Col: ColCosmosValidatorsRate, Header: "Cosmos Validators Rates Rate"},
+}
+
 type OperationsStatuses struct {
 	Accounts map[string]Account
 }
@@ -33,6 +49,10 @@ type Status struct {
 	StatusesActiveDelegatedValue decimal.Decimal
 	StatusesAssetType            string
 	StatusesDate                 time.Time
+	// IsAnchorageValidator is "Yes", "No" or empty when the export doesn't say.
+	IsAnchorageValidator string
+	ValidatorAddress     string
+	ValidatorID          string
 }
 
 func (c *OperationsStatuses) IsEmpty() bool {
@@ -57,12 +77,33 @@ func (c *OperationsStatuses) GetStatusByAssetAndDate(account string, asset strin
 	return status
 }
 
-func NewOperationsStatuses(table [][]string) *OperationsStatuses {
+// GetStatusesByAsset returns the statuses of the asset from one day to
+// another, both included, ordered by date.
+func (c *OperationsStatuses) GetStatusesByAsset(account string, asset string, from time.Time, to time.Time) []Status {
+	var statuses []Status
+	for _, row := range c.Accounts[account].Statuses {
+		if row.StatusesAssetType != asset || row.StatusesDate.Before(from) || row.StatusesDate.After(to) {
+			continue
+		}
+		statuses = append(statuses, row)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].StatusesDate.Before(statuses[j].StatusesDate)
+	})
+	return statuses
+}
+
+// NewOperationsStatuses binds the data rows. Every bad value is collected and
+// returned together as a *databind.ParseErrors.
+func NewOperationsStatuses(table [][]string) (*OperationsStatuses, error) {
 	operationsStatuses := &OperationsStatuses{
 		Accounts: make(map[string]Account),
 	}
+	errs := &databind.ParseErrors{}
 
-	for _, row := range table {
+	for i, row := range table {
+		c := databind.Cells{Row: row, Line: i + 1, Schema: Schema, Errs: errs}
 		accountName := sanitization.SanitizeName(row[ColStatusesAccountName])
 
 		acc, exists := operationsStatuses.Accounts[accountName]
@@ -72,29 +113,55 @@ func NewOperationsStatuses(table [][]string) *OperationsStatuses {
 			}
 		}
 
-		decimalActiveDelegatedValue, _ := decimal.NewFromString(row[ColStatusesActiveDelegatedValue])
-
-		acc.Statuses = append(acc.Statuses, Status{
+		status := Status{
 			CosmosValidatorsRatesRate:    row[ColCosmosValidatorsRate],
-			StatusesActiveDelegatedValue: decimalActiveDelegatedValue,
+			StatusesActiveDelegatedValue: c.Decimal(ColStatusesActiveDelegatedValue),
 			StatusesAssetType:            row[ColStatusesAssetType],
-			StatusesDate:                 converter.FromStrToTimeStamp(row[ColStatusesDate]),
-		})
+			StatusesDate:                 c.Date(ColStatusesDate),
+			IsAnchorageValidator:         strings.TrimSpace(row[ColStatusesIsAnchorageValidator]),
+			ValidatorAddress:             strings.TrimSpace(row[ColStatusesValidatorAddress]),
+			ValidatorID:                  strings.TrimSpace(row[ColStatusesValidatorID]),
+		}
+		if _, err := status.Commission(); err != nil {
+			errs.Add(databind.CellError{Row: c.Line, Column: Schema.Header(ColCosmosValidatorsRate), Value: row[ColCosmosValidatorsRate], Reason: err.Error()})
+		}
+		acc.Statuses = append(acc.Statuses, status)
 
 		operationsStatuses.Accounts[accountName] = acc
 	}
 
-	return operationsStatuses
-}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
 
-func IsAssetFromExternalValidator(operationStatus Status) bool {
-	cosmosAssets := []string{"OSMO", "HASH", "ATOM", "AXL", "EVMOS", "SEI", "SUI"}
+	return operationsStatuses, nil
+}
 
-	isAssetsInCosmosAssetsList := slices.IndexFunc(cosmosAssets, func(cosmoAsset string) bool {
-		return cosmoAsset == strings.ToUpper(operationStatus.StatusesAssetType)
-	}) != -1
+// Commission is the rate the validator keeps from the rewards, as a fraction:
+// "95%", "95.00%" and "0.95" are all 0.95. An empty rate is no commission.
+func (s Status) Commission() (decimal.Decimal, error) {
+	rate, err := databind.ParseDecimal(s.CosmosValidatorsRatesRate)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(1)) {
+		return decimal.Zero, errors.New("commission rate is not between 0% and 100%")
+	}
+	return rate, nil
+}
 
-	isFullRate := operationStatus.CosmosValidatorsRatesRate == "100.00%" || operationStatus.CosmosValidatorsRatesRate == "100%" || operationStatus.CosmosValidatorsRatesRate == "1"
+// ValidatorKey identifies the validator of the status, by ID or else by
+// address. It is empty when the export names no validator.
+func (s Status) ValidatorKey() string {
+	if s.ValidatorID != "" {
+		return s.ValidatorID
+	}
+	return s.ValidatorAddress
+}
 
-	return isAssetsInCosmosAssetsList && isFullRate
+// IsAssetFromExternalValidator tells if the validator of the status keeps all
+// the rewards.
+func IsAssetFromExternalValidator(operationStatus Status) bool {
+	commission, err := operationStatus.Commission()
+	return err == nil && commission.Equal(decimal.NewFromInt(1))
 }
@@ -30,12 +30,12 @@ var tests = []struct {
 		StatusesAssetType:            "HASH",
 		StatusesDate:                 time.Date(2007, 1, 1, 0, 0, 0, 0, time.UTC),
 	}, false},
-	{"is not asset from external validator if asset is ROSE", operationsstatuses.Status{
-		CosmosValidatorsRatesRate:    "100.00%",
+	{"is asset from external validator whatever the asset", operationsstatuses.Status{
+		CosmosValidatorsRatesRate:    "1",
 		StatusesActiveDelegatedValue: newActiveDelegatedValue,
 		StatusesAssetType:            "ROSE",
 		StatusesDate:                 time.Date(2007, 1, 1, 0, 0, 0, 0, time.UTC),
-	}, false},
+	}, true},
 }
 
 func TestIsAssetFromExternalValidator(t *testing.T) {
@@ -49,6 +49,32 @@ func TestIsAssetFromExternalValidator(t *testing.T) {
 	}
 }
 
+func TestCommission(t *testing.T) {
+	cases := []struct {
+		rate    string
+		want    string
+		wantErr bool
+	}{
+		{"95.00%", "0.95", false},
+		{"0.5", "0.5", false},
+		{"", "0", false},
+		{"150%", "0", true},
+		{"N/A", "0", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.rate, func(t *testing.T) {
+			got, err := operationsstatuses.Status{CosmosValidatorsRatesRate: c.rate}.Commission()
+			if (err != nil) != c.wantErr {
+				t.Errorf("Commission(%q) error %v, want error %v", c.rate, err, c.wantErr)
+			}
+			if !got.Equal(decimal.RequireFromString(c.want)) {
+				t.Errorf("Commission(%q) got %s, want %s", c.rate, got, c.want)
+			}
+		})
+	}
+}
+
 func TestIsEmpty(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -134,7 +160,10 @@ func TestNewOperationsStatuses(t *testing.T) {
 		{"Test Alpha Account3", "", "3349200.01", "", "", "CELO", "", "2023-06-29", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "100.00%", "", ""},
 	}
 
-	result := operationsstatuses.NewOperationsStatuses(table)
+	result, err := operationsstatuses.NewOperationsStatuses(table)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if len(result.Accounts) == 0 {
 		t.Error("TestNewOperationsStatuses: expected non-empty accounts")
@@ -3,6 +3,7 @@ package operationsstatusesbq
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/bigquery"
@@ -181,20 +182,23 @@ func StructToSlice(iter bigqueryutils.ResultIterator) ([][]string, error) {
 		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesActiveDelegatedValue), bigqueryRow.DELEGATION_STATUSES_ACTIVE_DELEGATED_VALUE_USD.StringVal)
 		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesAssetType), bigqueryRow.DELEGATION_STATUSES_ASSET_TYPE.StringVal)
 		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesDate), bigqueryRow.DELEGATION_STATUSES_DATE_DATE.StringVal)
-		newRow = slices.Insert(newRow, int(operationsstatuses.ColCosmosValidatorsRate), getExternalValidatorPercentage(bigqueryRow.DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR.StringVal))
+		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesIsAnchorageValidator), bigqueryRow.DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR.StringVal)
+		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesValidatorAddress), bigqueryRow.DELEGATION_STATUSES_VALIDATOR_ADDRESS.StringVal)
+		newRow = slices.Insert(newRow, int(operationsstatuses.ColStatusesValidatorID), bigqueryRow.DELEGATION_STATUSES_VALIDATOR_ID.StringVal)
+		newRow = slices.Insert(newRow, int(operationsstatuses.ColCosmosValidatorsRate), getExternalValidatorPercentage(bigqueryRow.DELEGATION_STATUSES_ASSET_TYPE.StringVal, bigqueryRow.DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR.StringVal))
 		table = append(table, newRow)
 	}
 
 	return table, nil
 }
 
-func getExternalValidatorPercentage(isAnchorageValidator string) string {
-	var cosmosValidatorsRate string
+// fullCommissionAssets are the assets whose third party validators are
+// billed as keeping all the rewards, BigQuery has no commission rates.
+var fullCommissionAssets = map[string]bool{"OSMO": true, "HASH": true, "ATOM": true, "AXL": true, "EVMOS": true, "SEI": true, "SUI": true}
 
-	if isAnchorageValidator == "Yes" {
-		cosmosValidatorsRate = "0"
-	} else {
-		cosmosValidatorsRate = "100.00%"
+func getExternalValidatorPercentage(assetType string, isAnchorageValidator string) string {
+	if isAnchorageValidator == "Yes" || !fullCommissionAssets[strings.ToUpper(assetType)] {
+		return "0"
 	}
-	return cosmosValidatorsRate
+	return "100.00%"
 }
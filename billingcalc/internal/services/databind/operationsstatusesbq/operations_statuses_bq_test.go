@@ -71,6 +71,22 @@ func TestStructToSlice(t *testing.T) {
 			DELEGATION_STATUSES_DATE_DATE:                  bigquery.NullString{StringVal: "2024-01-29", Valid: true},
 			DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR:     bigquery.NullString{StringVal: "Yes", Valid: true},
 		},
+		{
+			DELEGATION_STATUSES_ACCOUNT_NAME:               bigquery.NullString{StringVal: "Account3", Valid: true},
+			DELEGATION_STATUSES_ACTIVE_DELEGATED_VALUE_USD: bigquery.NullString{StringVal: "500.00", Valid: true},
+			DELEGATION_STATUSES_ASSET_TYPE:                 bigquery.NullString{StringVal: "HASH", Valid: true},
+			DELEGATION_STATUSES_DATE_DATE:                  bigquery.NullString{StringVal: "2024-01-29", Valid: true},
+			DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR:     bigquery.NullString{StringVal: "No", Valid: true},
+			DELEGATION_STATUSES_VALIDATOR_ADDRESS:          bigquery.NullString{StringVal: "pbvaloper1abc", Valid: true},
+			DELEGATION_STATUSES_VALIDATOR_ID:               bigquery.NullString{StringVal: "val-1", Valid: true},
+		},
+		{
+			DELEGATION_STATUSES_ACCOUNT_NAME:               bigquery.NullString{StringVal: "Account4", Valid: true},
+			DELEGATION_STATUSES_ACTIVE_DELEGATED_VALUE_USD: bigquery.NullString{StringVal: "700.00", Valid: true},
+			DELEGATION_STATUSES_ASSET_TYPE:                 bigquery.NullString{StringVal: "SOL", Valid: true},
+			DELEGATION_STATUSES_DATE_DATE:                  bigquery.NullString{StringVal: "2024-01-29", Valid: true},
+			DELEGATION_STATUSES_IS_ANCHORAGE_VALIDATOR:     bigquery.NullString{StringVal: "No", Valid: true},
+		},
 	}
 
 	iter := &MockResultIterator{Data: mockData}
@@ -81,8 +97,10 @@ func TestStructToSlice(t *testing.T) {
 	}
 
 	expected := [][]string{
-		{"Account1", "", "1000.00", "", "", "AssetType1", "", "2024-01-29", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "0"},
-		{"Account2", "", "31231.00", "", "", "AssetType1", "", "2024-01-29", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "0"},
+		{"Account1", "", "1000.00", "", "", "AssetType1", "", "2024-01-29", "Yes", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "0"},
+		{"Account2", "", "31231.00", "", "", "AssetType1", "", "2024-01-29", "Yes", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "0"},
+		{"Account3", "", "500.00", "", "", "HASH", "", "2024-01-29", "No", "", "", "", "", "", "", "", "", "", "pbvaloper1abc", "val-1", "", "", "", "", "100.00%"},
+		{"Account4", "", "700.00", "", "", "SOL", "", "2024-01-29", "No", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "0"},
 	}
 
 	assert.NoError(t, err, "StructToSlice should not return an error")
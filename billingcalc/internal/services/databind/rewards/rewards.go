@@ -1,12 +1,15 @@
 package rewards
 
 import (
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
@@ -15,6 +18,7 @@ const (
 	ColAccount       databind.Column = 1
 	ColOpeType       databind.Column = 5
 	ColAsset         databind.Column = 6
+	ColSourceAddrs   databind.Column = 9
 	ColAnchAssetQty  databind.Column = 11
 	ColAnchValue     databind.Column = 12
 	ColThirdPtQty    databind.Column = 13
@@ -23,6 +27,21 @@ const (
 	ColAccInternalID databind.Column = 18
 )
 
+// Schema finds the columns of the rewards export by their header.
+var Schema = databind.Schema{
+	{Col: ColOrganization, Header: "Operations Organization Name"},
+	{Col: ColAccount, Header: "Operations Account Name"},
+	{Col: ColOpeType, Header: "Operations Type"},
+	{Col: ColAsset, Header: "Operations Asset Type"},
+	{Col: ColSourceAddrs, Header: "Operations Source Addresses", Optional: true},
+	{Col: ColAnchAssetQty, Header: "Operations Total Anchorage Reward Part"},
+	{Col: ColAnchValue, Header: "Operations Total Anchorage USD Reward Part"},
+	{Col: ColThirdPtQty, Header: "Operations Total Non Anchorage Reward Part"},
+	{Col: ColThirdPtValue, Header: "Operations Total Non Anchorage USD Reward Part"},
+	{Col: ColBizDay, Header: "Business Day", Aliases: []string{"Operations Business Day"}, DateFormat: date.MonthFirst},
+	{Col: ColAccInternalID, Header: "Operations Client Internal ID", Aliases: []string{"Operations Account Internal ID", "Account Internal ID"}, Optional: true},
+}
+
 type Rewards struct {
 	organizations map[string]Organization
 }
@@ -46,14 +65,35 @@ type Asset struct {
 }
 
 type ClaimedReward struct {
-	AnchorageAssetQty  decimal.Decimal
-	AnchorageUsdValue  decimal.Decimal
-	BusinessDay        time.Time
-	OperationType      string
+	AnchorageAssetQty decimal.Decimal
+	AnchorageUsdValue decimal.Decimal
+	BusinessDay       time.Time
+	OperationType     string
+	// SourceAddresses are the addresses the reward was paid from, the
+	// validator address for delegation rewards.
+	SourceAddresses    []string
 	ThirdPartyAssetQty decimal.Decimal
 	ThirdPartyUsdValue decimal.Decimal
 }
 
+// PaidBy tells if address is one of the source addresses of the reward.
+func (c ClaimedReward) PaidBy(address string) bool {
+	for _, source := range c.SourceAddresses {
+		if address != "" && source == address {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAddresses reads a list of addresses separated by commas, semicolons or
+// spaces.
+func splitAddresses(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+}
+
 func (r *Rewards) GetOrganizationNames() map[string]Organization {
 	return r.organizations
 }
@@ -63,9 +103,17 @@ func (r *Rewards) GetAccountNames(organization string) map[string]Account {
 	return org.accounts
 }
 
+// GetAccountById returns the account with the given internal ID, looking at
+// the organizations by name so the same one is found on every call.
 func (r *Rewards) GetAccountById(accountId databind.AccountID) Account {
-	for _, o := range r.organizations {
-		for _, a := range o.accounts {
+	names := make([]string, 0, len(r.organizations))
+	for name := range r.organizations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, a := range r.organizations[name].accounts {
 			if a.Id == accountId {
 				return a
 			}
@@ -78,6 +126,21 @@ func (a *Account) GetAssets() map[string]Asset {
 	return a.assets
 }
 
+// GetSortedAssets returns the assets of the account ordered by name.
+func (a *Account) GetSortedAssets() []Asset {
+	names := make([]string, 0, len(a.assets))
+	for name := range a.assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assets := make([]Asset, 0, len(names))
+	for _, name := range names {
+		assets = append(assets, a.assets[name])
+	}
+	return assets
+}
+
 func (a *Asset) GetClaimedRewards() []ClaimedReward {
 	return a.claimedRewards
 }
@@ -86,12 +149,16 @@ func (r *Rewards) IsEmpty() bool {
 	return len(r.organizations) == 0
 }
 
-func NewRewards(table [][]string) *Rewards {
+// NewRewards binds the data rows. Every bad value is collected and returned
+// together as a *databind.ParseErrors.
+func NewRewards(table [][]string) (*Rewards, error) {
 	rewards := &Rewards{
 		organizations: make(map[string]Organization),
 	}
+	errs := &databind.ParseErrors{}
 
-	for _, row := range table {
+	for i, row := range table {
+		c := databind.Cells{Row: row, Line: i + 1, Schema: Schema, Errs: errs}
 		organizationName := sanitization.SanitizeName(row[ColOrganization])
 		org, exists := rewards.organizations[organizationName]
 		if !exists {
@@ -123,12 +190,13 @@ func NewRewards(table [][]string) *Rewards {
 		}
 
 		claimedReward := ClaimedReward{
-			AnchorageAssetQty:  converter.FromStrToDecimal(row[ColAnchAssetQty]),
-			AnchorageUsdValue:  converter.FromStrToDecimal(row[ColAnchValue]),
-			BusinessDay:        converter.FromStrToTimeStamp(row[ColBizDay]),
+			AnchorageAssetQty:  c.Decimal(ColAnchAssetQty),
+			AnchorageUsdValue:  c.Decimal(ColAnchValue),
+			BusinessDay:        c.Date(ColBizDay),
 			OperationType:      row[ColOpeType],
-			ThirdPartyAssetQty: converter.FromStrToDecimal(row[ColThirdPtQty]),
-			ThirdPartyUsdValue: converter.FromStrToDecimal(row[ColThirdPtValue]),
+			SourceAddresses:    splitAddresses(row[ColSourceAddrs]),
+			ThirdPartyAssetQty: c.Decimal(ColThirdPtQty),
+			ThirdPartyUsdValue: c.Decimal(ColThirdPtValue),
 		}
 
 		asset.claimedRewards = append(asset.claimedRewards, claimedReward)
@@ -137,5 +205,9 @@ func NewRewards(table [][]string) *Rewards {
 		rewards.organizations[organizationName] = org
 	}
 
-	return rewards
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
 }
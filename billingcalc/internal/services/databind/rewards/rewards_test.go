@@ -18,11 +18,15 @@ func GetRewards() *rewards.Rewards {
 		{"Org1", "Acc1", "Col2", "Col3", "Col4", "Delegation Reward", "ETH", "Col7", "Col8", "Col9", "Col10", "1.00", "1.50", "0.00", "0.00", "Col15", "Col16", "01/01/2023", "AccId1-1"},
 		{"Org1", "Acc1", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "0.50", "1.50", "0.00", "0.00", "Col15", "Col16", "01/01/2023", "AccId1-1"},
 		{"Org1", "Acc1", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "0.30", "1.80", "0.20", "1.00", "Col15", "Col16", "01/01/2023", "AccId1-1"},
-		{"Org1", "Acc2", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "Col11", "5.00", "Col13", "2.00", "Col15", "Col16", "01/01/2023", "AccId1-2"},
-		{"Org2", "Acc21", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "Col11", "1.99", "Col13", "0.00", "Col15", "Col16", "01/01/2023", "AccId2-21"},
+		{"Org1", "Acc2", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "11", "5.00", "13", "2.00", "Col15", "Col16", "01/01/2023", "AccId1-2"},
+		{"Org2", "Acc21", "Col2", "Col3", "Col4", "Delegation Reward", "BTC", "Col7", "Col8", "Col9", "Col10", "11", "1.99", "13", "0.00", "Col15", "Col16", "01/01/2023", "AccId2-21"},
 	}
 
-	return rewards.NewRewards(table)
+	r, err := rewards.NewRewards(table)
+	if err != nil {
+		panic(err)
+	}
+	return r
 }
 
 func TestLookupFunctions(t *testing.T) {
@@ -82,6 +86,7 @@ func TestLookupFunctions(t *testing.T) {
 				AnchorageUsdValue:  newDecimalFromString("1.50"),
 				BusinessDay:        date,
 				OperationType:      opeType,
+				SourceAddresses:    []string{"Col9"},
 				ThirdPartyAssetQty: newDecimalFromString("0.00"),
 				ThirdPartyUsdValue: newDecimalFromString("0.00"),
 			},
@@ -90,6 +95,7 @@ func TestLookupFunctions(t *testing.T) {
 				AnchorageUsdValue:  newDecimalFromString("1.80"),
 				BusinessDay:        date,
 				OperationType:      opeType,
+				SourceAddresses:    []string{"Col9"},
 				ThirdPartyAssetQty: newDecimalFromString("0.20"),
 				ThirdPartyUsdValue: newDecimalFromString("1.00"),
 			},
@@ -111,6 +117,23 @@ func TestLookupFunctions(t *testing.T) {
 	})
 }
 
+func TestNewRewardsWithInvalidValues(t *testing.T) {
+	table := [][]string{
+		{"Org1", "Acc1", "Col2", "Col3", "Col4", "Delegation Reward", "ETH", "Col7", "Col8", "Col9", "Col10", "1.00", "1.50", "0.00", "0.00", "Col15", "Col16", "01/01/2023", "AccId1-1"},
+		{"Org1", "Acc1", "Col2", "Col3", "Col4", "Delegation Reward", "ETH", "Col7", "Col8", "Col9", "Col10", "1.00", "#N/A", "0.00", "0.00", "Col15", "Col16", "31/01/2023", "AccId1-1"},
+	}
+
+	_, err := rewards.NewRewards(table)
+
+	parseErrs, ok := err.(*databind.ParseErrors)
+	if assert.True(t, ok, "expected *databind.ParseErrors, got %v", err) {
+		assert.Equal(t, []databind.CellError{
+			{Row: 2, Column: "Operations Total Anchorage USD Reward Part", Value: "#N/A", Reason: "spreadsheet error #N/A"},
+			{Row: 2, Column: "Business Day", Value: "31/01/2023", Reason: "not a date, expected YYYY-MM-DD or MM/DD/YYYY"},
+		}, parseErrs.Errors)
+	}
+}
+
 func newDecimalFromString(value string) decimal.Decimal {
 	str, _ := decimal.NewFromString(value)
 	return str
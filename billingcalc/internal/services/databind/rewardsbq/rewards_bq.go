@@ -148,6 +148,7 @@ func StructToSlice(iter bigqueryutils.ResultIterator) ([][]string, error) {
 		newRow = slices.Insert(newRow, int(rewards.ColAccount), bigqueryRow.OPERATIONS_ACCOUNT_NAME.StringVal)
 		newRow = slices.Insert(newRow, int(rewards.ColOpeType), bigqueryRow.OPERATIONS_TYPE.StringVal)
 		newRow = slices.Insert(newRow, int(rewards.ColAsset), bigqueryRow.OPERATIONS_ASSET_TYPE.StringVal)
+		newRow = slices.Insert(newRow, int(rewards.ColSourceAddrs), bigqueryRow.OPERATIONS_SOURCE_ADDRESSES.StringVal)
 		newRow = slices.Insert(newRow, int(rewards.ColAnchAssetQty), bigqueryRow.OPERATIONS_TOTAL_ASSET_QUANTITY.StringVal)
 		newRow = slices.Insert(newRow, int(rewards.ColAnchValue), bigqueryRow.OPERATIONS_TOTAL_ANCHORAGE_USD_REWARD_PART.StringVal)
 		newRow = slices.Insert(newRow, int(rewards.ColThirdPtQty), bigqueryRow.OPERATIONS_TOTAL_NON_ANCHORAGE_REWARD_PART.StringVal)
@@ -61,6 +61,7 @@ func TestStructToSlice(t *testing.T) {
 			OPERATIONS_TYPE:                                bigquery.NullString{StringVal: "Delegation Reward", Valid: true},
 			OPERATIONS_TOTAL_ASSET_QUANTITY:                bigquery.NullString{StringVal: "124.07", Valid: true},
 			OPERATIONS_ASSET_TYPE:                          bigquery.NullString{StringVal: "ROSE", Valid: true},
+			OPERATIONS_SOURCE_ADDRESSES:                    bigquery.NullString{StringVal: "oasis1validator", Valid: true},
 			OPERATIONS_TOTAL_ANCHORAGE_USD_REWARD_PART:     bigquery.NullString{StringVal: "12.00", Valid: true},
 			OPERATIONS_TOTAL_NON_ANCHORAGE_REWARD_PART:     bigquery.NullString{StringVal: "1.27", Valid: true},
 			OPERATIONS_TOTAL_NON_ANCHORAGE_USD_REWARD_PART: bigquery.NullString{StringVal: "1.27", Valid: true},
@@ -77,7 +78,7 @@ func TestStructToSlice(t *testing.T) {
 
 	expected := [][]string{
 		{
-			"Org1", "Account1", "", "", "", "Delegation Reward", "ROSE", "", "", "", "", "124.07", "12.00", "1.27", "1.27", "", "", "2024-01-01", "",
+			"Org1", "Account1", "", "", "", "Delegation Reward", "ROSE", "", "", "oasis1validator", "", "124.07", "12.00", "1.27", "1.27", "", "", "2024-01-01", "",
 		},
 	}
 
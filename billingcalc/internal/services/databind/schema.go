@@ -0,0 +1,109 @@
+package databind
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+// ColumnSpec declares a report column. Col is the position the parser reads
+// it from, Header and Aliases are the names it can have in the header row of
+// the report. DateFormat is how the report writes the dates of a date column.
+type ColumnSpec struct {
+	Col        Column
+	Header     string
+	Aliases    []string
+	Optional   bool
+	DateFormat date.Format
+}
+
+// Schema declares every column a parser reads.
+type Schema []ColumnSpec
+
+// MissingColumnsError lists the required columns that were not found in the
+// header row.
+type MissingColumnsError struct {
+	Columns []string
+}
+
+func (e *MissingColumnsError) Error() string {
+	return fmt.Sprintf("Missing columns: %s", strings.Join(e.Columns, ", "))
+}
+
+// Bind finds the columns of the schema by name in the header row and returns
+// the rows rearranged so each column sits at its Col, the layout the parsers
+// read. Missing optional columns are left empty, missing required ones are
+// reported by name.
+//
+// Headers are matched ignoring case, surrounding spaces and underscores, so
+// a BigQuery column name like operations_asset_type also matches "Operations
+// Asset Type".
+func (s Schema) Bind(header []string, rows [][]string) ([][]string, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if _, exists := positions[key]; !exists && key != "" {
+			positions[key] = i
+		}
+	}
+
+	width := 0
+	indexes := make([]int, len(s))
+	missing := []string{}
+	for i, spec := range s {
+		indexes[i] = spec.find(positions)
+		if indexes[i] < 0 && !spec.Optional {
+			missing = append(missing, spec.Header)
+		}
+		if int(spec.Col) >= width {
+			width = int(spec.Col) + 1
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, &MissingColumnsError{Columns: missing}
+	}
+
+	bound := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		newRow := make([]string, width)
+		for i, spec := range s {
+			if indexes[i] >= 0 && indexes[i] < len(row) {
+				newRow[spec.Col] = row[indexes[i]]
+			}
+		}
+		bound = append(bound, newRow)
+	}
+
+	return bound, nil
+}
+
+func (s Schema) spec(col Column) ColumnSpec {
+	for _, spec := range s {
+		if spec.Col == col {
+			return spec
+		}
+	}
+	return ColumnSpec{Col: col, Header: fmt.Sprintf("column %d", col)}
+}
+
+// Header returns the header name declared for col, or its index when col is
+// not part of the schema.
+func (s Schema) Header(col Column) string {
+	return s.spec(col).Header
+}
+
+func (c ColumnSpec) find(positions map[string]int) int {
+	for _, name := range append([]string{c.Header}, c.Aliases...) {
+		if i, exists := positions[normalizeHeader(name)]; exists {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeHeader(name string) string {
+	name = strings.ReplaceAll(name, "_", " ")
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
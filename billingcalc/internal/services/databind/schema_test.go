@@ -0,0 +1,50 @@
+//go:build !selectTest || unitTest
+
+package databind_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+)
+
+var testSchema = databind.Schema{
+	{Col: 0, Header: "Account Name"},
+	{Col: 1, Header: "Asset Type", Aliases: []string{"Operations Asset Type"}},
+	{Col: 3, Header: "USD Value", Optional: true},
+}
+
+func TestSchemaBind(t *testing.T) {
+	t.Run("Should find the columns by header", func(t *testing.T) {
+		header := []string{"Inserted", "asset_type", " account  name "}
+		rows := [][]string{{"x", "ATOM", "Acc1"}}
+
+		table, err := testSchema.Bind(header, rows)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Acc1", "ATOM", "", ""}}, table)
+	})
+
+	t.Run("Should accept aliases", func(t *testing.T) {
+		header := []string{"Account Name", "Operations Asset Type", "USD Value"}
+		rows := [][]string{{"Acc1", "SOL", "10"}, {"Acc2"}}
+
+		table, err := testSchema.Bind(header, rows)
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Acc1", "SOL", "", "10"}, {"Acc2", "", "", ""}}, table)
+	})
+
+	t.Run("Should report missing required columns by name", func(t *testing.T) {
+		_, err := testSchema.Bind([]string{"USD Value"}, nil)
+
+		assert.EqualError(t, err, "Missing columns: Account Name, Asset Type")
+
+		missing, ok := err.(*databind.MissingColumnsError)
+		if assert.True(t, ok) {
+			assert.Equal(t, []string{"Account Name", "Asset Type"}, missing.Columns)
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package trades
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+const (
+	ColTradeID     databind.Column = 0
+	ColTradeDate   databind.Column = 1
+	ColAccountID   databind.Column = 2
+	ColAsset       databind.Column = 3
+	ColSide        databind.Column = 4
+	ColQuantity    databind.Column = 5
+	ColNotionalUSD databind.Column = 6
+	ColFeeRate     databind.Column = 7
+
+	reportName = "Trade Activity Report"
+)
+
+// Schema finds the columns of the trade activity export by their header.
+// Fee Rate is the rate stated on the order, in percent units, which the
+// clients on the Standard brokerage schedule pay.
+var Schema = databind.Schema{
+	{Col: ColTradeID, Header: "Trade ID"},
+	{Col: ColTradeDate, Header: "Trade Date", DateFormat: date.MonthFirst},
+	{Col: ColAccountID, Header: "RDB Account ID", Aliases: []string{"Account ID"}},
+	{Col: ColAsset, Header: "Asset"},
+	{Col: ColSide, Header: "Side", Optional: true},
+	{Col: ColQuantity, Header: "Quantity", Optional: true},
+	{Col: ColNotionalUSD, Header: "Notional USD", Aliases: []string{"Notional Value USD"}},
+	{Col: ColFeeRate, Header: "Fee Rate", Aliases: []string{"Fee Rate %"}, Optional: true},
+}
+
+type Trades struct {
+	accounts map[databind.AccountID][]Trade
+}
+
+type Trade struct {
+	Id          string
+	Date        time.Time
+	AccountId   databind.AccountID
+	Asset       string
+	Side        string
+	Quantity    decimal.Decimal
+	NotionalUsd decimal.Decimal
+	// FeeRate is only meaningful when HasFeeRate, an order without a stated
+	// rate leaves the column empty.
+	FeeRate    decimal.Decimal
+	HasFeeRate bool
+}
+
+// NewTrades binds the data rows, skipping the ones without an account or
+// asset. Every bad value is collected and returned together as a
+// *databind.ParseErrors.
+func NewTrades(table [][]string) (*Trades, error) {
+	trades := &Trades{
+		accounts: make(map[databind.AccountID][]Trade),
+	}
+	errs := &databind.ParseErrors{}
+
+	for i, row := range table {
+		if row[ColAccountID] == "" || row[ColAsset] == "" {
+			continue
+		}
+		c := databind.Cells{Row: row, Line: i + 1, Report: reportName, Schema: Schema, Errs: errs}
+
+		trade := Trade{
+			Id:          row[ColTradeID],
+			Date:        c.Date(ColTradeDate),
+			AccountId:   databind.AccountID(strings.TrimSpace(row[ColAccountID])),
+			Asset:       strings.TrimSpace(row[ColAsset]),
+			Side:        row[ColSide],
+			Quantity:    c.Decimal(ColQuantity),
+			NotionalUsd: c.Decimal(ColNotionalUSD),
+		}
+		if strings.TrimSpace(row[ColFeeRate]) != "" {
+			trade.FeeRate = c.Percent(ColFeeRate)
+			trade.HasFeeRate = true
+		}
+
+		trades.accounts[trade.AccountId] = append(trades.accounts[trade.AccountId], trade)
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return trades, nil
+}
+
+// GetAccountTrades returns the trades of the account ordered by date and
+// trade ID.
+func (t *Trades) GetAccountTrades(accountId databind.AccountID) []Trade {
+	trades := append([]Trade(nil), t.accounts[accountId]...)
+	sort.SliceStable(trades, func(i, j int) bool {
+		if !trades[i].Date.Equal(trades[j].Date) {
+			return trades[i].Date.Before(trades[j].Date)
+		}
+		return trades[i].Id < trades[j].Id
+	})
+	return trades
+}
+
+// GetAccountIds returns the accounts with trades, sorted.
+func (t *Trades) GetAccountIds() []databind.AccountID {
+	ids := make([]databind.AccountID, 0, len(t.accounts))
+	for id := range t.accounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (t *Trades) IsEmpty() bool {
+	return len(t.accounts) == 0
+}
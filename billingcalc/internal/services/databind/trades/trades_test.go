@@ -0,0 +1,58 @@
+//go:build !selectTest || unitTest
+
+package trades_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+)
+
+func TestNewTrades(t *testing.T) {
+	table := [][]string{
+		{"T-2", "06/12/2023", "acc1", "BTC", "Buy", "1.5", "$45,000.00", ""},
+		{"T-1", "06/12/2023", "acc1", "ETH", "Sell", "10", "18000", "0.3%"},
+		{"T-3", "06/01/2023", "acc1", "BTC", "Buy", "0.5", "15000", "0.25"},
+		{"T-4", "06/02/2023", "acc2", "SOL", "Buy", "100", "2000", ""},
+		{"T-5", "06/02/2023", "", "SOL", "Buy", "100", "2000", ""},
+	}
+
+	data, err := trades.NewTrades(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should list the accounts with trades", func(t *testing.T) {
+		assert.Equal(t, []databind.AccountID{"acc1", "acc2"}, data.GetAccountIds())
+	})
+
+	t.Run("Should sort the trades by date and ID", func(t *testing.T) {
+		accTrades := data.GetAccountTrades("acc1")
+		if assert.Len(t, accTrades, 3) {
+			assert.Equal(t, "T-3", accTrades[0].Id)
+			assert.Equal(t, "T-1", accTrades[1].Id)
+			assert.Equal(t, "T-2", accTrades[2].Id)
+		}
+	})
+
+	t.Run("Should read the stated fee rate in percent units", func(t *testing.T) {
+		accTrades := data.GetAccountTrades("acc1")
+		assert.True(t, accTrades[1].HasFeeRate)
+		assert.Equal(t, "0.3", accTrades[1].FeeRate.String())
+		assert.False(t, accTrades[2].HasFeeRate)
+		assert.Equal(t, "45000", accTrades[2].NotionalUsd.String())
+	})
+
+	t.Run("Should report bad values", func(t *testing.T) {
+		_, err := trades.NewTrades([][]string{{"T-1", "06/31/2023", "acc1", "BTC", "Buy", "1", "abc", ""}})
+
+		var parseErrs *databind.ParseErrors
+		if assert.True(t, errors.As(err, &parseErrs)) {
+			assert.Len(t, parseErrs.Errors, 2)
+		}
+	})
+}
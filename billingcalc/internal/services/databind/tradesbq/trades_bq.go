@@ -0,0 +1,91 @@
+package tradesbq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/slices"
+)
+
+type Trades struct {
+	TRADE_ID     bigquery.NullString `bigquery:"trade_id"`
+	TRADE_DATE   bigquery.NullString `bigquery:"trade_date"`
+	ACCOUNT_ID   bigquery.NullString `bigquery:"account_id"`
+	ASSET        bigquery.NullString `bigquery:"asset"`
+	SIDE         bigquery.NullString `bigquery:"side"`
+	QUANTITY     bigquery.NullString `bigquery:"quantity"`
+	NOTIONAL_USD bigquery.NullString `bigquery:"notional_usd"`
+	FEE_RATE     bigquery.NullString `bigquery:"fee_rate"`
+}
+
+// GetDataQuery reads the trades from table, which is configured per
+// deployment as no trades table is shared with the other reports yet.
+func GetDataQuery(table string, day_start time.Time, day_end time.Time) string {
+	day_start_str := day_start.Format(time.RFC3339)
+	day_end_str := day_end.Format(time.RFC3339)
+
+	// Only settled trades are billed, the fee rate is the one stated on the
+	// order, in percent units.
+	query := fmt.Sprintf(`
+	SELECT
+		trades.trade_id AS trade_id,
+		(FORMAT_TIMESTAMP('%%F', trades.executed_at )) AS trade_date,
+		trades.account_id AS account_id,
+		shared.ASSET_SYMBOL_MAP(trades.asset_type) AS asset,
+		trades.side AS side,
+		CAST(trades.quantity AS STRING) AS quantity,
+		CAST(trades.notional_usd_value AS STRING) AS notional_usd,
+		CAST(trades.stated_fee_rate * 100 AS STRING) AS fee_rate
+	FROM
+		%s trades
+	WHERE
+		((( trades.executed_at ) >= (TIMESTAMP('%s')) AND ( trades.executed_at ) < (TIMESTAMP('%s'))))
+		AND trades.trade_state = 'SETTLED'
+	ORDER BY
+		3,
+		2,
+		1
+	`, table, day_start_str, day_end_str)
+
+	return query
+}
+
+// StructToSlice converts the query rows into the "Trade Activity Report"
+// layout.
+func StructToSlice(iter bigqueryutils.ResultIterator) ([][]string, error) {
+	table := [][]string{}
+
+	for {
+		bigqueryRow := Trades{}
+		err := iter.Next(&bigqueryRow)
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			errorMessage := "Error iterating over. " + err.Error()
+			return nil, errors.New(errorMessage)
+		}
+
+		newRow := []string{}
+
+		newRow = slices.Insert(newRow, int(trades.ColTradeID), bigqueryRow.TRADE_ID.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColTradeDate), bigqueryRow.TRADE_DATE.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColAccountID), bigqueryRow.ACCOUNT_ID.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColAsset), bigqueryRow.ASSET.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColSide), bigqueryRow.SIDE.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColQuantity), bigqueryRow.QUANTITY.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColNotionalUSD), bigqueryRow.NOTIONAL_USD.StringVal)
+		newRow = slices.Insert(newRow, int(trades.ColFeeRate), bigqueryRow.FEE_RATE.StringVal)
+
+		table = append(table, newRow)
+	}
+
+	return table, nil
+}
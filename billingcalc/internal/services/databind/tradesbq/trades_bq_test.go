@@ -0,0 +1,76 @@
+//go:build !selectTest || unitTest
+
+package tradesbq_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/tradesbq"
+)
+
+type MockResultIterator struct {
+	Data  []tradesbq.Trades
+	Index int
+}
+
+// Mock for the Big Querry Iterator
+func (m *MockResultIterator) Next(dst interface{}) error {
+	if m.Index >= len(m.Data) {
+		return iterator.Done
+	}
+	result, ok := dst.(*tradesbq.Trades)
+	if !ok {
+		return errors.New("type assertion to *tradesbq.Trades failed")
+	}
+	*result = m.Data[m.Index]
+	m.Index++
+	return nil
+}
+
+func TestGetDataQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	got := tradesbq.GetDataQuery("billing.trades", start, end)
+
+	assert.Contains(t, got, "billing.trades trades")
+	assert.Contains(t, got, start.Format(time.RFC3339))
+	assert.Contains(t, got, end.Format(time.RFC3339))
+	assert.Contains(t, got, "trades.trade_state = 'SETTLED'")
+}
+
+func TestStructToSlice(t *testing.T) {
+	mockData := []tradesbq.Trades{
+		{
+			TRADE_ID:     bigquery.NullString{StringVal: "T-1", Valid: true},
+			TRADE_DATE:   bigquery.NullString{StringVal: "2024-01-05", Valid: true},
+			ACCOUNT_ID:   bigquery.NullString{StringVal: "acc1", Valid: true},
+			ASSET:        bigquery.NullString{StringVal: "BTC", Valid: true},
+			SIDE:         bigquery.NullString{StringVal: "Buy", Valid: true},
+			QUANTITY:     bigquery.NullString{StringVal: "1", Valid: true},
+			NOTIONAL_USD: bigquery.NullString{StringVal: "42000", Valid: true},
+		},
+	}
+
+	table, err := tradesbq.StructToSlice(&MockResultIterator{Data: mockData})
+	assert.NoError(t, err)
+
+	data, err := trades.NewTrades(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accTrades := data.GetAccountTrades("acc1")
+	if assert.Len(t, accTrades, 1) {
+		assert.Equal(t, "BTC", accTrades[0].Asset)
+		assert.Equal(t, "42000", accTrades[0].NotionalUsd.String())
+		assert.False(t, accTrades[0].HasFeeRate)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/converter"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
 
@@ -21,6 +21,17 @@ const (
 	ColUsdValue         databind.Column = 10
 )
 
+// Schema finds the columns of the unclaimed balances export by their header.
+var Schema = databind.Schema{
+	{Col: ColAsset, Header: "Historic Daily Blockchain Balances Asset Type ID"},
+	{Col: ColBalanceType, Header: "Historic Daily Blockchain Balances Balance Type"},
+	{Col: ColDailyBalanceStr, Header: "Historic Daily Blockchain Balances Balance Str"},
+	{Col: ColDailyBalanceDate, Header: "Historic Daily Blockchain Balances Last Updated At Time", DateFormat: date.MonthFirst},
+	{Col: ColAccountName, Header: "Account Name", Aliases: []string{"Custody Accounts Account Name"}},
+	{Col: ColUsdPrice, Header: "USD Price"},
+	{Col: ColUsdValue, Header: "USD Value"},
+}
+
 type UnclaimedBalances struct {
 	Accounts map[string]Account
 }
@@ -74,15 +85,19 @@ func (u *UnclaimedBalances) IsEmpty() bool {
 	return len(u.Accounts) == 0
 }
 
-func NewUnclaimedBalances(table [][]string) *UnclaimedBalances {
+// NewUnclaimedBalances binds the data rows. Every bad value is collected and
+// returned together as a *databind.ParseErrors.
+func NewUnclaimedBalances(table [][]string) (*UnclaimedBalances, error) {
 	uncBal := &UnclaimedBalances{
 		Accounts: make(map[string]Account),
 	}
+	errs := &databind.ParseErrors{}
 
-	for _, row := range table {
+	for i, row := range table {
+		c := databind.Cells{Row: row, Line: i + 1, Schema: Schema, Errs: errs}
 		accountName := sanitization.SanitizeName(row[ColAccountName])
 		assetName := row[ColAsset]
-		balanceDay := converter.FromStrToTimeStamp(row[ColDailyBalanceDate])
+		balanceDay := c.Date(ColDailyBalanceDate)
 
 		acc, exists := uncBal.Accounts[accountName]
 		if !exists {
@@ -101,8 +116,8 @@ func NewUnclaimedBalances(table [][]string) *UnclaimedBalances {
 			}
 		}
 
-		dailyBalanceStr := converter.FromStrToDecimal(row[ColDailyBalanceStr])
-		usdPrice := converter.FromStrToDecimal(row[ColUsdPrice])
+		dailyBalanceStr := c.Decimal(ColDailyBalanceStr)
+		usdPrice := c.Decimal(ColUsdPrice)
 
 		balance, exists := asset.DailyBalances[balanceDay]
 		if exists {
@@ -114,7 +129,7 @@ func NewUnclaimedBalances(table [][]string) *UnclaimedBalances {
 				DailyBalanceStr:      dailyBalanceStr,
 				DailyBalanceDateTime: balanceDay,
 				UsdPrice:             usdPrice,
-				UsdValue:             converter.FromStrToDecimal(row[ColUsdValue]),
+				UsdValue:             c.Decimal(ColUsdValue),
 			}
 		}
 
@@ -123,5 +138,9 @@ func NewUnclaimedBalances(table [][]string) *UnclaimedBalances {
 		uncBal.Accounts[accountName] = acc
 	}
 
-	return uncBal
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return uncBal, nil
 }
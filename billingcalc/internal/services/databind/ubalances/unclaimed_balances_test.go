@@ -21,7 +21,11 @@ func initBalance() ubalances.UnclaimedBalances {
 		{"", "", "Address2A", "HASH", "", "DELEGATION_REWARDS", "5.00", "2023-05-31 23:58:17", "Acc2", "2.00", "10.00"},
 	}
 
-	return *ubalances.NewUnclaimedBalances(table)
+	uncBal, err := ubalances.NewUnclaimedBalances(table)
+	if err != nil {
+		panic(err)
+	}
+	return *uncBal
 }
 
 func TestLookups(t *testing.T) {
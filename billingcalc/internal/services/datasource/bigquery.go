@@ -0,0 +1,54 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+type bigQuerySource[T any] struct {
+	report  Report[T]
+	client  bigqueryutils.BigQueryWrapper
+	query   string
+	toSlice func(iter bigqueryutils.ResultIterator) ([][]string, error)
+}
+
+// NewBigQuerySource reads the report by running a query. The toSlice function
+// converts the query rows into the same table layout the report parser
+// expects from a spreadsheet, without the header rows.
+func NewBigQuerySource[T any](report Report[T], client bigqueryutils.BigQueryWrapper, query string, toSlice func(iter bigqueryutils.ResultIterator) ([][]string, error)) DataSource[T] {
+	return &bigQuerySource[T]{
+		report:  report,
+		client:  client,
+		query:   query,
+		toSlice: toSlice,
+	}
+}
+
+func (s *bigQuerySource[T]) Load(ctx context.Context) (T, error) {
+	var empty T
+	debug.NewMessageContext(ctx, fmt.Sprintf("Start querying \"%s\".", s.report.Name))
+
+	results, err := s.client.ExecuteQuery(ctx, s.query)
+	if err != nil {
+		msg := fmt.Sprintf("Error querying %s: %v", s.report.Name, err)
+		return empty, errors.New(msg)
+	}
+
+	table, err := s.toSlice(results)
+	if err != nil {
+		return empty, errors.New(err.Error())
+	}
+
+	data, err := s.report.parse(table, 0)
+	if err != nil {
+		return empty, err
+	}
+
+	debug.NewMessageContext(ctx, fmt.Sprintf("Finish querying \"%s\".", s.report.Name))
+
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/file"
+)
+
+type csvSource[T any] struct {
+	report Report[T]
+	file   io.Reader
+}
+
+// NewCsvSource reads the report from an uploaded CSV file.
+func NewCsvSource[T any](report Report[T], file io.Reader) DataSource[T] {
+	return &csvSource[T]{
+		report: report,
+		file:   file,
+	}
+}
+
+func (s *csvSource[T]) Load(ctx context.Context) (T, error) {
+	rows, err := file.ReadCsv(s.file)
+	if err != nil {
+		var empty T
+		return empty, errors.New(fmt.Sprintf("Error while reading %s sheet from file.", s.report.Name))
+	}
+
+	return NewCsvRowsSource(s.report, rows).Load(ctx)
+}
+
+type csvRowsSource[T any] struct {
+	report Report[T]
+	rows   [][]string
+}
+
+// NewCsvRowsSource binds a CSV file that was already read, e.g. while
+// streaming an upload. The rows include the header rows.
+func NewCsvRowsSource[T any](report Report[T], rows [][]string) DataSource[T] {
+	return &csvRowsSource[T]{
+		report: report,
+		rows:   rows,
+	}
+}
+
+func (s *csvRowsSource[T]) Load(ctx context.Context) (T, error) {
+	debug.NewMessageContext(ctx, fmt.Sprintf("Start parse \"%s\" file.", s.report.Name))
+
+	table, err := s.report.bind(s.rows)
+	if err != nil {
+		var empty T
+		return empty, errors.New(err.Error())
+	}
+
+	data, err := s.report.parse(table, s.report.HeaderRow)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	debug.NewMessageContext(ctx, fmt.Sprintf("End parse \"%s\" file.", s.report.Name))
+
+	return data, nil
+}
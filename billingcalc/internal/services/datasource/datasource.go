@@ -0,0 +1,189 @@
+// package datasource decouples where a report comes from (an uploaded CSV, a
+// Google Sheet tab or a BigQuery query) from how the fees are calculated.
+//
+// Every report used in the calculation is read through a DataSource, so a
+// single run can mix origins, e.g. the MFR from Sheets, the rewards from
+// BigQuery and the daily balances from an uploaded CSV.
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustments"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatuses"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/ubalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
+)
+
+// DataSource loads one report and binds it to its databind model.
+type DataSource[T any] interface {
+	Load(ctx context.Context) (T, error)
+}
+
+// SourceFunc adapts an ordinary function to the DataSource interface.
+type SourceFunc[T any] func(ctx context.Context) (T, error)
+
+func (f SourceFunc[T]) Load(ctx context.Context) (T, error) {
+	return f(ctx)
+}
+
+// Sources holds one DataSource per report. A nil source means the report was
+// not provided and is loaded as an empty report.
+type Sources struct {
+	Mfr                DataSource[*mfr.MasterFeeRates]
+	Rewards            DataSource[*rewards.Rewards]
+	UnclaimedBalances  DataSource[*ubalances.UnclaimedBalances]
+	BalanceAdjustments DataSource[*balanceadjustments.BalanceAdjustments]
+	OperationsStatuses DataSource[*operationsstatuses.OperationsStatuses]
+	DailyBalances      DataSource[*dailybalances.DailyBalance]
+	Trades             DataSource[*trades.Trades]
+}
+
+// Datasets holds every report already loaded from its source.
+type Datasets struct {
+	Mfr                *mfr.MasterFeeRates
+	Rewards            *rewards.Rewards
+	UnclaimedBalances  *ubalances.UnclaimedBalances
+	BalanceAdjustments *balanceadjustments.BalanceAdjustments
+	OperationsStatuses *operationsstatuses.OperationsStatuses
+	DailyBalances      *dailybalances.DailyBalance
+	Trades             *trades.Trades
+}
+
+// Dataset names one of the reports of Datasets.
+type Dataset string
+
+const (
+	DatasetMfr                Dataset = "mfr"
+	DatasetRewards            Dataset = "rewards"
+	DatasetUnclaimedBalances  Dataset = "unclaimedBalances"
+	DatasetBalanceAdjustments Dataset = "balanceAdjustments"
+	DatasetOperationsStatuses Dataset = "operationsStatuses"
+	DatasetDailyBalances      Dataset = "dailyBalances"
+	DatasetTrades             Dataset = "trades"
+)
+
+// AllDatasets lists every report, in the order they are loaded.
+var AllDatasets = []Dataset{DatasetMfr, DatasetRewards, DatasetUnclaimedBalances, DatasetBalanceAdjustments, DatasetOperationsStatuses, DatasetDailyBalances, DatasetTrades}
+
+// Load reads every report from its source. The MFR is mandatory, any other
+// missing source results in an empty report.
+func (s Sources) Load(ctx context.Context) (*Datasets, error) {
+	return s.LoadDatasets(ctx, AllDatasets)
+}
+
+// LoadDatasets reads the MFR and the reports in needed from their sources,
+// the others are not read and are left as empty reports.
+func (s Sources) LoadDatasets(ctx context.Context, needed []Dataset) (*Datasets, error) {
+	var err error
+	datasets := &Datasets{}
+
+	if s.Mfr == nil {
+		return nil, errors.New("Missing data source for the Master Fee Rates.")
+	}
+	progress.LoadingReport(ctx, "Master Fee Rates")
+	if datasets.Mfr, err = s.Mfr.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	if datasets.Rewards, err = load(ctx, needs(needed, DatasetRewards, s.Rewards), RewardsReport); err != nil {
+		return nil, err
+	}
+
+	if datasets.UnclaimedBalances, err = load(ctx, needs(needed, DatasetUnclaimedBalances, s.UnclaimedBalances), UnclaimedBalancesReport); err != nil {
+		return nil, err
+	}
+
+	if datasets.BalanceAdjustments, err = load(ctx, needs(needed, DatasetBalanceAdjustments, s.BalanceAdjustments), BalanceAdjustmentsReport); err != nil {
+		return nil, err
+	}
+
+	if datasets.OperationsStatuses, err = load(ctx, needs(needed, DatasetOperationsStatuses, s.OperationsStatuses), OperationsStatusesReport); err != nil {
+		return nil, err
+	}
+
+	if datasets.DailyBalances, err = load(ctx, needs(needed, DatasetDailyBalances, s.DailyBalances), DailyBalancesReport); err != nil {
+		return nil, err
+	}
+
+	if datasets.Trades, err = load(ctx, needs(needed, DatasetTrades, s.Trades), TradesReport); err != nil {
+		return nil, err
+	}
+
+	return datasets, nil
+}
+
+// needs returns source when dataset is in needed, nil otherwise.
+func needs[T any](needed []Dataset, dataset Dataset, source DataSource[T]) DataSource[T] {
+	for _, d := range needed {
+		if d == dataset {
+			return source
+		}
+	}
+	return nil
+}
+
+func load[T any](ctx context.Context, source DataSource[T], report Report[T]) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var empty T
+		return empty, errors.New(err.Error())
+	}
+
+	if source == nil {
+		debug.NewMessageContext(ctx, fmt.Sprintf("No data source for \"%s\", using an empty report.", report.Name))
+		return report.Parser(nil)
+	}
+
+	progress.LoadingReport(ctx, report.Name)
+	data, err := source.Load(ctx)
+	if err != nil {
+		// Returned as is so bad values keep their *databind.ParseErrors.
+		var empty T
+		return empty, err
+	}
+
+	return data, nil
+}
+
+// Once wraps a source so it is loaded a single time, letting other sources
+// depend on it (e.g. to resolve MFR accounts) without reading it again.
+func Once[T any](source DataSource[T]) DataSource[T] {
+	var (
+		once sync.Once
+		data T
+		err  error
+	)
+
+	return SourceFunc[T](func(ctx context.Context) (T, error) {
+		once.Do(func() {
+			data, err = source.Load(ctx)
+		})
+		return data, err
+	})
+}
+
+// RequireNotEmpty wraps a source so that loading an empty report fails with
+// the given message.
+func RequireNotEmpty[T interface{ IsEmpty() bool }](source DataSource[T], errorMessage string) DataSource[T] {
+	return SourceFunc[T](func(ctx context.Context) (T, error) {
+		data, err := source.Load(ctx)
+		if err != nil {
+			return data, err
+		}
+
+		if data.IsEmpty() {
+			var empty T
+			return empty, errors.New(errorMessage)
+		}
+
+		return data, nil
+	})
+}
@@ -0,0 +1,203 @@
+//go:build !selectTest || unitTest
+
+package datasource_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewardsbq"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+type fakeBigQuery struct {
+	rows  []rewardsbq.Rewards
+	query string
+}
+
+func (f *fakeBigQuery) ExecuteQuery(_ context.Context, query string) (bigqueryutils.ResultIterator, error) {
+	f.query = query
+	return &fakeIterator{rows: f.rows}, nil
+}
+
+func (f *fakeBigQuery) Close() error {
+	return nil
+}
+
+type fakeIterator struct {
+	rows  []rewardsbq.Rewards
+	index int
+}
+
+func (f *fakeIterator) Next(dst interface{}) error {
+	if f.index >= len(f.rows) {
+		return iterator.Done
+	}
+	row, ok := dst.(*rewardsbq.Rewards)
+	if !ok {
+		return errors.New("type assertion to *rewardsbq.Rewards failed")
+	}
+	*row = f.rows[f.index]
+	f.index++
+	return nil
+}
+
+func TestCsvSource(t *testing.T) {
+	file, err := static.Files.Open("gsheet/unclaimed.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := datasource.NewCsvSource(datasource.UnclaimedBalancesReport, file).Load(context.Background())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsEmpty(), "Unclaimed Balances should be loaded from the CSV file")
+	assert.Contains(t, result.Accounts, "FTVentureFund")
+}
+
+func TestCsvSourceColumnsByHeader(t *testing.T) {
+	t.Run("Should read the columns wherever they are", func(t *testing.T) {
+		const data = `Inserted,Account name,USD Value,USD Price,Historic Daily Blockchain Balances Asset Type ID,Historic Daily Blockchain Balances Balance Type,Historic Daily Blockchain Balances Balance Str,Historic Daily Blockchain Balances Last Updated At Time
+x,FT Venture Fund,100,0.5,OSMO,DELEGATION_REWARDS,200,2023-06-30
+`
+		result, err := datasource.NewCsvSource(datasource.UnclaimedBalancesReport, strings.NewReader(data)).Load(context.Background())
+
+		assert.NoError(t, err)
+		balances := result.GetSortedDailyBalances("FTVentureFund", "OSMO")
+		if assert.Len(t, balances, 1) {
+			assert.Equal(t, "100", balances[0].UsdValue.String())
+			assert.Equal(t, "200", balances[0].DailyBalanceStr.String())
+		}
+	})
+
+	t.Run("Should report a missing column by name", func(t *testing.T) {
+		const data = `Account name,USD Value
+FT Venture Fund,100
+`
+		_, err := datasource.NewCsvSource(datasource.UnclaimedBalancesReport, strings.NewReader(data)).Load(context.Background())
+
+		assert.ErrorContains(t, err, "Historic Daily Blockchain Balances Asset Type ID")
+	})
+
+	t.Run("Should report bad values with their cell", func(t *testing.T) {
+		const data = `Account name,USD Value,USD Price,Historic Daily Blockchain Balances Asset Type ID,Historic Daily Blockchain Balances Balance Type,Historic Daily Blockchain Balances Balance Str,Historic Daily Blockchain Balances Last Updated At Time
+FT Venture Fund,(100.00),0.5,OSMO,DELEGATION_REWARDS,200,2023-06-30
+FT Venture Fund,100,#N/A,OSMO,DELEGATION_REWARDS,200,2023-06-30
+`
+		_, err := datasource.NewCsvSource(datasource.UnclaimedBalancesReport, strings.NewReader(data)).Load(context.Background())
+
+		var parseErrs *databind.ParseErrors
+		if assert.True(t, errors.As(err, &parseErrs), "expected *databind.ParseErrors, got %v", err) {
+			assert.Equal(t, []databind.CellError{
+				{Report: "Unclaimed Balances Report", Row: 3, Column: "USD Price", Value: "#N/A", Reason: "spreadsheet error #N/A"},
+			}, parseErrs.Errors)
+		}
+	})
+}
+
+func TestBigQuerySource(t *testing.T) {
+	bq := &fakeBigQuery{
+		rows: []rewardsbq.Rewards{
+			{
+				OPERATIONS_ORGANIZATION_NAME:   bigquery.NullString{StringVal: "Org1", Valid: true},
+				OPERATIONS_ACCOUNT_NAME:        bigquery.NullString{StringVal: "Acc1", Valid: true},
+				OPERATIONS_ASSET_TYPE:          bigquery.NullString{StringVal: "ATOM", Valid: true},
+				BUSINESS_DAY:                   bigquery.NullString{StringVal: "2024-01-01", Valid: true},
+				OPERATIONS_ACCOUNT_INTERNAL_ID: bigquery.NullString{StringVal: "AccId1", Valid: true},
+			},
+		},
+	}
+
+	result, err := datasource.NewBigQuerySource(datasource.RewardsReport, bq, "SELECT 1", rewardsbq.StructToSlice).Load(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT 1", bq.query)
+	account := result.GetAccountById(databind.AccountID("AccId1"))
+	assert.Contains(t, account.GetAssets(), "ATOM")
+}
+
+func TestRequireNotEmpty(t *testing.T) {
+	empty := datasource.SourceFunc[*rewards.Rewards](func(ctx context.Context) (*rewards.Rewards, error) {
+		return rewards.NewRewards(nil)
+	})
+
+	_, err := datasource.RequireNotEmpty[*rewards.Rewards](empty, "Not found any Rewards").Load(context.Background())
+
+	assert.EqualError(t, err, "Not found any Rewards")
+}
+
+func TestSourcesLoad(t *testing.T) {
+	t.Run("Should fail without an MFR source", func(t *testing.T) {
+		_, err := datasource.Sources{}.Load(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("Should load missing reports as empty", func(t *testing.T) {
+		mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sources := datasource.Sources{
+			Mfr: datasource.NewMfrFromCsv(mfrFile),
+		}
+
+		data, err := sources.Load(context.Background())
+
+		assert.NoError(t, err)
+		assert.False(t, data.Mfr.IsEmpty())
+		assert.True(t, data.Rewards.IsEmpty())
+		assert.NotNil(t, data.BalanceAdjustments)
+		assert.NotNil(t, data.DailyBalances)
+		assert.True(t, data.OperationsStatuses.IsEmpty())
+		assert.True(t, data.UnclaimedBalances.IsEmpty())
+	})
+
+	t.Run("Should only read the needed reports", func(t *testing.T) {
+		mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rewardsCalls := 0
+		sources := datasource.Sources{
+			Mfr: datasource.NewMfrFromCsv(mfrFile),
+			Rewards: datasource.SourceFunc[*rewards.Rewards](func(ctx context.Context) (*rewards.Rewards, error) {
+				rewardsCalls++
+				return rewards.NewRewards(nil)
+			}),
+		}
+
+		data, err := sources.LoadDatasets(context.Background(), []datasource.Dataset{datasource.DatasetDailyBalances})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, rewardsCalls)
+		assert.True(t, data.Rewards.IsEmpty())
+	})
+}
+
+func TestOnce(t *testing.T) {
+	calls := 0
+	source := datasource.Once[*rewards.Rewards](datasource.SourceFunc[*rewards.Rewards](func(ctx context.Context) (*rewards.Rewards, error) {
+		calls++
+		return rewards.NewRewards(nil)
+	}))
+
+	first, err := source.Load(context.Background())
+	assert.NoError(t, err)
+	second, err := source.Load(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, calls, "The wrapped source should be loaded only once")
+	assert.Same(t, first, second)
+}
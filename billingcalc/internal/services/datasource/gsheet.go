@@ -0,0 +1,33 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/googlesheetsutils"
+)
+
+type sheetSource[T any] struct {
+	report       Report[T]
+	sheetRequest *googlesheetsutils.GoogleSheetRequest
+	tab          string
+}
+
+// NewSheetSource reads the report from a tab of a Google Sheet.
+func NewSheetSource[T any](report Report[T], sheetRequest *googlesheetsutils.GoogleSheetRequest, tab string) DataSource[T] {
+	return &sheetSource[T]{
+		report:       report,
+		sheetRequest: sheetRequest,
+		tab:          tab,
+	}
+}
+
+func (s *sheetSource[T]) Load(ctx context.Context) (T, error) {
+	return googlesheetsutils.GetDatabindFromSheetTab[T](ctx, googlesheetsutils.GetDatabindFromSheetTabParams[T]{
+		SheetRequest: s.sheetRequest,
+		TabName:      s.tab,
+		ReportName:   s.report.Name,
+		HeaderRow:    s.report.HeaderRow,
+		Schema:       s.report.Schema,
+		Parser:       s.report.Parser,
+	})
+}
@@ -0,0 +1,123 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustments"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatuses"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/ubalances"
+)
+
+// Report describes how a spreadsheet-like report is bound to its model,
+// regardless of where the rows come from.
+type Report[T any] struct {
+	Name      string
+	HeaderRow int // index of the first data row in CSV and Sheets exports
+	Schema    databind.Schema
+	Parser    func(data [][]string) (T, error)
+}
+
+var (
+	RewardsReport = Report[*rewards.Rewards]{
+		Name:      "Delegation and Staking Rewards Activity Report",
+		HeaderRow: 8,
+		Schema:    rewards.Schema,
+		Parser:    rewards.NewRewards,
+	}
+
+	UnclaimedBalancesReport = Report[*ubalances.UnclaimedBalances]{
+		Name:      "Unclaimed Balances Report",
+		HeaderRow: 1,
+		Schema:    ubalances.Schema,
+		Parser:    ubalances.NewUnclaimedBalances,
+	}
+
+	BalanceAdjustmentsReport = Report[*balanceadjustments.BalanceAdjustments]{
+		Name:      "Balance Adjustments Report",
+		HeaderRow: 1,
+		Schema:    balanceadjustments.Schema,
+		Parser:    balanceadjustments.NewBalanceAdjustments,
+	}
+
+	OperationsStatusesReport = Report[*operationsstatuses.OperationsStatuses]{
+		Name:      "Client Operations Statuses Report",
+		HeaderRow: 1,
+		Schema:    operationsstatuses.Schema,
+		Parser:    operationsstatuses.NewOperationsStatuses,
+	}
+
+	DailyBalancesReport = Report[*dailybalances.DailyBalance]{
+		Name:      "Daily Balances Report",
+		HeaderRow: 1,
+		Schema:    dailybalances.Schema,
+		Parser:    dailybalances.NewDailyBalance,
+	}
+
+	TradesReport = Report[*trades.Trades]{
+		Name:      "Trade Activity Report",
+		HeaderRow: 1,
+		Schema:    trades.Schema,
+		Parser:    trades.NewTrades,
+	}
+)
+
+// bind finds the report columns in the header row, the row right above
+// HeaderRow, and returns the data rows in the layout the parser reads.
+func (r Report[T]) bind(rows [][]string) ([][]string, error) {
+	if len(rows) < r.HeaderRow {
+		return nil, errors.New(fmt.Sprintf("Error while reading %s sheet from file.", r.Name))
+	}
+
+	data := rows[r.HeaderRow:]
+	if r.Schema == nil || r.HeaderRow == 0 {
+		return data, nil
+	}
+
+	table, err := r.Schema.Bind(rows[r.HeaderRow-1], data)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while parsing %s: %v", r.Name, err))
+	}
+
+	return table, nil
+}
+
+// parse runs the parser over the bound rows. firstRow is the number of rows
+// above the data, used to report bad values at their row in the source.
+func (r Report[T]) parse(table [][]string, firstRow int) (T, error) {
+	data, err := r.Parser(table)
+	var parseErrs *databind.ParseErrors
+	if errors.As(err, &parseErrs) {
+		parseErrs.Locate(r.Name, firstRow)
+	}
+	return data, err
+}
+
+// NewMfrFromCsv reads the Master Fee Rates from a CSV export.
+func NewMfrFromCsv(file io.Reader) DataSource[*mfr.MasterFeeRates] {
+	return SourceFunc[*mfr.MasterFeeRates](func(ctx context.Context) (*mfr.MasterFeeRates, error) {
+		return mfr.ProcessMfr(ctx, file, "", "", "")
+	})
+}
+
+// NewMfrFromCsvRows reads the Master Fee Rates from a CSV export that was
+// already read, e.g. while streaming an upload.
+func NewMfrFromCsvRows(rows [][]string) DataSource[*mfr.MasterFeeRates] {
+	return SourceFunc[*mfr.MasterFeeRates](func(ctx context.Context) (*mfr.MasterFeeRates, error) {
+		return mfr.ParseMfrCsv(rows)
+	})
+}
+
+// NewMfrFromSheet reads the Master Fee Rates from a Google Sheet tab.
+func NewMfrFromSheet(sheetId, tab, token string) DataSource[*mfr.MasterFeeRates] {
+	return SourceFunc[*mfr.MasterFeeRates](func(ctx context.Context) (*mfr.MasterFeeRates, error) {
+		return mfr.ProcessMfr(ctx, nil, sheetId, tab, token)
+	})
+}
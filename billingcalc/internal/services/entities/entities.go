@@ -0,0 +1,128 @@
+// package entities is the registry of the Anchorage entities that bill
+// clients, keyed by the Anchorage Entity ID of the MFR.
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+// DefaultFile is the registry embedded in the binary, used when the server
+// has no managed entities file.
+const DefaultFile = "entities.json"
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Remittance struct {
+	BankName      string `json:"bankName,omitempty"`
+	AccountName   string `json:"accountName,omitempty"`
+	AccountNumber string `json:"accountNumber,omitempty"`
+	RoutingNumber string `json:"routingNumber,omitempty"`
+	SwiftCode     string `json:"swiftCode,omitempty"`
+	Address       string `json:"address,omitempty"`
+	Instructions  string `json:"instructions,omitempty"`
+}
+
+type Entity struct {
+	EntityID   string     `json:"entityId"`
+	Acronym    string     `json:"acronym"`
+	LegalName  string     `json:"legalName"`
+	Remittance Remittance `json:"remittance"`
+	// InvoiceNumberTemplate formats the invoice numbers, {acronym} and
+	// {number} are replaced, e.g. "{acronym}-{number}" gives ADB-12.
+	InvoiceNumberTemplate string `json:"invoiceNumberTemplate"`
+	DefaultCurrency       string `json:"defaultCurrency"`
+	// DefaultBillingTerms are the days to pay of the accounts without
+	// Billing Terms in the MFR.
+	DefaultBillingTerms string `json:"defaultBillingTerms"`
+}
+
+// InvoiceNumber formats number with the entity template.
+func (e Entity) InvoiceNumber(number int) string {
+	return strings.NewReplacer("{acronym}", e.Acronym, "{number}", strconv.Itoa(number)).Replace(e.InvoiceNumberTemplate)
+}
+
+type Registry struct {
+	Version  string   `json:"version"`
+	Entities []Entity `json:"entities"`
+
+	byId map[string]Entity
+}
+
+// Parse reads and checks a registry file, every problem found is reported.
+func Parse(data []byte) (*Registry, error) {
+	registry := &Registry{}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, errors.New(fmt.Sprintf("Invalid entities file: %v", err))
+	}
+
+	var problems []string
+	if registry.Version == "" {
+		problems = append(problems, "version is required")
+	}
+
+	registry.byId = make(map[string]Entity)
+	acronyms := make(map[string]string)
+	for i, entity := range registry.Entities {
+		name := fmt.Sprintf("entity %d", i+1)
+		if entity.EntityID != "" {
+			name = "entity " + entity.EntityID
+		}
+
+		switch _, exists := registry.byId[entity.EntityID]; {
+		case entity.EntityID == "":
+			problems = append(problems, name+": entityId is required")
+		case exists:
+			problems = append(problems, name+": entityId is used more than once")
+		}
+		switch otherId, exists := acronyms[entity.Acronym]; {
+		case entity.Acronym == "":
+			problems = append(problems, name+": acronym is required")
+		case exists:
+			problems = append(problems, fmt.Sprintf("%s: acronym %s is already used by entity %s", name, entity.Acronym, otherId))
+		}
+		if !strings.Contains(entity.InvoiceNumberTemplate, "{number}") {
+			problems = append(problems, name+": invoiceNumberTemplate must contain {number}")
+		}
+		if entity.DefaultCurrency != "" && !currencyCode.MatchString(entity.DefaultCurrency) {
+			problems = append(problems, fmt.Sprintf("%s: defaultCurrency %q is not an ISO 4217 code", name, entity.DefaultCurrency))
+		}
+		if entity.DefaultBillingTerms != "" {
+			if _, err := strconv.Atoi(entity.DefaultBillingTerms); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: defaultBillingTerms %q is not a number of days", name, entity.DefaultBillingTerms))
+			}
+		}
+
+		registry.byId[entity.EntityID] = entity
+		acronyms[entity.Acronym] = entity.EntityID
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("Invalid entities file: " + strings.Join(problems, "; "))
+	}
+	return registry, nil
+}
+
+// Default returns the registry embedded in the binary.
+func Default() (*Registry, error) {
+	data, err := static.Files.ReadFile(DefaultFile)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error reading file %s: %v", DefaultFile, err))
+	}
+	return Parse(data)
+}
+
+// Get returns the entity registered as entityID, or an error naming it.
+func (r *Registry) Get(entityID string) (Entity, error) {
+	entity, ok := r.byId[entityID]
+	if !ok {
+		return Entity{}, errors.New(fmt.Sprintf("Anchorage Entity ID %q is not registered (registry version %s)", entityID, r.Version))
+	}
+	return entity, nil
+}
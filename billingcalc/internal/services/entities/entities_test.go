@@ -0,0 +1,49 @@
+//go:build !selectTest || unitTest
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+)
+
+func TestDefault(t *testing.T) {
+	registry, err := entities.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entity, err := registry.Get("15")
+	assert.NoError(t, err)
+	assert.Equal(t, "ADB", entity.Acronym)
+	assert.Equal(t, "ADB-12", entity.InvoiceNumber(12))
+
+	_, err = registry.Get("99")
+	assert.EqualError(t, err, `Anchorage Entity ID "99" is not registered (registry version 1)`)
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Should report every invalid entity", func(t *testing.T) {
+		_, err := entities.Parse([]byte(`{"entities": [
+			{"entityId": "15", "acronym": "ADB", "invoiceNumberTemplate": "{acronym}-{number}"},
+			{"entityId": "15", "acronym": "ADB", "invoiceNumberTemplate": "{acronym}"},
+			{"acronym": "ABS", "invoiceNumberTemplate": "{number}", "defaultCurrency": "usd", "defaultBillingTerms": "Net 30"}
+		]}`))
+
+		assert.EqualError(t, err, "Invalid entities file: version is required; "+
+			"entity 15: entityId is used more than once; "+
+			"entity 15: acronym ADB is already used by entity 15; "+
+			"entity 15: invoiceNumberTemplate must contain {number}; "+
+			"entity 3: entityId is required; "+
+			`entity 3: defaultCurrency "usd" is not an ISO 4217 code; `+
+			`entity 3: defaultBillingTerms "Net 30" is not a number of days`)
+	})
+
+	t.Run("Should reject a file that is not JSON", func(t *testing.T) {
+		_, err := entities.Parse([]byte(`[`))
+		assert.Error(t, err)
+	})
+}
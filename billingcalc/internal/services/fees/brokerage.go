@@ -0,0 +1,209 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
+)
+
+const (
+	BrokerageCalculatorName = "brokerage"
+	BrokerageServiceType    = "Brokerage Fee"
+)
+
+type brokerageCalculator struct{}
+
+func (brokerageCalculator) Name() string { return BrokerageCalculatorName }
+
+func (brokerageCalculator) Datasets() []datasource.Dataset {
+	return []datasource.Dataset{datasource.DatasetTrades}
+}
+
+func (brokerageCalculator) Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	return CalculateBrokerageFees(ctx, data.Mfr, data.Trades, invoiceDate)
+}
+
+// CalculateBrokerageFees bills the trades of the brokerage clients, one line
+// per account and asset. Clients on a negotiated rate pay it on the notional
+// of every trade, clients on the Standard schedule pay the rate stated on
+// each order.
+func CalculateBrokerageFees(ctx context.Context, mfr *mfr.MasterFeeRates, tradeActivity *trades.Trades, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	debug.NewMessageContext(ctx, "Start of Brokerage Fees calculation.")
+	summary := StakingSummary{}
+	var warnings []Warning
+	var errs []string
+
+	warnings = append(warnings, unbilledTrades(mfr, tradeActivity)...)
+
+	for _, organization := range mfr.GetSortedOrganizations() {
+		if ctx.Err() != nil {
+			debug.NewMessageContext(ctx, "Brokerage Fees calculation cancelled.")
+			break
+		}
+		progress.ProcessingOrganization(ctx, progress.StageBrokerage, organization.DisplayName)
+
+		accResults := []AccountResult{}
+		for _, mfrAccount := range mfr.GetSortedAccounts(organization.Id) {
+			if !mfrAccount.Brokerage.Client || mfrAccount.TerminatedBefore(invoiceDate) {
+				continue
+			}
+
+			accTrades := tradeActivity.GetAccountTrades(mfrAccount.Id)
+			if len(accTrades) == 0 {
+				debug.NewMessageContext(ctx, fmt.Sprintf("No trades for brokerage client %s", mfrAccount.Name))
+				continue
+			}
+
+			dueDate, err := GenDueDate(ctx, invoiceDate, mfrAccount.BillingTerms)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Account %s: %v", mfrAccount.DisplayName, err))
+				continue
+			}
+
+			outputs, warns := brokerageOutputs(ctx, organization, mfrAccount, accTrades)
+			warnings = append(warnings, warns...)
+			if len(outputs) == 0 {
+				continue
+			}
+
+			accResults = append(accResults, AccountResult{
+				AccountID:    string(mfrAccount.Id),
+				AccName:      mfrAccount.Name,
+				BillingTerms: mfrAccount.BillingTerms,
+				CustomerID:   mfrAccount.CustomerId,
+				DisplayName:  mfrAccount.DisplayName,
+				EntityID:     organization.EntityId,
+				InvoiceDate:  invoiceDate.Format("01/02/2006"),
+				DueDate:      dueDate.Format("01/02/2006"),
+				Assets:       outputs,
+			})
+		}
+
+		if len(accResults) > 0 {
+			summary = append(summary, OrgResult{
+				MsaID:    string(organization.Id),
+				OrgName:  organization.Name,
+				Accounts: accResults,
+			})
+		}
+	}
+
+	debug.NewMessageContext(ctx, "End of Brokerage Fees calculation.")
+
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, "\n"))
+	}
+
+	return summary, warnings, nil
+}
+
+// brokerageOutputs sums the fees of the account trades per asset.
+func brokerageOutputs(ctx context.Context, organization mfr.Organization, account mfr.Account, accTrades []trades.Trade) ([]StakingOutput, []Warning) {
+	var warnings []Warning
+	byAsset := make(map[string][]trades.Trade)
+	for _, trade := range accTrades {
+		byAsset[trade.Asset] = append(byAsset[trade.Asset], trade)
+	}
+
+	assets := make([]string, 0, len(byAsset))
+	for asset := range byAsset {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+
+	terms := account.Brokerage
+	var outputs []StakingOutput
+	for _, asset := range assets {
+		ex := explain.New(ctx, "Brokerage fee")
+		ex.Input("Account", account.Name)
+		ex.Input("Asset", asset)
+		if terms.Standard {
+			ex.Input("Brokerage schedule", "Standard, rate stated on each order")
+		} else {
+			ex.Input("Brokerage rate (%)", terms.Rate)
+		}
+
+		notional, fee := decimal.Zero, decimal.Zero
+		billed := 0
+		for _, trade := range byAsset[asset] {
+			rate := terms.Rate
+			if terms.Standard {
+				if !trade.HasFeeRate {
+					warnings = append(warnings, Warning{
+						OrgName:     organization.Name,
+						AccName:     account.Name,
+						Asset:       asset,
+						Description: fmt.Sprintf("Trade %s has no stated fee rate for the Standard brokerage schedule, it was not billed.", trade.Id),
+					})
+					continue
+				}
+				rate = trade.FeeRate
+			}
+
+			tradeFee := trade.NotionalUsd.Mul(rate).DivRound(decimal.NewFromInt(100), 16)
+			ex.Step("Trade "+trade.Id, fmt.Sprintf("notional %s x %s%% / 100", trade.NotionalUsd, rate), tradeFee, explain.Divided(16))
+			notional = notional.Add(trade.NotionalUsd)
+			fee = fee.Add(tradeFee)
+			billed++
+		}
+		if billed == 0 {
+			continue
+		}
+
+		amount := fee.Round(2)
+		ex.Step("Amount", "sum of the trade fees", amount, explain.Rounded(2))
+
+		itemCategory, monthlyRate := "Brokerage Fee", fmt.Sprint(terms.Rate.StringFixed(2), "%")
+		if terms.Standard {
+			itemCategory, monthlyRate = "Brokerage Fee - Standard", "Standard"
+		}
+		outputs = append(outputs, StakingOutput{
+			ServiceType:   BrokerageServiceType,
+			Asset:         asset,
+			Amount:        amount,
+			EarnedRewards: notional,
+			FeeRates:      terms.Rate,
+			ItemCategory:  itemCategory,
+			ItemQuantity:  fmt.Sprint(billed),
+			MonthlyRate:   monthlyRate,
+			Explanation:   ex,
+		})
+	}
+
+	return outputs, warnings
+}
+
+// unbilledTrades warns about the trades of accounts that are not brokerage
+// clients in the MFR.
+func unbilledTrades(mfr *mfr.MasterFeeRates, tradeActivity *trades.Trades) []Warning {
+	var warnings []Warning
+	for _, accountId := range tradeActivity.GetAccountIds() {
+		org, account, ok := mfr.FindAccountById(accountId)
+		switch {
+		case !ok:
+			warnings = append(warnings, Warning{
+				AccName:     string(accountId),
+				Description: fmt.Sprintf("Trades of account %s were not billed, the account is not in the MFR.", accountId),
+			})
+		case !account.Brokerage.Client:
+			warnings = append(warnings, Warning{
+				OrgName:     org.Name,
+				AccName:     account.Name,
+				Description: "Trades were not billed, the account is not a brokerage client in the MFR.",
+			})
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,76 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+func TestCalculateBrokerageFees(t *testing.T) {
+	mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(mfrFile).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfrData, err := mfr.ParseMfrCsv(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// accountIdFor2222 is on the Standard schedule, the Alpha account is not a
+	// brokerage client.
+	tradeActivity, err := trades.NewTrades([][]string{
+		{"T-1", "06/05/2023", "accountIdFor2222", "BTC", "Buy", "1", "30000", "0.25%"},
+		{"T-2", "06/06/2023", "accountIdFor2222", "BTC", "Sell", "0.5", "15000.50", "0.1"},
+		{"T-3", "06/07/2023", "accountIdFor2222", "ETH", "Buy", "10", "18000", ""},
+		{"T-4", "06/07/2023", "2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22", "ETH", "Buy", "10", "18000", "0.2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	summary, warns, err := fees.CalculateBrokerageFees(context.Background(), mfrData, tradeActivity, invoiceDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should bill the rate stated on each order of a Standard client", func(t *testing.T) {
+		if assert.Len(t, summary, 1) && assert.Len(t, summary[0].Accounts, 1) {
+			acc := summary[0].Accounts[0]
+			assert.Equal(t, "accountIdFor2222", acc.AccountID)
+			if assert.Len(t, acc.Assets, 1) {
+				line := acc.Assets[0]
+				assert.Equal(t, fees.BrokerageServiceType, line.ServiceType)
+				assert.Equal(t, "BTC", line.Asset)
+				// 30000 x 0.25% + 15000.50 x 0.1% = 75 + 15.0005
+				assert.Equal(t, "90.00", line.Amount.StringFixed(2))
+				assert.Equal(t, "45000.5", line.EarnedRewards.String())
+				assert.Equal(t, "2", line.ItemQuantity)
+				assert.Equal(t, "Standard", line.MonthlyRate)
+			}
+		}
+	})
+
+	t.Run("Should warn about the trades it can't bill", func(t *testing.T) {
+		var descriptions []string
+		for _, w := range warns {
+			descriptions = append(descriptions, w.Description)
+		}
+		assert.Contains(t, descriptions, "Trades were not billed, the account is not a brokerage client in the MFR.")
+		assert.Contains(t, descriptions, "Trade T-3 has no stated fee rate for the Standard brokerage schedule, it was not billed.")
+	})
+}
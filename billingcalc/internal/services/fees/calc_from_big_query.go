@@ -4,38 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"os"
-	"sync"
 	"time"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/bigqueryutils"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustments"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/balanceadjustmentsbq"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/dailybalancesbq"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatuses"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatusesbq"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewardsbq"
-	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/ubalances"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/trades"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/tradesbq"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/ubalancesbq"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
 )
 
 const env_project_id = "PROJECT_ID"
 
-func CalculateFromBigQuery(ctx context.Context, mfrFile io.Reader, sheetId string, mfrTab string, token string, periodBegin time.Time, periodEnd time.Time, firstExternalId int, invoiceDate time.Time) (*CalculatedFees, error) {
-	var balanceAdjustments *balanceadjustments.BalanceAdjustments
-	var dailyBalances *dailybalances.DailyBalance
+// env_trades_table names the BigQuery table of the trades. Trades are only
+// queried when it is set.
+const env_trades_table = "TRADES_TABLE"
 
-	var wg sync.WaitGroup
-	var mu sync.Mutex
-	var stakingSummary, custodySummary StakingSummary
-	var combinedWarnings []Warning
-	var err1, err2 error
-
-	projectId := getProjectId()
+func CalculateFromBigQuery(ctx context.Context, mfrRows [][]string, sheetId string, mfrTab string, token string, periodBegin time.Time, periodEnd time.Time, invoicing Invoicing, invoiceDate time.Time) (*CalculatedFees, error) {
+	projectId := getProjectId(ctx)
 
 	bq, err := bigqueryutils.NewBigQueryWrapper(ctx, projectId)
 	if err != nil {
@@ -48,180 +44,100 @@ func CalculateFromBigQuery(ctx context.Context, mfrFile io.Reader, sheetId strin
 		}
 	}()
 
-	// Processing Mfr
-	mfr, err := mfr.ProcessMfr(ctx, mfrFile, sheetId, mfrTab, token)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
-
-	balances, err := getUnclaimedBalances(ctx, bq, periodBegin, periodEnd)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
+	var mfrSource datasource.DataSource[*mfr.MasterFeeRates]
+	if mfrRows != nil {
+		mfrSource = datasource.NewMfrFromCsvRows(mfrRows)
+	} else {
+		mfrSource = datasource.NewMfrFromSheet(sheetId, mfrTab, token)
+	}
+	mfrSource = datasource.Once(mfrSource)
+
+	sources := datasource.Sources{
+		Mfr: mfrSource,
+		Rewards: datasource.RequireNotEmpty(
+			datasource.NewBigQuerySource(datasource.RewardsReport, bq, rewardsbq.GetDataQuery(periodBegin, periodEnd), rewardsbq.StructToSlice),
+			fmt.Sprintf("Not found any Rewards for the period between %s and %s", periodBegin, periodEnd),
+		),
+		UnclaimedBalances:  datasource.NewBigQuerySource(datasource.UnclaimedBalancesReport, bq, ubalancesbq.GetDataQuery(periodBegin, periodEnd), ubalancesbq.StructToSlice),
+		BalanceAdjustments: getBalanceAdjustmentsSource(bq, mfrSource, periodBegin, periodEnd),
+		OperationsStatuses: datasource.RequireNotEmpty(
+			datasource.NewBigQuerySource(datasource.OperationsStatusesReport, bq, operationsstatusesbq.GetDataQuery(periodBegin, periodEnd), operationsstatusesbq.StructToSlice),
+			fmt.Sprintf("Not found any Operations Statuses for the period between %s and %s", periodBegin, periodEnd),
+		),
+		DailyBalances: getDailyBalancesSource(bq, mfrSource, periodBegin, periodEnd),
+		Trades:        getTradesSource(ctx, bq, periodBegin, periodEnd),
+	}
+
+	return Calculate(ctx, sources, invoicing, invoiceDate)
+}
 
-	rewards, err := getRewards(ctx, bq, periodBegin, periodEnd)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
-	if rewards.IsEmpty() {
-		errorMessage := fmt.Sprintf("Not found any Rewards for the period between %s and %s", periodBegin, periodEnd)
-		return nil, errors.New(errorMessage)
+// getTradesSource queries the trades of the table in env_trades_table. Without
+// it the trades are an empty report, so no brokerage fee is billed.
+func getTradesSource(ctx context.Context, bq bigqueryutils.BigQueryWrapper, periodBegin time.Time, periodEnd time.Time) datasource.DataSource[*trades.Trades] {
+	table := os.Getenv(env_trades_table)
+	if table == "" {
+		debug.NewMessageContext(ctx, fmt.Sprintf("%s env variable not found or empty, no brokerage fees are billed from BigQuery", env_trades_table))
+		return nil
 	}
 
-	operationsStatuses, err := getStatuses(ctx, bq, periodBegin, periodEnd)
-	if err != nil {
-		return nil, errors.New(err.Error())
-	}
-	if operationsStatuses.IsEmpty() {
-		errorMessage := fmt.Sprintf("Not found any Operations Statuses for the period between %s and %s", periodBegin, periodEnd)
-		return nil, errors.New(errorMessage)
-	}
-	wg.Add(2)
-
-	go func() {
-		defer wg.Done()
-		summary, stakingWarn := CalculateStakingFees(mfr, rewards, balances, balanceAdjustments, operationsStatuses, dailyBalances, firstExternalId, invoiceDate)
-		mu.Lock()
-		defer mu.Unlock()
-		if summary != nil {
-			stakingSummary = append(stakingSummary, summary...)
-			combinedWarnings = append(combinedWarnings, stakingWarn...)
-		} else {
-			err1 = errors.New("error in CalculateStakingFees")
-		}
-	}()
+	return datasource.NewBigQuerySource(datasource.TradesReport, bq, tradesbq.GetDataQuery(table, periodBegin, periodEnd), tradesbq.StructToSlice)
+}
 
-	go func() {
-		defer wg.Done()
-		summary, custodyWarn := CalculateCustodyFees(mfr, rewards, balances, balanceAdjustments, operationsStatuses, dailyBalances, firstExternalId, invoiceDate)
-		mu.Lock()
-		defer mu.Unlock()
-		if summary != nil {
-			custodySummary = append(custodySummary, summary...)
-			combinedWarnings = append(combinedWarnings, custodyWarn...)
-		} else {
-			err2 = errors.New("error in CalculateCustodyFees")
+// getDailyBalancesSource queries the daily balances and keys them by the MSA ID
+// and account name found in the MFR, as custody fees are looked up that way.
+func getDailyBalancesSource(bq bigqueryutils.BigQueryWrapper, mfrSource datasource.DataSource[*mfr.MasterFeeRates], periodBegin time.Time, periodEnd time.Time) datasource.DataSource[*dailybalances.DailyBalance] {
+	return datasource.SourceFunc[*dailybalances.DailyBalance](func(ctx context.Context) (*dailybalances.DailyBalance, error) {
+		mfrData, err := mfrSource.Load(ctx)
+		if err != nil {
+			return nil, err
 		}
-	}()
 
-	wg.Wait()
-
-	mergedResults := make(map[string]*OrgResult)
-	for _, summary := range []StakingSummary{stakingSummary, custodySummary} {
-		for _, orgResult := range summary {
-			if existingOrg, ok := mergedResults[orgResult.OrgName]; ok {
-				existingOrg.Accounts = MergeAccounts(existingOrg.Accounts, orgResult.Accounts)
-			} else {
-				orgCopy := orgResult
-				mergedResults[orgResult.OrgName] = &orgCopy
+		toSlice := func(iter bigqueryutils.ResultIterator) ([][]string, error) {
+			table, err := dailybalancesbq.StructToSlice(iter)
+			if err != nil {
+				return nil, err
 			}
-		}
-	}
-
-	var combinedSummary StakingSummary
-	for _, org := range mergedResults {
-		combinedSummary = append(combinedSummary, *org)
-	}
 
-	if err1 != nil || err2 != nil {
-		var errMsg string
-		if err1 != nil {
-			errMsg += err1.Error()
+			return dailybalancesbq.AssignMfrAccounts(table, func(accountId string) (string, string, bool) {
+				org, acc, ok := mfrData.FindAccountById(databind.AccountID(accountId))
+				return string(org.Id), acc.Name, ok
+			}), nil
 		}
-		if err2 != nil {
-			if errMsg != "" {
-				errMsg += " | "
-			}
-			errMsg += err2.Error()
-		}
-		return nil, errors.New(errMsg)
-	}
 
-	return &CalculatedFees{
-		Summary: combinedSummary,
-		Warns:   combinedWarnings,
-	}, nil
+		return datasource.NewBigQuerySource(datasource.DailyBalancesReport, bq, dailybalancesbq.GetDataQuery(periodBegin, periodEnd), toSlice).Load(ctx)
+	})
 }
 
-func getUnclaimedBalances(ctx context.Context, bq bigqueryutils.BigQueryWrapper, begin time.Time, end time.Time) (*ubalances.UnclaimedBalances, error) {
-	debug.NewMessage("Start querying \"Unclaimed Balances\".")
-	var unclaimed *ubalances.UnclaimedBalances
-
-	queryUnclaimed := ubalancesbq.GetDataQuery(begin, end)
-	resultsUnclaimed, err := bq.ExecuteQuery(ctx, queryUnclaimed)
-	if err != nil {
-		msg := fmt.Sprintf("Error querying Unclaimed Balances: %v", err)
-		return unclaimed, errors.New(msg)
-	}
-	balancesSlices, errSlice := ubalancesbq.StructToSlice(resultsUnclaimed)
-	if errSlice != nil {
-		return unclaimed, errors.New(errSlice.Error())
-	}
-
-	unclaimed = ubalances.NewUnclaimedBalances(balancesSlices)
-
-	if unclaimed.IsEmpty() {
-		msg := fmt.Sprintf("Not found any Unclaimed Balances for the period between %s and %s", begin, end)
-		debug.NewMessage(msg)
-	}
-
-	debug.NewMessage("Finish querying \"Unclaimed Balances\".")
-
-	return unclaimed, nil
-}
-
-func getRewards(ctx context.Context, bq bigqueryutils.BigQueryWrapper, begin time.Time, end time.Time) (*rewards.Rewards, error) {
-	debug.NewMessage("Start querying \"Delegation Rewards\".")
-
-	var result *rewards.Rewards
-
-	queryrewards := rewardsbq.GetDataQuery(begin, end)
-	resultsrewards, err := bq.ExecuteQuery(ctx, queryrewards)
-	if err != nil {
-		msg := fmt.Sprintf("Error querying Delegation Rewards: %v", err)
-		return result, errors.New(msg)
-	}
-	rewardsSlices, errSlice := rewardsbq.StructToSlice(resultsrewards)
-	if errSlice != nil {
-		return result, errors.New(errSlice.Error())
-	}
-
-	result = rewards.NewRewards(rewardsSlices)
-
-	debug.NewMessage("Finish querying \"Delegation Rewards\".")
-
-	return result, nil
-}
-
-func getStatuses(ctx context.Context, bq bigqueryutils.BigQueryWrapper, begin time.Time, end time.Time) (*operationsstatuses.OperationsStatuses, error) {
-	name := "Operations Statuses"
-	debug.NewMessage(fmt.Sprintf("Start querying \"%s\".", name))
-
-	var result *operationsstatuses.OperationsStatuses
-
-	queryOpStatuses := operationsstatusesbq.GetDataQuery(begin, end)
-	resultsStatuses, err := bq.ExecuteQuery(ctx, queryOpStatuses)
-	if err != nil {
-		msg := fmt.Sprintf("Error querying %s: %v", name, err)
-		return result, errors.New(msg)
-	}
-	opStatusesSlices, errSlice := operationsstatusesbq.StructToSlice(resultsStatuses)
-	if errSlice != nil {
-		return result, errors.New(errSlice.Error())
-	}
+// getBalanceAdjustmentsSource queries the balance adjustments and renames them
+// after the MFR organization and account, as staking fees are looked up that way.
+func getBalanceAdjustmentsSource(bq bigqueryutils.BigQueryWrapper, mfrSource datasource.DataSource[*mfr.MasterFeeRates], periodBegin time.Time, periodEnd time.Time) datasource.DataSource[*balanceadjustments.BalanceAdjustments] {
+	return datasource.SourceFunc[*balanceadjustments.BalanceAdjustments](func(ctx context.Context) (*balanceadjustments.BalanceAdjustments, error) {
+		mfrData, err := mfrSource.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	result = operationsstatuses.NewOperationsStatuses(opStatusesSlices)
+		toSlice := func(iter bigqueryutils.ResultIterator) ([][]string, error) {
+			table, err := balanceadjustmentsbq.StructToSlice(iter)
+			if err != nil {
+				return nil, err
+			}
 
-	debug.NewMessage(fmt.Sprintf("Finish querying \"%s\".", name))
+			return balanceadjustmentsbq.AssignMfrAccounts(table, func(accountId string) (string, string, bool) {
+				org, acc, ok := mfrData.FindAccountById(databind.AccountID(accountId))
+				return org.Name, acc.Name, ok
+			}), nil
+		}
 
-	return result, nil
+		return datasource.NewBigQuerySource(datasource.BalanceAdjustmentsReport, bq, balanceadjustmentsbq.GetDataQuery(periodBegin, periodEnd), toSlice).Load(ctx)
+	})
 }
 
-func getProjectId() string {
+func getProjectId(ctx context.Context) string {
 	projectId := os.Getenv(env_project_id)
 	if projectId == "" {
 		msg := fmt.Sprintf("%s env variable not found or empty", env_project_id)
-		debug.NewMessage(msg)
+		debug.NewMessageContext(ctx, msg)
 		projectId = "anchorage-playground"
 	}
 
@@ -0,0 +1,107 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+// Calculate loads the reports needed by the selected fee calculators from
+// their data sources and runs the calculators over them, merging their
+// results per organization. The merged line items are then assembled into
+// invoices as invoicing says.
+func Calculate(ctx context.Context, sources datasource.Sources, invoicing Invoicing, invoiceDate time.Time) (*CalculatedFees, error) {
+	grouping, err := ParseInvoiceGrouping(string(invoicing.Grouping))
+	if err != nil {
+		return nil, err
+	}
+	invoicing.Grouping = grouping
+
+	level, err := ParseMinimumFeeLevel(string(invoicing.MinimumFeeLevel))
+	if err != nil {
+		return nil, err
+	}
+	invoicing.MinimumFeeLevel = level
+
+	fill, err := ParseStakedBalanceFill(string(invoicing.StakedBalanceFill))
+	if err != nil {
+		return nil, err
+	}
+
+	validators := invoicing.ValidatorFees
+	if validators == nil {
+		if validators, err = validatorfees.Default(); err != nil {
+			return nil, err
+		}
+	}
+
+	selected, err := calculators.Select(invoicing.Calculators)
+	if err != nil {
+		return nil, err
+	}
+	selected = withStakingTerms(selected, fill, validators)
+
+	data, err := sources.LoadDatasets(ctx, neededDatasets(selected))
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateFromDatasets(ctx, selected, data, invoicing, invoiceDate)
+}
+
+func calculateFromDatasets(ctx context.Context, selected []FeeCalculator, data *datasource.Datasets, invoicing Invoicing, invoiceDate time.Time) (*CalculatedFees, error) {
+	merged, warns, err := runCalculators(ctx, selected, data, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+	warns = append(skippedAccountWarnings(data.Mfr), warns...)
+
+	// The minimum charge is part of the custody terms, it is only applied
+	// when custody is billed.
+	for _, calculator := range selected {
+		if calculator.Name() == CustodyCalculatorName {
+			if merged, err = applyMinimumFees(ctx, merged, data, invoicing.MinimumFeeLevel, invoiceDate); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	merged, chargeWarns, err := chargeOneTimeFees(ctx, merged, invoicing.Charges, invoicing.RunID, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+	warns = append(warns, chargeWarns...)
+
+	summary, err := AssembleInvoices(ctx, merged, invoicing, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CalculatedFees{
+		Summary: summary,
+		Warns:   warns,
+	}
+	if invoicing.Sequences != nil || invoicing.Charges != nil {
+		result.RunID = invoicing.RunID
+	}
+	return result, nil
+}
+
+// skippedAccountWarnings tells about the MFR accounts that are not billed, so
+// they don't go missing from the invoices unnoticed.
+func skippedAccountWarnings(m *mfr.MasterFeeRates) []Warning {
+	if m == nil {
+		return nil
+	}
+	var warnings []Warning
+	for _, skipped := range m.GetSkippedAccounts() {
+		message := fmt.Sprintf("MFR row %d: %s", skipped.Line, skipped.Reason)
+		warnings = addWarning(skipped.OrgName, skipped.AccountName, "", message, warnings)
+	}
+	return warnings
+}
@@ -0,0 +1,219 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+const dailyBalancesCsv = `MSA ID,Org Name,Account Name,Anchorage Entity,Org ID,Asset Type,Account Internal ID,Total Quantity,Unit Price USD,Total USD Value,Total Quantity From Addresses,Unclaimed rewards USD,Total AUC in USD,Graduated tier?
+22222,Org Test Beta,Test Beta Account,TRUST_COMPANY,orgId,SOL,accountIdFor2222,3000000,10,30000000,0,0,30000000,No
+22222,Org Test Beta,Test Beta Account,TRUST_COMPANY,orgId,WPUNKS,accountIdFor2222,30,100000,3000000,0,0,3000000,No
+`
+
+func TestCalculate(t *testing.T) {
+	mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dailyBalancesFile := strings.NewReader(dailyBalancesCsv)
+
+	sources := datasource.Sources{
+		Mfr:           datasource.NewMfrFromCsv(mfrFile),
+		DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, dailyBalancesFile),
+	}
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	result, err := fees.Calculate(context.Background(), sources, fees.Invoicing{FirstExternalId: 1}, invoiceDate)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.Summary)
+
+	t.Run("Should merge staking and custody results once per organization", func(t *testing.T) {
+		seen := make(map[string]bool)
+		for _, org := range result.Summary {
+			assert.Falsef(t, seen[org.OrgName], "Organization %s found more than once", org.OrgName)
+			seen[org.OrgName] = true
+		}
+	})
+
+	t.Run("Should include custody fees", func(t *testing.T) {
+		custodyLines := 0
+		for _, org := range result.Summary {
+			for _, acc := range org.Accounts {
+				for _, line := range acc.Assets {
+					if line.ServiceType == "Custody Fee" {
+						custodyLines++
+					}
+				}
+			}
+		}
+		assert.Greater(t, custodyLines, 0)
+	})
+}
+
+func TestCalculateIsDeterministic(t *testing.T) {
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	calculate := func() []byte {
+		mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources := datasource.Sources{
+			Mfr:           datasource.NewMfrFromCsv(mfrFile),
+			DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, strings.NewReader(dailyBalancesCsv)),
+		}
+
+		result, err := fees.Calculate(context.Background(), sources, fees.Invoicing{FirstExternalId: 1}, invoiceDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	first := calculate()
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, string(first), string(calculate()))
+	}
+
+	result := fees.CalculatedFees{}
+	if err := json.Unmarshal(first, &result); err != nil {
+		t.Fatal(err)
+	}
+	orgNames := []string{}
+	externalIDs := []string{}
+	for _, org := range result.Summary {
+		orgNames = append(orgNames, org.OrgName)
+		for _, acc := range org.Accounts {
+			externalIDs = append(externalIDs, acc.ExternalID)
+		}
+	}
+	// By MSA ID: 11111 then 22222, and by account ID within 22222.
+	assert.Equal(t, []string{"OrgTestAlpha", "OrgTestBeta"}, orgNames)
+	assert.Equal(t, []string{"1", "2", "3"}, externalIDs)
+}
+
+func TestMergeSummariesKeepsOrder(t *testing.T) {
+	staking := fees.StakingSummary{
+		{MsaID: "2", OrgName: "B", Accounts: []fees.AccountResult{{AccountID: "b1", Assets: []fees.StakingOutput{{Asset: "SOL"}}}}},
+		{MsaID: "1", OrgName: "A", Accounts: []fees.AccountResult{{AccountID: "a2"}, {AccountID: "a1"}}},
+	}
+	custody := fees.StakingSummary{
+		{MsaID: "3", OrgName: "C"},
+		{MsaID: "2", OrgName: "B", Accounts: []fees.AccountResult{{AccountID: "b1", Assets: []fees.StakingOutput{{Asset: "ETH"}}}}},
+	}
+
+	merged := fees.MergeSummaries(staking, custody)
+
+	if assert.Len(t, merged, 3) {
+		assert.Equal(t, "B", merged[0].OrgName)
+		assert.Equal(t, []fees.StakingOutput{{Asset: "SOL"}, {Asset: "ETH"}}, merged[0].Accounts[0].Assets)
+		assert.Equal(t, "A", merged[1].OrgName)
+		assert.Equal(t, "a2", merged[1].Accounts[0].AccountID)
+		assert.Equal(t, "C", merged[2].OrgName)
+	}
+}
+
+func TestMergeSummariesKeepsSameNamesApart(t *testing.T) {
+	staking := fees.StakingSummary{
+		{MsaID: "1", OrgName: "Org", Accounts: []fees.AccountResult{
+			{AccountID: "a1", AccName: "Main", BillingTerms: "30", CustomerID: "100", Assets: []fees.StakingOutput{{Asset: "SOL"}}},
+		}},
+	}
+	custody := fees.StakingSummary{
+		{MsaID: "1", OrgName: "Org", Accounts: []fees.AccountResult{
+			{AccountID: "a2", AccName: "Main", BillingTerms: "30", CustomerID: "100", Assets: []fees.StakingOutput{{Asset: "ETH"}}},
+		}},
+		{MsaID: "2", OrgName: "Org", Accounts: []fees.AccountResult{
+			{AccountID: "a1", AccName: "Main", BillingTerms: "30", CustomerID: "100", Assets: []fees.StakingOutput{{Asset: "BTC"}}},
+		}},
+	}
+
+	merged := fees.MergeSummaries(staking, custody)
+
+	if assert.Len(t, merged, 2) {
+		assert.Equal(t, "1", merged[0].MsaID)
+		if assert.Len(t, merged[0].Accounts, 2) {
+			assert.Equal(t, []fees.StakingOutput{{Asset: "SOL"}}, merged[0].Accounts[0].Assets)
+			assert.Equal(t, []fees.StakingOutput{{Asset: "ETH"}}, merged[0].Accounts[1].Assets)
+		}
+		assert.Equal(t, "2", merged[1].MsaID)
+		assert.Equal(t, []fees.StakingOutput{{Asset: "BTC"}}, merged[1].Accounts[0].Assets)
+	}
+}
+
+func TestCalculateExplained(t *testing.T) {
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	calculate := func(ctx context.Context) fees.StakingSummary {
+		mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources := datasource.Sources{
+			Mfr:           datasource.NewMfrFromCsv(mfrFile),
+			DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, strings.NewReader(dailyBalancesCsv)),
+		}
+
+		result, err := fees.Calculate(ctx, sources, fees.Invoicing{FirstExternalId: 1}, invoiceDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Summary
+	}
+
+	t.Run("Should explain every custody line with its final amount", func(t *testing.T) {
+		summary := calculate(explain.NewContext(context.Background()))
+
+		custodyLines := 0
+		for _, org := range summary {
+			for _, acc := range org.Accounts {
+				for _, line := range acc.Assets {
+					if line.ServiceType != "Custody Fee" {
+						continue
+					}
+					custodyLines++
+					if !assert.NotNil(t, line.Explanation) {
+						continue
+					}
+					assert.NotEmpty(t, line.Explanation.Tiers)
+					last := line.Explanation.Steps[len(line.Explanation.Steps)-1]
+					amount := line.Explanation.Steps[len(line.Explanation.Steps)-2]
+					assert.Equal(t, "Monthly rate", last.Name)
+					assert.Equal(t, "Amount", amount.Name)
+					assert.True(t, line.Amount.Equal(amount.Value))
+				}
+			}
+		}
+		assert.Greater(t, custodyLines, 0)
+
+		var text bytes.Buffer
+		assert.NoError(t, fees.WriteExplanations(&text, summary))
+		assert.Contains(t, text.String(), "Method: Custody fee")
+	})
+
+	t.Run("Should not explain without explain mode", func(t *testing.T) {
+		for _, org := range calculate(context.Background()) {
+			for _, acc := range org.Accounts {
+				for _, line := range acc.Assets {
+					assert.Nil(t, line.Explanation)
+				}
+			}
+		}
+	})
+}
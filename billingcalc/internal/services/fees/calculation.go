@@ -1,11 +1,13 @@
 package fees
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -17,58 +19,73 @@ import (
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/ubalances"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/custody"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
 )
 
-var EntityIDToAcronym = map[string]string{
-	"15": "ADB",
-	"33": "ABS",
-}
-
-func CalculateStakingFees(mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *ubalances.UnclaimedBalances, balAdj *balanceadjustments.BalanceAdjustments, ops *operationsstatuses.OperationsStatuses, dayBal *dailybalances.DailyBalance, firstExternalId int, invoiceDate time.Time) (StakingSummary, []Warning) {
+func CalculateStakingFees(ctx context.Context, mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *ubalances.UnclaimedBalances, balAdj *balanceadjustments.BalanceAdjustments, ops *operationsstatuses.OperationsStatuses, dayBal *dailybalances.DailyBalance, invoiceDate time.Time, fill StakedBalanceFill, validators *validatorfees.Registry) (StakingSummary, []Warning, error) {
 	msg := "Start of Staking Fees calculation."
-	debug.NewMessage(msg)
+	debug.NewMessageContext(ctx, msg)
 
 	var calcTable CalcTable
 	var summary StakingSummary
 	var warnings []Warning
+	var errs []string
 
-	firstAcc := true
-	currentExternalID := firstExternalId
-	getInvoiceNumber := GenInvoiceNumber(firstExternalId)
+	switch fill {
+	case FillPrevious, FillZero, FillSkip:
+	default:
+		return nil, nil, errors.New(fmt.Sprintf("Invalid staked balance fill %q, expected %q, %q or %q", fill, FillPrevious, FillZero, FillSkip))
+	}
 
 	err := setCalcTable(&calcTable)
 	if err != nil {
 		msg := fmt.Sprintf("Failed in Calc Table: %v", err)
-		debug.NewMessage(msg)
-		panic(err)
+		debug.NewMessageContext(ctx, msg)
+		return nil, nil, errors.New(msg)
 	}
 
-	for _, organization := range mfr.GetOrganizations() {
+	monthStart := time.Date(invoiceDate.Year(), invoiceDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	for _, organization := range mfr.GetSortedOrganizations() {
+		if ctx.Err() != nil {
+			debug.NewMessageContext(ctx, "Staking Fees calculation cancelled.")
+			break
+		}
 		accResults := []AccountResult{}
-		debug.NewMessage(fmt.Sprintf("Processing organization %s id:%s", organization.DisplayName, organization.Id))
-		for _, mfrAccount := range mfr.GetAccounts(organization.Id) {
-			debug.NewMessage(fmt.Sprintf("MFR account name: %s", mfrAccount.Name))
+		debug.NewMessageContext(ctx, fmt.Sprintf("Processing organization %s id:%s", organization.DisplayName, organization.Id))
+		progress.ProcessingOrganization(ctx, progress.StageStaking, organization.DisplayName)
+		for _, mfrAccount := range mfr.GetSortedAccounts(organization.Id) {
+			debug.NewMessageContext(ctx, fmt.Sprintf("MFR account name: %s", mfrAccount.Name))
 			var out []StakingOutput
+			if mfrAccount.TerminatedBefore(invoiceDate) {
+				debug.NewMessageContext(ctx, fmt.Sprintf("Skipping account %s, terminated on %s", mfrAccount.Name, mfrAccount.TerminationDate.Format("01/02/2006")))
+				continue
+			}
 
-			currentExternalID = GenExternalID(currentExternalID, firstAcc)
-			firstAcc = false
-			dueDate := GenDueDate(invoiceDate, mfrAccount.BillingTerms)
+			dueDate, err := GenDueDate(ctx, invoiceDate, mfrAccount.BillingTerms)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Account %s: %v", mfrAccount.DisplayName, err))
+				continue
+			}
 
 			rwdAccount := rwd.GetAccountById(mfrAccount.Id)
 			if rwdAccount.Name == "" {
-				debug.NewMessage(fmt.Sprintf("Skipping because no entry in Rewards sheet was found for ID %s", mfrAccount.Id))
+				debug.NewMessageContext(ctx, fmt.Sprintf("Skipping because no entry in Rewards sheet was found for ID %s", mfrAccount.Id))
 			}
 
-			for _, rwdAsset := range rwdAccount.GetAssets() {
+			for _, rwdAsset := range rwdAccount.GetSortedAssets() {
 				fee := mfrAccount.GetAssetStakingFees(rwdAsset.Name)
 				if fee.AssetName == "" {
 					warnMsg := fmt.Sprintf("MFR entry not found for account %v, asset %v.", rwdAccount.Id, rwdAsset.Name)
 					warnings = addWarning(organization.Name, rwdAccount.Name, rwdAsset.Name, warnMsg, warnings)
-					debug.NewMessage(warnMsg)
+					debug.NewMessageContext(ctx, warnMsg)
 				}
 
 				balAdjUsdValue := balAdj.SumDailyBalanceAdjustments(organization.Name, mfrAccount.Name, rwdAsset.Name)
@@ -76,54 +93,66 @@ func CalculateStakingFees(mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *u
 				claimedRewards := rwdAsset.GetClaimedRewards()
 				unclaimedBalances := ubal.GetSortedDailyBalances(rwdAccount.Name, rwdAsset.Name)
 
-				operationsStatus := ops.GetStatusByAssetAndDate(rwdAccount.Name, rwdAsset.Name, invoiceDate)
+				operationsStatuses := ops.GetStatusesByAsset(rwdAccount.Name, rwdAsset.Name, monthStart, monthEnd)
 
-				appendStakingOutput(&out, calcTable, rwdAccount.Name, rwdAsset.Name, fee, claimedRewards, unclaimedBalances, operationsStatus, invoiceDate, balAdjUsdValue)
+				stakingWarnings := appendStakingOutput(ctx, &out, calcTable, organization.Name, rwdAccount.Name, string(mfrAccount.Id), rwdAsset.Name, fee, claimedRewards, unclaimedBalances, operationsStatuses, invoiceDate, fill, validators, balAdjUsdValue)
+				warnings = append(warnings, stakingWarnings...)
 			}
 
 			customerID := mfrAccount.CustomerId
 			entityID := organization.EntityId
 			if entityID == "" {
 				msg := "Failed to fetch EntityID from: " + organization.Name
-				debug.NewMessage(msg)
+				debug.NewMessageContext(ctx, msg)
 				log.Println(msg)
 			} else {
-				invoiceNumber := getInvoiceNumber(entityID, string(organization.Id))
-				externalID := fmt.Sprintf("%d", currentExternalID)
 				accResults = append(accResults, AccountResult{
-					AccName:       mfrAccount.Name,
-					BillingTerms:  mfrAccount.BillingTerms,
-					CustomerID:    customerID,
-					DisplayName:   mfrAccount.DisplayName,
-					ExternalID:    externalID,
-					EntityID:      entityID,
-					InvoiceNumber: invoiceNumber,
-					InvoiceDate:   invoiceDate.Format("01/02/2006"),
-					DueDate:       dueDate.Format("01/02/2006"),
-					Assets:        out,
+					AccountID:    string(mfrAccount.Id),
+					AccName:      mfrAccount.Name,
+					BillingTerms: mfrAccount.BillingTerms,
+					CustomerID:   customerID,
+					DisplayName:  mfrAccount.DisplayName,
+					EntityID:     entityID,
+					InvoiceDate:  invoiceDate.Format("01/02/2006"),
+					DueDate:      dueDate.Format("01/02/2006"),
+					Assets:       out,
 				})
 			}
 
 			if accResultsJson, err := json.Marshal(accResults); err != nil {
-				debug.NewMessage(fmt.Sprintf("fail: accResults json.Marshal %s", err.Error()))
+				debug.NewMessageContext(ctx, fmt.Sprintf("fail: accResults json.Marshal %s", err.Error()))
 			} else {
-				debug.NewMessage(fmt.Sprintf("success: accResults json.Marshal %s", string(accResultsJson)))
+				debug.NewMessageContext(ctx, fmt.Sprintf("success: accResults json.Marshal %s", string(accResultsJson)))
 			}
 		}
 		summary = append(summary, OrgResult{
+			MsaID:    string(organization.Id),
 			OrgName:  organization.Name,
 			Accounts: accResults,
 		})
 	}
 
-	debug.NewMessage("End of Staking Fees calculation.")
+	debug.NewMessageContext(ctx, "End of Staking Fees calculation.")
 	if summaryJson, err := json.Marshal(summary); err != nil {
-		debug.NewMessage(fmt.Sprintf("Failed to json.Marshal summary %s", err.Error()))
+		debug.NewMessageContext(ctx, fmt.Sprintf("Failed to json.Marshal summary %s", err.Error()))
 	} else {
-		debug.NewMessage(string(summaryJson))
+		debug.NewMessageContext(ctx, string(summaryJson))
 	}
 
-	return summary, warnings
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, "\n"))
+	}
+
+	return summary, warnings, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func Contains(value string, slice []string) bool {
@@ -148,19 +177,19 @@ func GetResultByOrgName(orgName string, result []OrgResult) OrgResult {
 	}
 }
 
-func GenDueDate(invoiceDate time.Time, billingTerms string) time.Time {
+func GenDueDate(ctx context.Context, invoiceDate time.Time, billingTerms string) (time.Time, error) {
 	if billingTerms == "" {
-		return invoiceDate
+		return invoiceDate, nil
 	}
 	var billingTermsToCalc int
 	_, err := fmt.Sscanf(billingTerms, "%d", &billingTermsToCalc)
 	if err != nil {
-		debug.NewMessage(err.Error())
-		debug.NewMessage(fmt.Sprintf("billingTerms string: %s", billingTerms))
-		panic("Error while calculating due date")
+		debug.NewMessageContext(ctx, err.Error())
+		debug.NewMessageContext(ctx, fmt.Sprintf("billingTerms string: %s", billingTerms))
+		return time.Time{}, errors.New(fmt.Sprintf("Invalid billing terms %q: %v", billingTerms, err))
 	}
 	day := 24 * time.Hour
-	return invoiceDate.Add(time.Duration(billingTermsToCalc) * day)
+	return invoiceDate.Add(time.Duration(billingTermsToCalc) * day), nil
 }
 
 func GenExternalID(currentExternalID int, firstAcc bool) int {
@@ -170,40 +199,11 @@ func GenExternalID(currentExternalID int, firstAcc bool) int {
 	return currentExternalID
 }
 
-func GenInvoiceNumber(initialValue int) func(entityID, msaID string) string {
-	value := initialValue
-	invoiceNumberTable := make(map[string]int) // { "msaID": value }
-
-	return func(entityID, msaID string) string {
-		// Acronym
-		acronym, ok := EntityIDToAcronym[entityID]
-		if !ok {
-			return "Acronym Not Found"
-		}
-
-		currentValue := value
-		// Get the value related with the MSAID
-		_, exist := invoiceNumberTable[msaID]
-		if !exist {
-			invoiceNumberTable[msaID] = currentValue
-			value++
-		} else {
-			currentValue = invoiceNumberTable[msaID]
-		}
-
-		return acronym + "-" + strconv.Itoa(currentValue)
-	}
-}
-
-func appendStakingOutput(out *[]StakingOutput, calcTable CalcTable, account string, asset string, stakingFee mfr.StakingFee, filteredRewards []rewards.ClaimedReward, dailyBalances []ubalances.DailyBalance, opStatus operationsstatuses.Status, invoiceDate time.Time, balAdjuUsdValue decimal.Decimal) {
+// appendStakingOutput adds the staking lines of an account asset to out, it
+// returns the warnings about how they were billed.
+func appendStakingOutput(ctx context.Context, out *[]StakingOutput, calcTable CalcTable, organization string, account string, accountID string, asset string, mfrStakingFee mfr.StakingFee, claimedRewards []rewards.ClaimedReward, dailyBalances []ubalances.DailyBalance, statuses []operationsstatuses.Status, invoiceDate time.Time, fill StakedBalanceFill, validators *validatorfees.Registry, accountBalAdjuUsdValue decimal.Decimal) []Warning {
+	var warnings []Warning
 	for _, entry := range calcTable {
-		var earnedRewards decimal.Decimal
-		var fee decimal.Decimal
-		var amount decimal.Decimal
-		monthlyRate := ""
-
-		ItemCategory := "Delegation Rewards Fees"
-
 		if entry.Asset != asset {
 			continue
 		}
@@ -213,37 +213,109 @@ func appendStakingOutput(out *[]StakingOutput, calcTable CalcTable, account stri
 			validator = NonAnchorageValidator
 		}
 
-		if entry.Claimable {
-			if len(dailyBalances) == 0 {
-				msg := fmt.Sprintf("Account %s delegation reward for %s does not relate to Anchorage staked balances.", account, asset)
-				debug.NewMessage(msg)
-				return
-			}
-			earnedRewards = sumDiffUnclaimedInUsd(dailyBalances, filteredRewards, validator)
-		} else {
-			earnedRewards = sumClaimedRewards(filteredRewards, validator)
+		if entry.Claimable && len(dailyBalances) == 0 {
+			msg := fmt.Sprintf("Account %s delegation reward for %s does not relate to Anchorage staked balances.", account, asset)
+			debug.NewMessageContext(ctx, msg)
+			return warnings
 		}
 
-		switch true {
-		case operationsstatuses.IsAssetFromExternalValidator(opStatus):
-			calcAmountFromExternalValidator(opStatus, &ItemCategory, &earnedRewards, invoiceDate, stakingFee.ThirdPartyFee, &fee, &amount, &monthlyRate, balAdjuUsdValue)
-		default:
-			calcAmountDefault(stakingFee, entry, &earnedRewards, &fee, &amount, balAdjuUsdValue)
+		stakingValidators, unattributed := splitByValidator(entry, statuses, claimedRewards)
+		for _, stakingValidator := range stakingValidators {
+			var earnedRewards decimal.Decimal
+			var fee decimal.Decimal
+			var amount decimal.Decimal
+			monthlyRate := ""
+			split := len(stakingValidators) > 1
+
+			ItemCategory := "Delegation Rewards Fees"
+
+			ex := explain.New(ctx, "Staking fee")
+			ex.Input("Account", account)
+			ex.Input("Asset", asset)
+			ex.Input("Validator", validator)
+			stakingFee, validatorLabel := validatorStakingFee(validators, stakingValidator, entry, accountID, mfrStakingFee, ex)
+			ex.Input("Collected on chain", entry.On_chain)
+			ex.Input("Claimable", entry.Claimable)
+			ex.Input("Anchorage fee (%)", stakingFee.AnchorageFee)
+			ex.Input("Third party fee (%)", stakingFee.ThirdPartyFee)
+			ex.Input("Balance adjustments USD sum", accountBalAdjuUsdValue)
+			explainRewards(ex, stakingValidator.Rewards, validator)
+			explainRewards(ex, unattributed, validator)
+
+			balAdjuUsdValue := accountBalAdjuUsdValue
+			if entry.Claimable {
+				earnedRewards = sumDiffUnclaimedInUsd(dailyBalances, claimedRewards, validator, ex)
+				ex.Step("Earned rewards", "sum of (balance diff + claimed qty) x USD price, from the second day", earnedRewards, "")
+				if split {
+					earnedRewards = earnedRewards.Mul(stakingValidator.Share)
+				}
+			} else {
+				earnedRewards = sumClaimedRewards(stakingValidator.Rewards, validator)
+				ex.Step("Earned rewards", "sum of the claimed rewards USD value", earnedRewards, "")
+				if split {
+					earnedRewards = earnedRewards.Add(sumClaimedRewards(unattributed, validator).Mul(stakingValidator.Share))
+				}
+			}
+			if split {
+				balAdjuUsdValue = balAdjuUsdValue.Mul(stakingValidator.Share)
+				ex.Step("Validator share", "validator staked balance / staked balance of the month", stakingValidator.Share, explain.Divided(16))
+				ex.Step("Validator earned rewards", "rewards paid by the validator + share x other earned rewards", earnedRewards, "")
+				ex.Step("Validator balance adjustments", "share x balance adjustments", balAdjuUsdValue, "")
+			}
+
+			feeModel, commission, fallback := stakingFeeModel(stakingFee, lastStatus(stakingValidator.Statuses))
+			if fallback != "" {
+				if validatorLabel != "" {
+					fallback = "Validator " + validatorLabel + ": " + fallback
+				}
+				warnings = addWarning(organization, account, asset, fallback, warnings)
+			}
+			ex.Input("Fee model", feeModel)
+			switch feeModel {
+			case StakedBalanceFeeModel:
+				ex.SetMethod("Staking fee, 100% validator")
+				calcAmountFromExternalValidator(stakingValidator.Statuses, fill, &ItemCategory, &earnedRewards, invoiceDate, stakingFee.StakedBalanceFee, &fee, &amount, &monthlyRate, balAdjuUsdValue, ex)
+			case BlendedFeeModel:
+				ex.SetMethod("Staking fee, commission validator")
+				calcAmountBlended(stakingFee, entry, stakingValidator.Statuses, fill, commission, earnedRewards, invoiceDate, &fee, &amount, &monthlyRate, balAdjuUsdValue, ex)
+			default:
+				calcAmountDefault(stakingFee, entry, &earnedRewards, &fee, &amount, balAdjuUsdValue, ex)
+			}
+			ex.Step("Earned rewards shown", "earned rewards", earnedRewards.Round(2), explain.Rounded(2))
+
+			itemDescription := ""
+			if validatorLabel != "" {
+				itemDescription = "Validator " + validatorLabel
+			}
+
+			*out = append(*out, StakingOutput{
+				ServiceType:             StakingServiceType,
+				Asset:                   asset,
+				Amount:                  amount,
+				CollectedOnChainAlready: entry.On_chain,
+				EarnedRewards:           earnedRewards.Round(2),
+				FeeRates:                fee,
+				ItemCategory:            ItemCategory,
+				ItemDescription:         itemDescription,
+				ItemQuantity:            "",
+				Memo:                    "",
+				MonthlyRate:             monthlyRate,
+				FeeModel:                feeModel,
+				Validator:               validatorLabel,
+				Explanation:             ex,
+			})
 		}
+	}
+	return warnings
+}
 
-		*out = append(*out, StakingOutput{
-			ServiceType:             "Staking Fee",
-			Asset:                   asset,
-			Amount:                  amount,
-			CollectedOnChainAlready: entry.On_chain,
-			EarnedRewards:           earnedRewards.Round(2),
-			FeeRates:                fee,
-			ItemCategory:            ItemCategory,
-			ItemDescription:         "",
-			ItemQuantity:            "",
-			Memo:                    "",
-			MonthlyRate:             monthlyRate,
-		})
+func explainRewards(ex *explain.Explanation, claimedRewards []rewards.ClaimedReward, validator string) {
+	for _, entry := range claimedRewards {
+		row := explain.RewardRow{BusinessDay: entry.BusinessDay, OperationType: entry.OperationType, AssetQty: entry.AnchorageAssetQty, UsdValue: entry.AnchorageUsdValue}
+		if validator != AnchorageValidator {
+			row.AssetQty, row.UsdValue = entry.ThirdPartyAssetQty, entry.ThirdPartyUsdValue
+		}
+		ex.Reward(row)
 	}
 }
 
@@ -269,7 +341,7 @@ func sumClaimedRewards(claimedRewards []rewards.ClaimedReward, validator string)
 // Sum of daily USD value change in 'Historic Daily Blockchain Balances Balance Str'
 // from 'Daily Historic Blockchain Balances' + sumif('Operations Total USD Reward Value' > 0)
 // from 'Staking Rewards and Delegation Rewards'
-func sumDiffUnclaimedInUsd(dailyBalances []ubalances.DailyBalance, claimedValues []rewards.ClaimedReward, validator string) decimal.Decimal {
+func sumDiffUnclaimedInUsd(dailyBalances []ubalances.DailyBalance, claimedValues []rewards.ClaimedReward, validator string, ex *explain.Explanation) decimal.Decimal {
 	if len(dailyBalances) == 0 {
 		return decimal.Zero
 	}
@@ -291,6 +363,9 @@ func sumDiffUnclaimedInUsd(dailyBalances []ubalances.DailyBalance, claimedValues
 			usdValue := total.Mul(balance.UsdPrice)
 
 			sumDiffUsdValue = sumDiffUsdValue.Add(usdValue)
+			ex.Balance(explain.BalanceRow{Date: balanceDay, Balance: balance.DailyBalanceStr, Diff: diffBalance, ClaimedQty: claimedAssets, UsdPrice: balance.UsdPrice, UsdValue: usdValue, Counted: true})
+		} else {
+			ex.Balance(explain.BalanceRow{Date: balanceDay, Balance: balance.DailyBalanceStr, UsdPrice: balance.UsdPrice})
 		}
 
 		previousBalance = balance.DailyBalanceStr
@@ -318,38 +393,106 @@ func sumClaimedAssetsByDay(claimedValues []rewards.ClaimedReward, validator stri
 	return totalClaimed
 }
 
+// stakingFeeModel picks how a staking line is billed from the commission of
+// its validator: on the rewards, on the staked balance when the validator
+// keeps all of them, or both weighted by the commission. Only the assets with
+// a staked balance fee in the MFR are billed on the staked balance, fallback
+// tells why a line falls back to the rewards when its validator keeps some.
+func stakingFeeModel(stakingFee mfr.StakingFee, opStatus operationsstatuses.Status) (feeModel string, commission decimal.Decimal, fallback string) {
+	commission, err := opStatus.Commission()
+	if err != nil {
+		return RewardsFeeModel, decimal.Zero, fmt.Sprintf("The validator commission %q could not be read (%v), the staking fee was billed on the rewards", opStatus.CosmosValidatorsRatesRate, err)
+	}
+	if !commission.IsPositive() {
+		return RewardsFeeModel, decimal.Zero, ""
+	}
+	if !stakingFee.StakedBalanceFee.IsPositive() {
+		return RewardsFeeModel, decimal.Zero, fmt.Sprintf("The validator keeps %s%% of the rewards but the MFR has no staked balance fee for %s, the staking fee was billed on the rewards", commission.Shift(2).String(), stakingFee.AssetName)
+	}
+	if commission.Equal(decimal.NewFromInt(1)) {
+		return StakedBalanceFeeModel, commission, ""
+	}
+	return BlendedFeeModel, commission, ""
+}
+
 /* When clients stake to a validator that charges 100% commission, none of their earned rewards are sent to their Anchorage account. To ensure Anchorage earns revenue from these staking arrangements, there is an alternative fee charged in these scenarios, which is a percentage of the total average balance staked to the 100% validator during the month.
  */
-func calcAmountFromExternalValidator(opStatus operationsstatuses.Status, ItemCategory *string, earnedRewards *decimal.Decimal, invoiceDate time.Time, thirdPartyValidatorFee decimal.Decimal, fee *decimal.Decimal, amount *decimal.Decimal, monthlyRate *string, balAdjuUsdValue decimal.Decimal) {
+func calcAmountFromExternalValidator(opStatuses []operationsstatuses.Status, fill StakedBalanceFill, ItemCategory *string, earnedRewards *decimal.Decimal, invoiceDate time.Time, stakedBalanceFee decimal.Decimal, fee *decimal.Decimal, amount *decimal.Decimal, monthlyRate *string, balAdjuUsdValue decimal.Decimal, ex *explain.Explanation) {
 	*ItemCategory = "Delegation Rewards Fees - 100% validator"
 	*earnedRewards, _ = decimal.NewFromString("0")
-	*monthlyRate = fmt.Sprint(thirdPartyValidatorFee.Round(2).StringFixed(2), "%")
-	monthsInYear := decimal.NewFromInt(12)
+	*monthlyRate = fmt.Sprint(stakedBalanceFee.Round(2).StringFixed(2), "%")
 
-	activeDelegatedValue := opStatus.StatusesActiveDelegatedValue
-	invoiceMonthDays := decimal.NewFromInt(date.NumberOfDaysInMonth(invoiceDate.Month(), invoiceDate.Year()))
-	averageStakedBalance := activeDelegatedValue.DivRound(invoiceMonthDays, 16).Round(2)
-	alternativeFeeRate := thirdPartyValidatorFee.DivRound(decimal.NewFromInt(100), 16)
+	averageStakedBalance := calcAverageStakedBalance(opStatuses, invoiceDate, fill, ex)
+	*amount = calcStakedBalanceAmount(averageStakedBalance, stakedBalanceFee, balAdjuUsdValue, ex).Round(2)
+	*fee = averageStakedBalance
 
+	ex.Step("Amount", "adjusted balance x alternative fee rate / 12", *amount, explain.DividedThenRounded(16, 2))
+	ex.Step("Monthly rate", "staked balance fee", stakedBalanceFee.Round(2), explain.Rounded(2))
+}
+
+// calcAmountBlended bills a validator keeping part of the rewards: the rewards
+// fee on the share the client receives and the staked balance fee on the share
+// the validator keeps.
+func calcAmountBlended(mfrFees mfr.StakingFee, entry CalcTableEntry, opStatuses []operationsstatuses.Status, fill StakedBalanceFill, commission decimal.Decimal, earnedRewards decimal.Decimal, invoiceDate time.Time, fee *decimal.Decimal, amount *decimal.Decimal, monthlyRate *string, balAdjuUsdValue decimal.Decimal, ex *explain.Explanation) {
+	*monthlyRate = fmt.Sprint(mfrFees.StakedBalanceFee.Round(2).StringFixed(2), "%")
+	one := decimal.NewFromInt(1)
+
+	var feeRate decimal.Decimal
+	*fee, feeRate = calcRewardsFeeRate(mfrFees, entry, ex)
+	adjustedRewards := earnedRewards.Add(balAdjuUsdValue)
+	rewardsAmount := feeRate.Mul(adjustedRewards)
+	ex.Step("Adjusted rewards", "earned rewards + balance adjustments", adjustedRewards, "")
+	ex.Step("Rewards amount", "rate x adjusted rewards", rewardsAmount, "")
+
+	averageStakedBalance := calcAverageStakedBalance(opStatuses, invoiceDate, fill, ex)
+	stakedAmount := calcStakedBalanceAmount(averageStakedBalance, mfrFees.StakedBalanceFee, balAdjuUsdValue, ex)
+	ex.Step("Staked balance amount", "adjusted balance x alternative fee rate / 12", stakedAmount, explain.Divided(16))
+
+	ex.Input("Validator commission", commission)
+	*amount = one.Sub(commission).Mul(rewardsAmount).Add(commission.Mul(stakedAmount)).Round(2)
+	ex.Step("Amount", "(1 - commission) x rewards amount + commission x staked balance amount", *amount, explain.Rounded(2))
+	ex.Step("Monthly rate", "staked balance fee", mfrFees.StakedBalanceFee.Round(2), explain.Rounded(2))
+}
+
+// calcStakedBalanceAmount is the monthly staked balance fee, not rounded.
+func calcStakedBalanceAmount(averageStakedBalance decimal.Decimal, stakedBalanceFee decimal.Decimal, balAdjuUsdValue decimal.Decimal, ex *explain.Explanation) decimal.Decimal {
+	monthsInYear := decimal.NewFromInt(12)
+	alternativeFeeRate := stakedBalanceFee.DivRound(decimal.NewFromInt(100), 16)
 	adjustedAmount := averageStakedBalance.Add(balAdjuUsdValue)
-	*amount = adjustedAmount.Mul(alternativeFeeRate).DivRound(monthsInYear, 16).Round(2)
-	*fee = averageStakedBalance
+
+	ex.Step("Alternative fee rate", "staked balance fee / 100", alternativeFeeRate, explain.Divided(16))
+	ex.Step("Adjusted balance", "average staked balance + balance adjustments", adjustedAmount, "")
+	return adjustedAmount.Mul(alternativeFeeRate).DivRound(monthsInYear, 16)
 }
 
-func calcAmountDefault(mfrFees mfr.StakingFee, entry CalcTableEntry, earnedRewards *decimal.Decimal, fee *decimal.Decimal, amount *decimal.Decimal, balAdjuUsdValue decimal.Decimal) {
-	*fee = mfrFees.AnchorageFee
+// calcRewardsFeeRate returns the MFR fee of the validator of entry and the
+// rate it charges on the rewards, grossed up when it is collected on chain.
+func calcRewardsFeeRate(mfrFees mfr.StakingFee, entry CalcTableEntry, ex *explain.Explanation) (decimal.Decimal, decimal.Decimal) {
+	fee := mfrFees.AnchorageFee
+	feeName := "Anchorage fee"
 	if entry.Validator == "non_anchorage" {
-		*fee = mfrFees.ThirdPartyFee
+		fee = mfrFees.ThirdPartyFee
+		feeName = "Third party fee"
 	}
 
 	feeRate := fee.DivRound(decimal.NewFromInt(100), 16)
+	ex.Step("Fee rate", feeName+" / 100", feeRate, explain.Divided(16))
 	if entry.On_chain {
 		one := decimal.NewFromInt(1)
 		feeRate = feeRate.DivRound(one.Sub(feeRate), 16)
+		ex.Step("On-chain gross-up rate", "fee rate / (1 - fee rate)", feeRate, explain.Divided(16))
 	}
+	return fee, feeRate
+}
+
+func calcAmountDefault(mfrFees mfr.StakingFee, entry CalcTableEntry, earnedRewards *decimal.Decimal, fee *decimal.Decimal, amount *decimal.Decimal, balAdjuUsdValue decimal.Decimal, ex *explain.Explanation) {
+	var feeRate decimal.Decimal
+	*fee, feeRate = calcRewardsFeeRate(mfrFees, entry, ex)
 
 	adjustedAmount := earnedRewards.Add(balAdjuUsdValue)
 	*amount = feeRate.Mul(adjustedAmount).Round(2)
+	ex.Step("Adjusted rewards", "earned rewards + balance adjustments", adjustedAmount, "")
+	ex.Step("Amount", "rate x adjusted rewards", *amount, explain.Rounded(2))
 }
 
 func addWarning(organizationName, accountName, assetName, message string, warnings []Warning) []Warning {
@@ -374,76 +517,119 @@ func setCalcTable(calcTable *CalcTable) error {
 	return nil
 }
 
-func CalculateCustodyFees(mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *ubalances.UnclaimedBalances, balAdj *balanceadjustments.BalanceAdjustments, ops *operationsstatuses.OperationsStatuses, dayBal *dailybalances.DailyBalance, firstExternalId int, invoiceDate time.Time) (StakingSummary, []Warning) {
-	debug.NewMessage("Start of Custody Fees calculation.")
+func CalculateCustodyFees(ctx context.Context, mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *ubalances.UnclaimedBalances, balAdj *balanceadjustments.BalanceAdjustments, ops *operationsstatuses.OperationsStatuses, dayBal *dailybalances.DailyBalance, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	debug.NewMessageContext(ctx, "Start of Custody Fees calculation.")
 	var summary StakingSummary
 	var warnings []Warning
+	var errs []string
 
 	ItemCategory := "Custody Fee by Asset"
 	daysInMonth := date.NumberOfDaysInTime(invoiceDate)
-	firstAcc := true
-	currentExternalID := firstExternalId
-	getInvoiceNumber := GenInvoiceNumber(firstExternalId)
-
-	for _, organization := range mfr.GetOrganizations() {
+	for _, organization := range mfr.GetSortedOrganizations() {
+		if ctx.Err() != nil {
+			debug.NewMessageContext(ctx, "Custody Fees calculation cancelled.")
+			break
+		}
 		accResults := []AccountResult{}
-		debug.NewMessage(fmt.Sprintf("Processing organization %s id:%s", organization.DisplayName, organization.Id))
+		debug.NewMessageContext(ctx, fmt.Sprintf("Processing organization %s id:%s", organization.DisplayName, organization.Id))
+		progress.ProcessingOrganization(ctx, progress.StageCustody, organization.DisplayName)
 
 		aucOrgValue, _ := dayBal.GetAverageUsdBalanceByOrg(string(organization.Id), daysInMonth)
 		if aucOrgValue.IsZero() {
 			warnMsg := fmt.Sprintf("Skipping organization %s due to zero AUC value", organization.Name)
-			debug.NewMessage(warnMsg)
+			debug.NewMessageContext(ctx, warnMsg)
 		}
 
-		for _, mfrAccount := range mfr.GetAccounts(organization.Id) {
+		for _, mfrAccount := range mfr.GetSortedAccounts(organization.Id) {
 			var custodyOutputs []StakingOutput
-			debug.NewMessage(fmt.Sprintf("MFR account name: %s", mfrAccount.Name))
+			debug.NewMessageContext(ctx, fmt.Sprintf("MFR account name: %s", mfrAccount.Name))
+
+			// The fee of an account billable only part of the month is tiered
+			// on the organization AUC of the whole month, like the other
+			// accounts, and its share only counts the AUC held while billable.
+			billable, _ := billableDays(mfrAccount, invoiceDate)
+			if billable == 0 {
+				debug.NewMessageContext(ctx, fmt.Sprintf("Skipping account %s, not billable in the invoice month", mfrAccount.Name))
+				continue
+			}
+			firstBillable, lastBillable, _ := billableWindow(mfrAccount, invoiceDate)
+			prorated := billable < daysInMonth
+			proration := decimal.NewFromInt(int64(billable)).DivRound(decimal.NewFromInt(int64(daysInMonth)), 16)
 
 			rwdAccount := rwd.GetAccountById(mfrAccount.Id)
 			if rwdAccount.Name == "" {
-				debug.NewMessage(fmt.Sprintf("Skipping because no entry in Rewards sheet was found for ID %s", mfrAccount.Id))
+				debug.NewMessageContext(ctx, fmt.Sprintf("Skipping because no entry in Rewards sheet was found for ID %s", mfrAccount.Id))
 			}
 
-			currentExternalID = GenExternalID(currentExternalID, firstAcc)
-			firstAcc = false
-			dueDate := GenDueDate(invoiceDate, mfrAccount.BillingTerms)
+			dueDate, err := GenDueDate(ctx, invoiceDate, mfrAccount.BillingTerms)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Account %s: %v", mfrAccount.DisplayName, err))
+				continue
+			}
 
 			accountBalances, err := dayBal.GetAccountBalances(string(organization.Id), mfrAccount.Name)
 			if err != nil {
 				warnMsg := fmt.Sprintf("Error getting account balances: %s", err.Error())
-				debug.NewMessage(warnMsg)
+				debug.NewMessageContext(ctx, warnMsg)
 			}
 
 			if len(accountBalances) == 0 {
 				msg := fmt.Sprintf("Account Balances not found in Daily Balances for this account: %s", mfrAccount.Name)
-				debug.NewMessage(msg)
+				debug.NewMessageContext(ctx, msg)
 			}
 
-			for _, assetType := range mfr.GetAssetTypes(organization.Id, mfrAccount.Id) {
-				avgAucAssets, err := custody.AvgAucByAsset(accountBalances, int(assetType.Id), int64(daysInMonth)) // B
+			for _, assetType := range mfr.GetSortedAssetTypes(organization.Id, mfrAccount.Id) {
+				avgAucAssets, undated, err := custody.AvgAucByAssetBetween(accountBalances, int(assetType.Id), firstBillable, lastBillable, int64(daysInMonth)) // B
 				if err != nil {
 					warnMsg := fmt.Sprintf("Error calculating AvgAucAsset: %s", err.Error())
-					debug.NewMessage(warnMsg)
+					debug.NewMessageContext(ctx, warnMsg)
 				}
 
 				if len(avgAucAssets) == 0 {
 					msg := fmt.Sprintf("Assets not found in the list of assets for this AssetID: %d", assetType.Id)
-					debug.NewMessage(msg)
+					debug.NewMessageContext(ctx, msg)
 				}
 
-				tierRate, err := assetType.FindAllTiers(aucOrgValue)
+				schedule, err := assetType.Schedule()
 				if err != nil {
-					warnMsg := fmt.Sprintf("Error finding tiers: %s", err.Error())
-					debug.NewMessage(warnMsg)
+					warnings = addWarning(organization.Name, mfrAccount.Name, "", fmt.Sprintf("Custody fees of asset type %d were not billed: %v", assetType.Id, err), warnings)
+					continue
 				}
 
-				for assetName, avgAucAsset := range avgAucAssets {
-					feeAmount := custody.CalcEffectiveFeeAmount(tierRate, assetType.MinimumFee, aucOrgValue).Round(2)
-
-					billedAmount := avgAucAsset.DivRound(aucOrgValue, 2).Mul(feeAmount).Round(2)
+				assetTypeID := assetType.Id
+				for _, assetName := range sortedKeys(avgAucAssets) {
+					avgAucAsset := avgAucAssets[assetName]
+					ex := explain.New(ctx, "Custody fee")
+					ex.Input("Account", mfrAccount.Name)
+					ex.Input("Asset", assetName)
+					ex.Input("Asset type", assetType.Id)
+					ex.Input("Organization average AUC", aucOrgValue)
+					ex.Input("Billable days", fmt.Sprintf("%d of %d, %s to %s", billable, daysInMonth, firstBillable.Format("2006-01-02"), lastBillable.Format("2006-01-02")))
+					ex.Input("Asset average AUC", avgAucAsset)
+
+					// The minimum charge is applied once per customer by the
+					// Minimum Fee True-up, after all the line items exist.
+					feeAmount := custody.CalcScheduleFeeExplained(schedule, aucOrgValue, ex).Round(2)
+					ex.Step("Fee amount", "tiered fee", feeAmount, explain.Rounded(2))
+
+					assetShare := avgAucAsset.DivRound(aucOrgValue, 2)
 					monthlyRate := feeAmount.DivRound(decimal.NewFromInt(12), 2).Round(2)
+					ex.Step("Asset share", "asset average AUC / organization average AUC", assetShare, explain.Divided(2))
+					var billedAmount decimal.Decimal
+					if prorated && undated[assetName] {
+						// Without the date of each balance, the AUC held while
+						// billable can only be told from the billable days.
+						warnings = addWarning(organization.Name, mfrAccount.Name, assetName, "The daily balances have no date, the custody fee was prorated by the billable days of the month", warnings)
+						billedAmount = assetShare.Mul(feeAmount).Mul(proration).Round(2)
+						ex.Step("Proration", "billable days / days in month", proration, explain.Divided(16))
+						ex.Step("Amount", "asset share x fee amount x proration", billedAmount, explain.Rounded(2))
+					} else {
+						billedAmount = assetShare.Mul(feeAmount).Round(2)
+						ex.Step("Amount", "asset share x fee amount", billedAmount, explain.Rounded(2))
+					}
+					ex.Step("Monthly rate", "fee amount / 12", monthlyRate, explain.DividedThenRounded(2, 2))
 					custodyOutputs = append(custodyOutputs, StakingOutput{
-						ServiceType:     "Custody Fee",
+						ServiceType:     CustodyServiceType,
 						Asset:           assetName,
 						Amount:          billedAmount,
 						EarnedRewards:   avgAucAsset,
@@ -453,6 +639,8 @@ func CalculateCustodyFees(mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *u
 						ItemQuantity:    "",
 						Memo:            "",
 						MonthlyRate:     monthlyRate.StringFixed(2),
+						Explanation:     ex,
+						assetTypeID:     &assetTypeID,
 					})
 				}
 			}
@@ -461,38 +649,40 @@ func CalculateCustodyFees(mfr *mfr.MasterFeeRates, rwd *rewards.Rewards, ubal *u
 			entityID := organization.EntityId
 			if entityID == "" {
 				msg := "Failed to fetch EntityID from: " + organization.Name
-				debug.NewMessage(msg)
+				debug.NewMessageContext(ctx, msg)
 				log.Println(msg)
 			} else {
-				invoiceNumber := getInvoiceNumber(entityID, string(organization.Id))
-				externalID := fmt.Sprintf("%d", currentExternalID)
 				accResults = append(accResults, AccountResult{
-					AccName:       mfrAccount.Name,
-					BillingTerms:  mfrAccount.BillingTerms,
-					CustomerID:    customerID,
-					DisplayName:   mfrAccount.DisplayName,
-					ExternalID:    externalID,
-					EntityID:      entityID,
-					InvoiceNumber: invoiceNumber,
-					InvoiceDate:   invoiceDate.Format("01/02/2006"),
-					DueDate:       dueDate.Format("01/02/2006"),
-					Assets:        custodyOutputs,
+					AccountID:    string(mfrAccount.Id),
+					AccName:      mfrAccount.Name,
+					BillingTerms: mfrAccount.BillingTerms,
+					CustomerID:   customerID,
+					DisplayName:  mfrAccount.DisplayName,
+					EntityID:     entityID,
+					InvoiceDate:  invoiceDate.Format("01/02/2006"),
+					DueDate:      dueDate.Format("01/02/2006"),
+					Assets:       custodyOutputs,
 				})
 			}
 		}
 
 		summary = append(summary, OrgResult{
+			MsaID:    string(organization.Id),
 			OrgName:  organization.Name,
 			Accounts: accResults,
 		})
 	}
 
-	debug.NewMessage("End of Custody Fees calculation.")
+	debug.NewMessageContext(ctx, "End of Custody Fees calculation.")
 	if summaryJson, err := json.Marshal(summary); err != nil {
-		debug.NewMessage(fmt.Sprintf("Failed to json.Marshal summary %s", err.Error()))
+		debug.NewMessageContext(ctx, fmt.Sprintf("Failed to json.Marshal summary %s", err.Error()))
 	} else {
-		debug.NewMessage(string(summaryJson))
+		debug.NewMessageContext(ctx, string(summaryJson))
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, "\n"))
 	}
 
-	return summary, warnings
+	return summary, warnings, nil
 }
@@ -3,9 +3,12 @@
 package fees_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
 )
 
@@ -15,30 +18,29 @@ var (
 )
 
 func TestGenerateInvoiceNumber(t *testing.T) {
-	getInvoiceNumber := fees.GenInvoiceNumber(1)
-
-	invoice1 := getInvoiceNumber("15", "1001")
-	expected1 := "ADB-1"
-	if invoice1 != expected1 {
-		t.Errorf("expected %s, but got %s", expected1, invoice1)
+	registry, err := entities.Default()
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	invoice2 := getInvoiceNumber("33", "1002")
-	expected2 := "ABS-2"
-	if invoice2 != expected2 {
-		t.Errorf("expected %s, but got %s", expected2, invoice2)
+	adb, err := registry.Get("15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice, expected := adb.InvoiceNumber(1), "ADB-1"; invoice != expected {
+		t.Errorf("expected %s, but got %s", expected, invoice)
 	}
 
-	invoice3 := getInvoiceNumber("100", "1003")
-	expected3 := "Acronym Not Found"
-	if invoice3 != expected3 {
-		t.Errorf("expected %s, but got %s", expected3, invoice3)
+	abs, err := registry.Get("33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice, expected := abs.InvoiceNumber(2), "ABS-2"; invoice != expected {
+		t.Errorf("expected %s, but got %s", expected, invoice)
 	}
 
-	invoice4 := getInvoiceNumber("15", "1001")
-	expected4 := "ADB-1"
-	if invoice4 != expected4 {
-		t.Errorf("expected %s, but got %s", invoice4, expected4)
+	if _, err := registry.Get("100"); err == nil {
+		t.Error("expected an error for an unregistered entity")
 	}
 }
 
@@ -47,13 +49,35 @@ func TestIntegration(t *testing.T) {
 	currentExternalID := fees.GenExternalID(currentExternalID, firstAcc)
 	externalID := fmt.Sprintf("%d", currentExternalID)
 
-	getInvoiceNumber := fees.GenInvoiceNumber(currentExternalID)
+	registry, err := entities.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity, err := registry.Get("15")
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	entityID := "15"
-	invoice := getInvoiceNumber(entityID, externalID)
+	invoice := entity.InvoiceNumber(currentExternalID)
 	expected := "ADB-" + externalID
 
 	if invoice != expected {
 		t.Errorf("expected %s, but got %s", expected, invoice)
 	}
 }
+
+func TestGenDueDate(t *testing.T) {
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	dueDate, err := fees.GenDueDate(context.Background(), invoiceDate, "15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2023, time.July, 15, 0, 0, 0, 0, time.UTC); !dueDate.Equal(expected) {
+		t.Errorf("expected %s, but got %s", expected, dueDate)
+	}
+
+	if _, err := fees.GenDueDate(context.Background(), invoiceDate, "Net"); err == nil {
+		t.Error("expected an error for invalid billing terms")
+	}
+}
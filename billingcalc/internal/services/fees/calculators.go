@@ -0,0 +1,257 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+const (
+	StakingCalculatorName = "staking"
+	CustodyCalculatorName = "custody"
+
+	StakingServiceType = "Staking Fee"
+	CustodyServiceType = "Custody Fee"
+)
+
+// FeeCalculator calculates the line items of one service. Datasets lists the
+// reports it reads besides the MFR, the others are left empty when only
+// calculators that don't need them run.
+type FeeCalculator interface {
+	Name() string
+	Datasets() []datasource.Dataset
+	Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error)
+}
+
+// CalculatorRegistry keeps the calculators in the order they were
+// registered, which is the order their line items are merged in.
+type CalculatorRegistry struct {
+	mu          sync.RWMutex
+	calculators []FeeCalculator
+}
+
+func NewCalculatorRegistry(calculators ...FeeCalculator) (*CalculatorRegistry, error) {
+	registry := &CalculatorRegistry{}
+	for _, calculator := range calculators {
+		if err := registry.Register(calculator); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Register adds a calculator, each name can only be registered once.
+func (r *CalculatorRegistry) Register(calculator FeeCalculator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, registered := range r.calculators {
+		if registered.Name() == calculator.Name() {
+			return errors.New(fmt.Sprintf("Fee calculator %q is already registered", calculator.Name()))
+		}
+	}
+	r.calculators = append(r.calculators, calculator)
+	return nil
+}
+
+func (r *CalculatorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+// Select returns the calculators named in names, in registration order. No
+// names selects every calculator.
+func (r *CalculatorRegistry) Select(names []string) ([]FeeCalculator, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(names) == 0 {
+		return append([]FeeCalculator(nil), r.calculators...), nil
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var selected []FeeCalculator
+	for _, calculator := range r.calculators {
+		if wanted[calculator.Name()] {
+			selected = append(selected, calculator)
+			delete(wanted, calculator.Name())
+		}
+	}
+
+	if len(wanted) > 0 {
+		var unknown []string
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, errors.New(fmt.Sprintf("Unknown fee calculators %s, expected some of %s", strings.Join(unknown, ", "), strings.Join(r.namesLocked(), ", ")))
+	}
+	return selected, nil
+}
+
+func (r *CalculatorRegistry) namesLocked() []string {
+	names := make([]string, 0, len(r.calculators))
+	for _, calculator := range r.calculators {
+		names = append(names, calculator.Name())
+	}
+	return names
+}
+
+// neededDatasets lists the reports read by any of calculators.
+func neededDatasets(calculators []FeeCalculator) []datasource.Dataset {
+	var needed []datasource.Dataset
+	seen := make(map[datasource.Dataset]bool)
+	for _, calculator := range calculators {
+		for _, dataset := range calculator.Datasets() {
+			if !seen[dataset] {
+				seen[dataset] = true
+				needed = append(needed, dataset)
+			}
+		}
+	}
+	return needed
+}
+
+// runCalculators runs every calculator concurrently and merges their line
+// items per organization. The results are merged in the order of
+// calculators, whichever finished first, so the same inputs give the same
+// output.
+func runCalculators(ctx context.Context, calculators []FeeCalculator, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	type result struct {
+		summary StakingSummary
+		warns   []Warning
+		err     error
+	}
+
+	var wg sync.WaitGroup
+	results := make([]result, len(calculators))
+	for i, calculator := range calculators {
+		wg.Add(1)
+		go func(i int, calculator FeeCalculator) {
+			defer wg.Done()
+			summary, warns, err := calculator.Calculate(ctx, data, invoiceDate)
+			if err == nil && summary == nil {
+				err = errors.New(fmt.Sprintf("error in the %s fee calculator", calculator.Name()))
+			}
+			results[i] = result{summary: summary, warns: warns, err: err}
+		}(i, calculator)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, errors.New(err.Error())
+	}
+
+	var errs []string
+	summaries := make([]StakingSummary, 0, len(results))
+	var warns []Warning
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err.Error())
+			continue
+		}
+		summaries = append(summaries, r.summary)
+		warns = append(warns, r.warns...)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, " | "))
+	}
+
+	return MergeSummaries(summaries...), warns, nil
+}
+
+// stakingCalculator bills the staked balance of the days without operations
+// status as fill says, and the validators at the fees of validators.
+type stakingCalculator struct {
+	fill       StakedBalanceFill
+	validators *validatorfees.Registry
+}
+
+func (stakingCalculator) Name() string { return StakingCalculatorName }
+
+func (stakingCalculator) Datasets() []datasource.Dataset {
+	return []datasource.Dataset{datasource.DatasetRewards, datasource.DatasetUnclaimedBalances, datasource.DatasetBalanceAdjustments, datasource.DatasetOperationsStatuses}
+}
+
+func (c stakingCalculator) Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	return CalculateStakingFees(ctx, data.Mfr, data.Rewards, data.UnclaimedBalances, data.BalanceAdjustments, data.OperationsStatuses, data.DailyBalances, invoiceDate, c.fill, c.validators)
+}
+
+// withStakingTerms returns selected with its staking calculator filling the
+// staked balance as fill says and resolving the validator fees with
+// validators.
+func withStakingTerms(selected []FeeCalculator, fill StakedBalanceFill, validators *validatorfees.Registry) []FeeCalculator {
+	configured := make([]FeeCalculator, len(selected))
+	for i, calculator := range selected {
+		if staking, ok := calculator.(stakingCalculator); ok {
+			staking.fill = fill
+			staking.validators = validators
+			calculator = staking
+		}
+		configured[i] = calculator
+	}
+	return configured
+}
+
+type custodyCalculator struct{}
+
+func (custodyCalculator) Name() string { return CustodyCalculatorName }
+
+func (custodyCalculator) Datasets() []datasource.Dataset {
+	return []datasource.Dataset{datasource.DatasetRewards, datasource.DatasetDailyBalances}
+}
+
+func (custodyCalculator) Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	return CalculateCustodyFees(ctx, data.Mfr, data.Rewards, data.UnclaimedBalances, data.BalanceAdjustments, data.OperationsStatuses, data.DailyBalances, invoiceDate)
+}
+
+// calculators are the fee calculators every calculation can run. Staking is
+// registered first so its line items come first on the invoices.
+var calculators = mustCalculatorRegistry(stakingCalculator{}, custodyCalculator{}, brokerageCalculator{}, onboardingCalculator{})
+
+func mustCalculatorRegistry(calculators ...FeeCalculator) *CalculatorRegistry {
+	registry, err := NewCalculatorRegistry(calculators...)
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// RegisterCalculator makes calculator available to every calculation. It
+// must be called before the server starts handling requests.
+func RegisterCalculator(calculator FeeCalculator) error {
+	return calculators.Register(calculator)
+}
+
+// CalculatorNames lists the registered fee calculators.
+func CalculatorNames() []string {
+	return calculators.Names()
+}
+
+// NeededDatasets lists the reports read by the calculators named in names,
+// the MFR first. No names is every registered calculator.
+func NeededDatasets(names []string) ([]datasource.Dataset, error) {
+	selected, err := calculators.Select(names)
+	if err != nil {
+		return nil, err
+	}
+	return append([]datasource.Dataset{datasource.DatasetMfr}, neededDatasets(selected)...), nil
+}
+
+// CheckCalculators fails when names has a calculator that is not registered.
+func CheckCalculators(names []string) error {
+	_, err := calculators.Select(names)
+	return err
+}
@@ -0,0 +1,99 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+type fakeCalculator struct {
+	name string
+}
+
+func (c fakeCalculator) Name() string { return c.name }
+
+func (c fakeCalculator) Datasets() []datasource.Dataset { return nil }
+
+func (c fakeCalculator) Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (fees.StakingSummary, []fees.Warning, error) {
+	return fees.StakingSummary{{MsaID: "1", Accounts: []fees.AccountResult{{
+		AccountID: "A",
+		Assets:    []fees.StakingOutput{{ServiceType: c.name, Amount: decimal.NewFromInt(1)}},
+	}}}}, nil, nil
+}
+
+func TestCalculatorRegistry(t *testing.T) {
+	registry, err := fees.NewCalculatorRegistry(fakeCalculator{"first"}, fakeCalculator{"second"}, fakeCalculator{"third"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should select every calculator without names", func(t *testing.T) {
+		selected, err := registry.Select(nil)
+		assert.NoError(t, err)
+		assert.Len(t, selected, 3)
+	})
+
+	t.Run("Should keep the registration order", func(t *testing.T) {
+		selected, err := registry.Select([]string{"third", "first"})
+		assert.NoError(t, err)
+		if assert.Len(t, selected, 2) {
+			assert.Equal(t, "first", selected[0].Name())
+			assert.Equal(t, "third", selected[1].Name())
+		}
+	})
+
+	t.Run("Should reject unknown names", func(t *testing.T) {
+		_, err := registry.Select([]string{"first", "transactions", "brokerage"})
+		assert.EqualError(t, err, "Unknown fee calculators brokerage, transactions, expected some of first, second, third")
+	})
+
+	t.Run("Should not register a name twice", func(t *testing.T) {
+		err := registry.Register(fakeCalculator{"second"})
+		assert.EqualError(t, err, `Fee calculator "second" is already registered`)
+	})
+}
+
+func TestCalculateSelectedCalculators(t *testing.T) {
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	calculate := func(names []string) (*fees.CalculatedFees, error) {
+		mfrFile, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources := datasource.Sources{
+			Mfr:           datasource.NewMfrFromCsv(mfrFile),
+			DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, strings.NewReader(dailyBalancesCsv)),
+		}
+		return fees.Calculate(context.Background(), sources, fees.Invoicing{FirstExternalId: 1, Calculators: names}, invoiceDate)
+	}
+
+	t.Run("Should only bill the selected calculators", func(t *testing.T) {
+		result, err := calculate([]string{fees.CustodyCalculatorName})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, org := range result.Summary {
+			for _, acc := range org.Accounts {
+				for _, line := range acc.Assets {
+					assert.Equal(t, "Custody Fee", line.ServiceType)
+				}
+			}
+		}
+	})
+
+	t.Run("Should fail with an unknown calculator", func(t *testing.T) {
+		_, err := calculate([]string{"transactions"})
+		assert.Error(t, err)
+	})
+}
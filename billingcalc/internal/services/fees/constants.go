@@ -4,3 +4,14 @@ const (
 	AnchorageValidator    = "AnchorageValidator"
 	NonAnchorageValidator = "NonAnchorageValidator"
 )
+
+// Staking fee models, how a staking line was billed.
+const (
+	// RewardsFeeModel is a share of the rewards earned.
+	RewardsFeeModel = "rewards"
+	// StakedBalanceFeeModel is an annual rate on the average staked balance,
+	// for validators keeping all the rewards.
+	StakedBalanceFeeModel = "staked_balance"
+	// BlendedFeeModel weights both by the commission of the validator.
+	BlendedFeeModel = "blended"
+)
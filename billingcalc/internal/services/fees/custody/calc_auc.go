@@ -2,6 +2,7 @@ package custody
 
 import (
 	"errors"
+	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -11,9 +12,34 @@ import (
 
 func AvgAucByAsset(balances map[string]dailybalances.Balance, assetType int, numberDays int64) (map[string]decimal.Decimal, error) {
 	result := make(map[string]decimal.Decimal)
+	err := eachAssetOfType(balances, assetType, func(key string, balance dailybalances.Balance) {
+		result[key] = balance.TotalAucUsd.DivRound(decimal.NewFromInt(numberDays), 16)
+	})
+	return result, err
+}
+
+// AvgAucByAssetBetween averages over numberDays the AUC held from first to
+// last, the other days count as nothing held. The balances without a date
+// are averaged on their whole total and flagged in undated, the caller has to
+// prorate them.
+func AvgAucByAssetBetween(balances map[string]dailybalances.Balance, assetType int, first, last time.Time, numberDays int64) (map[string]decimal.Decimal, map[string]bool, error) {
+	result := make(map[string]decimal.Decimal)
+	undated := make(map[string]bool)
+	err := eachAssetOfType(balances, assetType, func(key string, balance dailybalances.Balance) {
+		total, ok := balance.TotalAucUsdBetween(first, last)
+		if !ok {
+			total = balance.TotalAucUsd
+			undated[key] = true
+		}
+		result[key] = total.DivRound(decimal.NewFromInt(numberDays), 16)
+	})
+	return result, undated, err
+}
+
+func eachAssetOfType(balances map[string]dailybalances.Balance, assetType int, fn func(key string, balance dailybalances.Balance)) error {
 	assetTypeList, err := assettypes.NewAssetTypeList()
 	if err != nil {
-		return result, errors.New(err.Error())
+		return errors.New(err.Error())
 	}
 
 	for key, balance := range balances {
@@ -23,8 +49,8 @@ func AvgAucByAsset(balances map[string]dailybalances.Balance, assetType int, num
 		}
 
 		if assetType != nil {
-			result[key] = balance.TotalAucUsd.DivRound(decimal.NewFromInt(numberDays), 16)
+			fn(key, balance)
 		}
 	}
-	return result, nil
+	return nil
 }
@@ -1,9 +1,13 @@
 package custody
 
 import (
+	"fmt"
+
 	"github.com/shopspring/decimal"
 
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
 )
 
 // CalcEffectiveFeeAmount calculates the effective fee amount based on fee tiers,
@@ -11,34 +15,46 @@ import (
 //
 // It returns the effective fee amount calculated according to the provided data
 func CalcEffectiveFeeAmount(tiers []mfr.TierData, minimumFee mfr.MinimumFee, totalOrgAvgAuc decimal.Decimal) decimal.Decimal {
+	return CalcEffectiveFeeAmountExplained(tiers, minimumFee, totalOrgAvgAuc, nil)
+}
+
+// CalcEffectiveFeeAmountExplained is CalcEffectiveFeeAmount recording the
+// tiers applied and the minimum fee decision in ex.
+func CalcEffectiveFeeAmountExplained(tiers []mfr.TierData, minimumFee mfr.MinimumFee, totalOrgAvgAuc decimal.Decimal, ex *explain.Explanation) decimal.Decimal {
 	if minimumFee.IsAucBased() && totalOrgAvgAuc.LessThan(tiers[0].Floor) {
+		ex.Step("Effective fee", "AUC below the first tier floor, AUC based minimum charge", minimumFee.MinimumCharge, "")
 		return minimumFee.MinimumCharge
 	}
 
-	var effectiveAmount, monthlyRate decimal.Decimal
-	remainingAmount := totalOrgAvgAuc
-
-	if len(tiers) == 1 {
-		monthlyRate = tiers[0].Rate.DivRound(decimal.NewFromFloat(12), 16)
-		effectiveAmount = totalOrgAvgAuc.Mul(monthlyRate)
-	} else {
-		// graduated tiers
-		for _, tier := range tiers {
-			monthlyRate = tier.Rate.DivRound(decimal.NewFromFloat(12), 16)
-			if remainingAmount.GreaterThan(tier.Floor) {
-				effectiveAmount = effectiveAmount.Add(tier.Floor.Mul(monthlyRate))
-				remainingAmount = remainingAmount.Sub(tier.Floor)
-			} else {
-				effectiveAmount = effectiveAmount.Add(remainingAmount.Mul(monthlyRate))
-				break
-			}
-		}
-	}
+	effectiveAmount := CalcTieredFeeAmountExplained(tiers, totalOrgAvgAuc, ex)
 
 	// Check if the effective amount is less than the minimum fee 'Greater of'
 	if minimumFee.IsGreaterOf() && effectiveAmount.LessThan(minimumFee.MinimumCharge) {
+		ex.Step("Effective fee", "tiered fee below the 'Greater of' minimum charge", minimumFee.MinimumCharge, "")
 		return minimumFee.MinimumCharge
 	}
 
 	return effectiveAmount
 }
+
+// CalcTieredFeeAmountExplained is the monthly fee of the tiers applied to the
+// organization AUC, without any minimum fee. The tier floors are band widths,
+// see tiers.BandWidth. No tiers is a zero fee.
+func CalcTieredFeeAmountExplained(tierData []mfr.TierData, totalOrgAvgAuc decimal.Decimal, ex *explain.Explanation) decimal.Decimal {
+	schedule := tiers.Schedule{Mode: tiers.BandWidth}
+	for _, tier := range tierData {
+		schedule.Tiers = append(schedule.Tiers, tiers.Tier{Floor: tier.Floor, Rate: tier.Rate})
+	}
+	return CalcScheduleFeeExplained(schedule, totalOrgAvgAuc, ex)
+}
+
+// CalcScheduleFeeExplained is the monthly fee of schedule applied to the
+// organization AUC, without any minimum fee, recording each band in ex.
+func CalcScheduleFeeExplained(schedule tiers.Schedule, totalOrgAvgAuc decimal.Decimal, ex *explain.Explanation) decimal.Decimal {
+	result := schedule.Apply(totalOrgAvgAuc)
+	for _, band := range result.Bands {
+		ex.Tier(explain.TierRow{Floor: band.Floor, Rate: band.Rate, MonthlyRate: band.MonthlyRate, Balance: band.Balance, Amount: band.Amount})
+	}
+	ex.Step("Tiered fee", fmt.Sprintf("sum of balance x rate / 12 over the %s tiers", schedule.Mode), result.MonthlyFee, explain.Divided(16))
+	return result.MonthlyFee
+}
@@ -0,0 +1,234 @@
+// package explain records how the amount of a line item was calculated: the
+// inputs read from the reports, every intermediate value and the rounding of
+// each step, so a disputed amount can be checked without redoing the math.
+package explain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type contextKey struct{}
+
+// NewContext turns on explain mode for the calculations run with ctx.
+func NewContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKey{}, true)
+}
+
+func Enabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(contextKey{}).(bool)
+	return enabled
+}
+
+// Value is an input of the calculation.
+type Value struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Step is an intermediate value. Rounding is empty when the value is exact.
+type Step struct {
+	Name     string          `json:"name"`
+	Formula  string          `json:"formula"`
+	Value    decimal.Decimal `json:"value"`
+	Rounding string          `json:"rounding,omitempty"`
+}
+
+// RewardRow is a claimed reward of the Rewards report, for the validator the
+// line is billed for.
+type RewardRow struct {
+	BusinessDay   time.Time       `json:"businessDay"`
+	OperationType string          `json:"operationType"`
+	AssetQty      decimal.Decimal `json:"assetQty"`
+	UsdValue      decimal.Decimal `json:"usdValue"`
+}
+
+// BalanceRow is a day of the Unclaimed Balances report. The first day is
+// only the starting balance, Counted is false for it.
+type BalanceRow struct {
+	Date       time.Time       `json:"date"`
+	Balance    decimal.Decimal `json:"balance"`
+	Diff       decimal.Decimal `json:"diff"`
+	ClaimedQty decimal.Decimal `json:"claimedQty"`
+	UsdPrice   decimal.Decimal `json:"usdPrice"`
+	UsdValue   decimal.Decimal `json:"usdValue"`
+	Counted    bool            `json:"counted"`
+}
+
+// StakedBalanceRow is a day of the balance staked to a validator. Fill names
+// the policy that gave the value of a day without operations status, Counted
+// is false for the days left out of the average.
+type StakedBalanceRow struct {
+	Date    time.Time       `json:"date"`
+	Value   decimal.Decimal `json:"value"`
+	Fill    string          `json:"fill,omitempty"`
+	Counted bool            `json:"counted"`
+}
+
+// TierRow is a fee tier applied to the organization AUC.
+type TierRow struct {
+	Floor       decimal.Decimal `json:"floor"`
+	Rate        decimal.Decimal `json:"rate"`
+	MonthlyRate decimal.Decimal `json:"monthlyRate"`
+	Balance     decimal.Decimal `json:"balance"`
+	Amount      decimal.Decimal `json:"amount"`
+}
+
+// Explanation is attached to a line item in explain mode. Its methods do
+// nothing on a nil Explanation, so the calculations record their steps the
+// same way whether explain mode is on or not.
+type Explanation struct {
+	Method         string             `json:"method"`
+	Inputs         []Value            `json:"inputs"`
+	Rewards        []RewardRow        `json:"rewards,omitempty"`
+	DailyBalances  []BalanceRow       `json:"dailyBalances,omitempty"`
+	StakedBalances []StakedBalanceRow `json:"stakedBalances,omitempty"`
+	Tiers          []TierRow          `json:"tiers,omitempty"`
+	Steps          []Step             `json:"steps"`
+}
+
+// New returns the explanation of a line calculated by method, or nil when
+// explain mode is off.
+func New(ctx context.Context, method string) *Explanation {
+	if !Enabled(ctx) {
+		return nil
+	}
+	return &Explanation{Method: method, Inputs: []Value{}, Steps: []Step{}}
+}
+
+// SetMethod replaces the method once the calculation knows which one applies.
+func (e *Explanation) SetMethod(method string) {
+	if e == nil {
+		return
+	}
+	e.Method = method
+}
+
+// Input records an input, decimals are written in full.
+func (e *Explanation) Input(name string, value interface{}) {
+	if e == nil {
+		return
+	}
+	e.Inputs = append(e.Inputs, Value{Name: name, Value: fmt.Sprint(value)})
+}
+
+func (e *Explanation) Step(name, formula string, value decimal.Decimal, rounding string) {
+	if e == nil {
+		return
+	}
+	e.Steps = append(e.Steps, Step{Name: name, Formula: formula, Value: value, Rounding: rounding})
+}
+
+func (e *Explanation) Reward(row RewardRow) {
+	if e == nil {
+		return
+	}
+	e.Rewards = append(e.Rewards, row)
+}
+
+func (e *Explanation) Balance(row BalanceRow) {
+	if e == nil {
+		return
+	}
+	e.DailyBalances = append(e.DailyBalances, row)
+}
+
+func (e *Explanation) StakedBalance(row StakedBalanceRow) {
+	if e == nil {
+		return
+	}
+	e.StakedBalances = append(e.StakedBalances, row)
+}
+
+func (e *Explanation) Tier(row TierRow) {
+	if e == nil {
+		return
+	}
+	e.Tiers = append(e.Tiers, row)
+}
+
+// Rounded describes a value rounded with decimal.Round.
+func Rounded(places int) string {
+	return fmt.Sprintf("rounded to %d decimals, half away from zero", places)
+}
+
+// Divided describes a division done with decimal.DivRound.
+func Divided(places int) string {
+	return fmt.Sprintf("division rounded to %d decimals", places)
+}
+
+// DividedThenRounded describes a decimal.DivRound followed by a Round.
+func DividedThenRounded(divPlaces, places int) string {
+	return Divided(divPlaces) + ", then " + Rounded(places)
+}
+
+// Text writes the explanation for a person to read.
+func (e *Explanation) Text() string {
+	if e == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Method: %s\n", e.Method)
+
+	if len(e.Inputs) > 0 {
+		b.WriteString("Inputs:\n")
+		for _, input := range e.Inputs {
+			fmt.Fprintf(&b, "  %s: %s\n", input.Name, input.Value)
+		}
+	}
+
+	if len(e.Rewards) > 0 {
+		b.WriteString("Rewards:\n")
+		for _, r := range e.Rewards {
+			fmt.Fprintf(&b, "  %s  %-12s qty %s  USD %s\n", r.BusinessDay.Format("2006-01-02"), r.OperationType, r.AssetQty, r.UsdValue)
+		}
+	}
+
+	if len(e.DailyBalances) > 0 {
+		b.WriteString("Daily balances:\n")
+		for _, r := range e.DailyBalances {
+			if !r.Counted {
+				fmt.Fprintf(&b, "  %s  balance %s (starting balance)\n", r.Date.Format("2006-01-02"), r.Balance)
+				continue
+			}
+			fmt.Fprintf(&b, "  %s  balance %s  diff %s  + claimed %s  x price %s = USD %s\n", r.Date.Format("2006-01-02"), r.Balance, r.Diff, r.ClaimedQty, r.UsdPrice, r.UsdValue)
+		}
+	}
+
+	if len(e.StakedBalances) > 0 {
+		b.WriteString("Staked balances:\n")
+		for _, r := range e.StakedBalances {
+			fmt.Fprintf(&b, "  %s  USD %s", r.Date.Format("2006-01-02"), r.Value)
+			if r.Fill != "" {
+				fmt.Fprintf(&b, " (no status, %s)", r.Fill)
+			}
+			if !r.Counted {
+				b.WriteString(" not counted")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(e.Tiers) > 0 {
+		b.WriteString("Tiers:\n")
+		for _, t := range e.Tiers {
+			fmt.Fprintf(&b, "  floor %s  rate %s%%  monthly rate %s  on %s = %s\n", t.Floor, t.Rate, t.MonthlyRate, t.Balance, t.Amount)
+		}
+	}
+
+	b.WriteString("Steps:\n")
+	for _, s := range e.Steps {
+		fmt.Fprintf(&b, "  %s = %s = %s", s.Name, s.Formula, s.Value)
+		if s.Rounding != "" {
+			fmt.Fprintf(&b, " (%s)", s.Rounding)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,38 @@
+//go:build !selectTest || unitTest
+
+package explain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+)
+
+func TestExplanation(t *testing.T) {
+	t.Run("Should record nothing when explain mode is off", func(t *testing.T) {
+		ex := explain.New(context.Background(), "Staking fee")
+		ex.Input("Account", "Test")
+		ex.Step("Amount", "rate x rewards", decimal.NewFromInt(1), explain.Rounded(2))
+
+		assert.Nil(t, ex)
+		assert.Equal(t, "", ex.Text())
+	})
+
+	t.Run("Should write the inputs and steps with their rounding", func(t *testing.T) {
+		ex := explain.New(explain.NewContext(context.Background()), "Staking fee")
+		ex.Input("Anchorage fee (%)", decimal.RequireFromString("15"))
+		ex.Step("Fee rate", "Anchorage fee / 100", decimal.RequireFromString("0.15"), explain.Divided(16))
+		ex.Step("Adjusted rewards", "earned rewards + balance adjustments", decimal.RequireFromString("100.5"), "")
+
+		assert.Equal(t, "Method: Staking fee\n"+
+			"Inputs:\n"+
+			"  Anchorage fee (%): 15\n"+
+			"Steps:\n"+
+			"  Fee rate = Anchorage fee / 100 = 0.15 (division rounded to 16 decimals)\n"+
+			"  Adjusted rewards = earned rewards + balance adjustments = 100.5\n", ex.Text())
+	})
+}
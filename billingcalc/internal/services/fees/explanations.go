@@ -0,0 +1,30 @@
+package fees
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteExplanations writes the explanation of every line item of summary, in
+// the order of the summary. Lines calculated without explain mode are listed
+// without one.
+func WriteExplanations(w io.Writer, summary StakingSummary) error {
+	for _, org := range summary {
+		for _, acc := range org.Accounts {
+			for _, item := range acc.Assets {
+				_, err := fmt.Fprintf(w, "== %s / %s / %s %s %s: %s\n", org.OrgName, acc.DisplayName, item.ServiceType, item.Asset, item.ItemCategory, item.Amount.StringFixed(2))
+				if err != nil {
+					return err
+				}
+				text := item.Explanation.Text()
+				if text == "" {
+					text = "No explanation recorded.\n"
+				}
+				if _, err := fmt.Fprintf(w, "%s\n", text); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -1,6 +1,11 @@
 package fees
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+)
 
 // Contains the info about the Asset for Staking Calculations
 type CalcTableEntry struct {
@@ -25,14 +30,29 @@ type StakingOutput struct {
 	ItemQuantity            string          `json:"itemQuantity"`
 	Memo                    string          `json:"memo"`
 	MonthlyRate             string          `json:"monthlyRate"`
+	// FeeModel is how a staking line was billed, see RewardsFeeModel.
+	FeeModel string `json:"feeModel,omitempty"`
+	// Validator names the validator of a staking line, when the operations
+	// statuses tell it.
+	Validator string `json:"validator,omitempty"`
+	// Explanation is only set in explain mode.
+	Explanation *explain.Explanation `json:"explanation,omitempty"`
+	// assetTypeID is the MFR asset type a custody line was calculated for,
+	// the minimum fee true-up groups the lines by it.
+	assetTypeID *mfr.AssetID
 }
 
 type OrgResult struct {
+	MsaID    string          `json:"msaID"`
 	OrgName  string          `json:"orgName"`
 	Accounts []AccountResult `json:"accounts"`
 }
 
+// AccountResult holds the line items of an account. InvoiceNumber,
+// ExternalID and Currency are left empty by the fee calculations and set by
+// AssembleInvoices.
 type AccountResult struct {
+	AccountID     string          `json:"accountID"`
 	AccName       string          `json:"clientName"`
 	BillingTerms  string          `json:"billingTerms"`
 	CustomerID    string          `json:"customerID"`
@@ -40,6 +60,7 @@ type AccountResult struct {
 	EntityID      string          `json:"entityID"`
 	InvoiceNumber string          `json:"invoiceNumber"`
 	ExternalID    string          `json:"externalID"`
+	Currency      string          `json:"currency"`
 	InvoiceDate   string          `json:"invoiceDate"`
 	DueDate       string          `json:"dueDate"`
 	Assets        []StakingOutput `json:"assets"`
@@ -54,7 +75,11 @@ type Warning struct {
 
 type StakingSummary []OrgResult
 
+// CalculatedFees is the result of a calculation. RunID is set when the
+// invoice identifiers or the one-time fees were recorded, it is the run to
+// release them with.
 type CalculatedFees struct {
-	Summary StakingSummary
-	Warns   []Warning
+	Summary StakingSummary `json:"summary"`
+	Warns   []Warning      `json:"warns"`
+	RunID   string         `json:"runId,omitempty"`
 }
@@ -0,0 +1,219 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+// InvoiceGrouping is the rule deciding which line items go on the same
+// invoice.
+type InvoiceGrouping string
+
+const (
+	// GroupByAccount bills every account on its own invoice.
+	GroupByAccount InvoiceGrouping = "account"
+	// GroupByMsaEntity bills all the accounts of an MSA under the same
+	// Anchorage entity, customer and billing terms on one invoice.
+	GroupByMsaEntity InvoiceGrouping = "msa_entity"
+)
+
+// ParseInvoiceGrouping reads the grouping rule of a request, an empty value
+// is GroupByAccount.
+func ParseInvoiceGrouping(value string) (InvoiceGrouping, error) {
+	switch grouping := InvoiceGrouping(value); grouping {
+	case "":
+		return GroupByAccount, nil
+	case GroupByAccount, GroupByMsaEntity:
+		return grouping, nil
+	default:
+		return "", errors.New(fmt.Sprintf("Invalid invoice grouping %q, expected %q or %q", value, GroupByAccount, GroupByMsaEntity))
+	}
+}
+
+// invoiceKey is the invoice an account is billed on. Under GroupByMsaEntity
+// the accounts of an MSA and entity are only billed together when they have
+// the same customer, billing terms and due date, as the invoice has one of
+// each.
+func (g InvoiceGrouping) invoiceKey(msaID string, acc AccountResult) string {
+	if g == GroupByMsaEntity {
+		return strings.Join([]string{msaID, acc.EntityID, acc.CustomerID, acc.BillingTerms, acc.DueDate}, "/")
+	}
+	return msaID + "/" + acc.AccountID
+}
+
+// Invoicing is how the calculated line items are billed.
+type Invoicing struct {
+	Grouping InvoiceGrouping
+	// FirstExternalId numbers the invoices when Sequences is not set.
+	FirstExternalId int
+	// Sequences, when set, reserves the invoice identifiers for RunID, so no
+	// other run gets them.
+	Sequences *sequence.Allocator
+	RunID     string
+	// Charges, when set, remembers the one-time fees RunID bills.
+	Charges *charges.Tracker
+	// Entities defaults to the registry embedded in the binary.
+	Entities *entities.Registry
+	// Calculators names the fee calculators whose line items are billed,
+	// every registered one when empty.
+	Calculators []string
+	// MinimumFeeLevel groups the fees compared to the minimum charge.
+	MinimumFeeLevel MinimumFeeLevel
+	// StakedBalanceFill fills the days without operations status in the
+	// average staked balance.
+	StakedBalanceFill StakedBalanceFill
+	// ValidatorFees are the staking fees negotiated per validator, the
+	// registry embedded in the binary by default.
+	ValidatorFees *validatorfees.Registry
+}
+
+type invoice struct {
+	entity   entities.Entity
+	accounts []*AccountResult
+}
+
+// groupInvoices copies the summary, applies the entity defaults to its
+// accounts and returns their invoices, in the order of the summary. Every
+// account must belong to a registered entity.
+func groupInvoices(ctx context.Context, summary StakingSummary, grouping InvoiceGrouping, registry *entities.Registry, invoiceDate time.Time) (StakingSummary, []*invoice, error) {
+	byKey := make(map[string]*invoice)
+	var invoices []*invoice
+	var errs []string
+
+	grouped := make(StakingSummary, 0, len(summary))
+	for _, org := range summary {
+		org.Accounts = append([]AccountResult{}, org.Accounts...)
+		for j := range org.Accounts {
+			acc := &org.Accounts[j]
+			entity, err := registry.Get(acc.EntityID)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Account %s of %s: %v", acc.DisplayName, org.OrgName, err))
+				continue
+			}
+			if err := applyDefaults(ctx, acc, entity, invoiceDate); err != nil {
+				return nil, nil, err
+			}
+
+			key := grouping.invoiceKey(org.MsaID, *acc)
+			inv, ok := byKey[key]
+			if !ok {
+				inv = &invoice{entity: entity}
+				byKey[key] = inv
+				invoices = append(invoices, inv)
+			}
+			inv.accounts = append(inv.accounts, acc)
+		}
+		grouped = append(grouped, org)
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, "\n"))
+	}
+	return grouped, invoices, nil
+}
+
+// applyDefaults fills in what the MFR leaves to the entity: the currency,
+// and the billing terms of an account without any.
+func applyDefaults(ctx context.Context, acc *AccountResult, entity entities.Entity, invoiceDate time.Time) error {
+	acc.Currency = entity.DefaultCurrency
+	if acc.BillingTerms != "" || entity.DefaultBillingTerms == "" {
+		return nil
+	}
+	dueDate, err := GenDueDate(ctx, invoiceDate, entity.DefaultBillingTerms)
+	if err != nil {
+		return err
+	}
+	acc.BillingTerms = entity.DefaultBillingTerms
+	acc.DueDate = dueDate.Format("01/02/2006")
+	return nil
+}
+
+func (inv *invoice) setIDs(externalID int, invoiceNumber int) {
+	for _, acc := range inv.accounts {
+		acc.ExternalID = strconv.Itoa(externalID)
+		acc.InvoiceNumber = inv.entity.InvoiceNumber(invoiceNumber)
+	}
+}
+
+// AssembleInvoices groups the line items of the merged summary into invoices
+// and gives each invoice one external ID and one invoice number, the accounts
+// billed on the same invoice share its identifiers. Invoices are numbered in
+// the order of the summary.
+func AssembleInvoices(ctx context.Context, summary StakingSummary, invoicing Invoicing, invoiceDate time.Time) (StakingSummary, error) {
+	registry := invoicing.Entities
+	if registry == nil {
+		var err error
+		if registry, err = entities.Default(); err != nil {
+			return nil, err
+		}
+	}
+
+	assembled, invoices, err := groupInvoices(ctx, summary, invoicing.Grouping, registry, invoiceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoicing.Sequences == nil {
+		numberInvoices(invoices, invoicing.FirstExternalId)
+		return assembled, nil
+	}
+	if err := reserveInvoiceNumbers(ctx, invoices, invoicing.Sequences, invoicing.RunID); err != nil {
+		// A run holds all of its numbers or none, the ones reserved before
+		// the failure are given back.
+		if _, releaseErr := invoicing.Sequences.Release(ctx, invoicing.RunID); releaseErr != nil {
+			return nil, errors.New(fmt.Sprintf("%v, and the numbers of run %s could not be released: %v", err, invoicing.RunID, releaseErr))
+		}
+		return nil, err
+	}
+	return assembled, nil
+}
+
+// numberInvoices counts both identifiers from firstExternalId.
+func numberInvoices(invoices []*invoice, firstExternalId int) {
+	currentExternalID := firstExternalId
+	for i, inv := range invoices {
+		currentExternalID = GenExternalID(currentExternalID, i == 0)
+		inv.setIDs(currentExternalID, currentExternalID)
+	}
+}
+
+// reserveInvoiceNumbers reserves the identifiers for runID: external IDs from
+// sequence.ExternalIDSequence and invoice numbers from the sequence of the
+// entity acronym.
+func reserveInvoiceNumbers(ctx context.Context, invoices []*invoice, sequences *sequence.Allocator, runID string) error {
+	nextExternalID, err := sequences.Reserve(ctx, sequence.ExternalIDSequence, runID, len(invoices))
+	if err != nil {
+		return err
+	}
+
+	countByAcronym := make(map[string]int)
+	for _, inv := range invoices {
+		countByAcronym[inv.entity.Acronym]++
+	}
+	nextByAcronym := make(map[string]int)
+	for _, acronym := range sortedKeys(countByAcronym) {
+		first, err := sequences.Reserve(ctx, acronym, runID, countByAcronym[acronym])
+		if err != nil {
+			return err
+		}
+		nextByAcronym[acronym] = first
+		debug.NewMessageContext(ctx, fmt.Sprintf("Run %s reserved %d %s invoice numbers from %d", runID, countByAcronym[acronym], acronym, first))
+	}
+
+	for _, inv := range invoices {
+		inv.setIDs(nextExternalID, nextByAcronym[inv.entity.Acronym])
+		nextByAcronym[inv.entity.Acronym]++
+		nextExternalID++
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
+)
+
+func invoiceSummary() fees.StakingSummary {
+	return fees.StakingSummary{
+		{MsaID: "1001", OrgName: "A", Accounts: []fees.AccountResult{
+			{AccountID: "a1", EntityID: "15"},
+			{AccountID: "a2", EntityID: "15"},
+		}},
+		{MsaID: "1002", OrgName: "B", Accounts: []fees.AccountResult{
+			{AccountID: "b1", EntityID: "33"},
+		}},
+	}
+}
+
+func invoiceIDs(summary fees.StakingSummary) (externalIDs, invoiceNumbers []string) {
+	for _, org := range summary {
+		for _, acc := range org.Accounts {
+			externalIDs = append(externalIDs, acc.ExternalID)
+			invoiceNumbers = append(invoiceNumbers, acc.InvoiceNumber)
+		}
+	}
+	return externalIDs, invoiceNumbers
+}
+
+func TestAssembleInvoices(t *testing.T) {
+	ctx := context.Background()
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should give each account its own invoice", func(t *testing.T) {
+		summary, err := fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByAccount, FirstExternalId: 10}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, invoiceNumbers := invoiceIDs(summary)
+		assert.Equal(t, []string{"10", "11", "12"}, externalIDs)
+		assert.Equal(t, []string{"ADB-10", "ADB-11", "ABS-12"}, invoiceNumbers)
+	})
+
+	t.Run("Should bill the accounts of an MSA and entity on one invoice", func(t *testing.T) {
+		summary, err := fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByMsaEntity, FirstExternalId: 10}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, invoiceNumbers := invoiceIDs(summary)
+		assert.Equal(t, []string{"10", "10", "11"}, externalIDs)
+		assert.Equal(t, []string{"ADB-10", "ADB-10", "ABS-11"}, invoiceNumbers)
+	})
+
+	t.Run("Should split an MSA and entity invoice on the customer and billing terms", func(t *testing.T) {
+		summary := invoiceSummary()
+		summary[0].Accounts = append(summary[0].Accounts,
+			fees.AccountResult{AccountID: "a3", EntityID: "15", CustomerID: "200"},
+			fees.AccountResult{AccountID: "a4", EntityID: "15", BillingTerms: "15", DueDate: "07/15/2023"},
+		)
+
+		summary, err := fees.AssembleInvoices(ctx, summary, fees.Invoicing{Grouping: fees.GroupByMsaEntity, FirstExternalId: 10}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, invoiceNumbers := invoiceIDs(summary)
+		assert.Equal(t, []string{"10", "10", "11", "12", "13"}, externalIDs)
+		assert.Equal(t, []string{"ADB-10", "ADB-10", "ADB-11", "ADB-12", "ABS-13"}, invoiceNumbers)
+	})
+
+	t.Run("Should fail for an entity that is not registered", func(t *testing.T) {
+		summary := invoiceSummary()
+		summary[1].Accounts[0].EntityID = "99"
+		summary[1].Accounts[0].DisplayName = "Beta Main"
+
+		_, err := fees.AssembleInvoices(ctx, summary, fees.Invoicing{FirstExternalId: 1}, invoiceDate)
+		assert.EqualError(t, err, `Account Beta Main of B: Anchorage Entity ID "99" is not registered (registry version 1)`)
+	})
+
+	t.Run("Should apply the entity defaults", func(t *testing.T) {
+		registry, err := entities.Parse([]byte(`{"version": "test", "entities": [
+			{"entityId": "15", "acronym": "ADB", "invoiceNumberTemplate": "INV-{acronym}-{number}", "defaultCurrency": "USD", "defaultBillingTerms": "30"},
+			{"entityId": "33", "acronym": "ABS", "invoiceNumberTemplate": "{acronym}{number}", "defaultCurrency": "SGD"}
+		]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		summary := invoiceSummary()
+		summary[0].Accounts[1].BillingTerms = "15"
+
+		summary, err = fees.AssembleInvoices(ctx, summary, fees.Invoicing{FirstExternalId: 1, Entities: registry}, invoiceDate)
+		assert.NoError(t, err)
+
+		accounts := append(summary[0].Accounts, summary[1].Accounts...)
+		assert.Equal(t, "INV-ADB-1", accounts[0].InvoiceNumber)
+		assert.Equal(t, "ABS3", accounts[2].InvoiceNumber)
+		assert.Equal(t, "USD", accounts[0].Currency)
+		assert.Equal(t, "SGD", accounts[2].Currency)
+		// The MFR billing terms win over the entity ones.
+		assert.Equal(t, "30", accounts[0].BillingTerms)
+		assert.Equal(t, "07/30/2023", accounts[0].DueDate)
+		assert.Equal(t, "15", accounts[1].BillingTerms)
+		assert.Equal(t, "", accounts[2].BillingTerms)
+	})
+
+	t.Run("Should not change the calculated summary", func(t *testing.T) {
+		calculated := invoiceSummary()
+
+		_, err := fees.AssembleInvoices(ctx, calculated, fees.Invoicing{FirstExternalId: 1}, invoiceDate)
+		assert.NoError(t, err)
+
+		assert.Equal(t, invoiceSummary(), calculated)
+	})
+}
+
+func TestParseInvoiceGrouping(t *testing.T) {
+	grouping, err := fees.ParseInvoiceGrouping("")
+	assert.NoError(t, err)
+	assert.Equal(t, fees.GroupByAccount, grouping)
+
+	grouping, err = fees.ParseInvoiceGrouping("msa_entity")
+	assert.NoError(t, err)
+	assert.Equal(t, fees.GroupByMsaEntity, grouping)
+
+	_, err = fees.ParseInvoiceGrouping("customer")
+	assert.EqualError(t, err, `Invalid invoice grouping "customer", expected "account" or "msa_entity"`)
+}
+
+func TestAssembleInvoicesWithSequences(t *testing.T) {
+	ctx := context.Background()
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	store, err := sequence.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sequences := sequence.NewAllocator(store)
+	_, _ = sequences.Seed(ctx, "ADB", 500)
+
+	t.Run("Should number the invoices of each entity from its own sequence", func(t *testing.T) {
+		summary, err := fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByAccount, Sequences: sequences, RunID: "run-1"}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, invoiceNumbers := invoiceIDs(summary)
+		assert.Equal(t, []string{"1", "2", "3"}, externalIDs)
+		assert.Equal(t, []string{"ADB-500", "ADB-501", "ABS-1"}, invoiceNumbers)
+	})
+
+	t.Run("Should give other runs other numbers", func(t *testing.T) {
+		summary, err := fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByMsaEntity, Sequences: sequences, RunID: "run-2"}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, invoiceNumbers := invoiceIDs(summary)
+		assert.Equal(t, []string{"4", "4", "5"}, externalIDs)
+		assert.Equal(t, []string{"ADB-502", "ADB-502", "ABS-2"}, invoiceNumbers)
+	})
+
+	t.Run("Should give a run calculated again the same numbers", func(t *testing.T) {
+		summary, err := fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByAccount, Sequences: sequences, RunID: "run-1"}, invoiceDate)
+		assert.NoError(t, err)
+
+		externalIDs, _ := invoiceIDs(summary)
+		assert.Equal(t, []string{"1", "2", "3"}, externalIDs)
+	})
+	t.Run("Should release the numbers of a run that could not reserve them all", func(t *testing.T) {
+		// run-3 holds a single ADB number and now needs two of them.
+		_, err := sequences.Reserve(ctx, "ADB", "run-3", 1)
+		assert.NoError(t, err)
+
+		_, err = fees.AssembleInvoices(ctx, invoiceSummary(), fees.Invoicing{Grouping: fees.GroupByAccount, Sequences: sequences, RunID: "run-3"}, invoiceDate)
+		assert.ErrorContains(t, err, "Run run-3 already reserved 1 numbers of ADB and now needs 2")
+
+		for _, name := range []string{sequence.ExternalIDSequence, "ABS", "ADB"} {
+			ledger, err := sequences.Ledger(ctx, name)
+			assert.NoError(t, err)
+			for _, r := range ledger.Reservations {
+				assert.False(t, r.RunID == "run-3" && r.Status == sequence.StatusReserved, name)
+			}
+		}
+		ledger, err := sequences.Ledger(ctx, sequence.ExternalIDSequence)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, ledger.Next)
+	})
+}
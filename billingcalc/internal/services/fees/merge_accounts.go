@@ -1,23 +1,53 @@
 package fees
 
+// MergeSummaries combines the results of several fee calculations, joining
+// the accounts of organizations with the same MSA ID, so two MSAs sharing a
+// name stay apart. Organizations and accounts keep the order they first
+// appear in, so merging ordered summaries gives an ordered summary.
+func MergeSummaries(summaries ...StakingSummary) StakingSummary {
+	mergedResults := make(map[string]*OrgResult)
+	var order []string
+	for _, summary := range summaries {
+		for _, orgResult := range summary {
+			if existingOrg, ok := mergedResults[orgResult.MsaID]; ok {
+				existingOrg.Accounts = MergeAccounts(existingOrg.Accounts, orgResult.Accounts)
+			} else {
+				orgCopy := orgResult
+				mergedResults[orgResult.MsaID] = &orgCopy
+				order = append(order, orgResult.MsaID)
+			}
+		}
+	}
+
+	var combinedSummary StakingSummary
+	for _, msaID := range order {
+		combinedSummary = append(combinedSummary, *mergedResults[msaID])
+	}
+
+	return combinedSummary
+}
+
+// MergeAccounts joins the line items of the accounts with the same account
+// ID.
 func MergeAccounts(accounts1, accounts2 []AccountResult) []AccountResult {
 	mergedAccounts := make(map[string]*AccountResult)
+	var order []string
 
 	for _, accounts := range [][]AccountResult{accounts1, accounts2} {
 		for _, account := range accounts {
-			key := account.AccName + account.BillingTerms + account.CustomerID
-			if existingAccount, ok := mergedAccounts[key]; ok {
+			if existingAccount, ok := mergedAccounts[account.AccountID]; ok {
 				existingAccount.Assets = append(existingAccount.Assets, account.Assets...)
 			} else {
 				accountCopy := account
-				mergedAccounts[key] = &accountCopy
+				mergedAccounts[account.AccountID] = &accountCopy
+				order = append(order, account.AccountID)
 			}
 		}
 	}
 
 	var result []AccountResult
-	for _, account := range mergedAccounts {
-		result = append(result, *account)
+	for _, accountID := range order {
+		result = append(result, *mergedAccounts[accountID])
 	}
 	return result
 }
@@ -0,0 +1,246 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/assettypes"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+const MinimumFeeServiceType = "Minimum Fee True-up"
+
+// MinimumFeeLevel is the group of line items compared to one minimum charge.
+type MinimumFeeLevel string
+
+const (
+	// MinimumFeeByMsa compares all the fees of an MSA to one minimum.
+	MinimumFeeByMsa MinimumFeeLevel = "msa"
+	// MinimumFeeByAccount compares the fees of each account to its minimum.
+	MinimumFeeByAccount MinimumFeeLevel = "account"
+	// MinimumFeeByAssetType compares the fees of each asset type of an
+	// account to its minimum.
+	MinimumFeeByAssetType MinimumFeeLevel = "asset_type"
+)
+
+// ParseMinimumFeeLevel reads the minimum fee level of a request, an empty
+// value is MinimumFeeByMsa.
+func ParseMinimumFeeLevel(value string) (MinimumFeeLevel, error) {
+	switch level := MinimumFeeLevel(value); level {
+	case "":
+		return MinimumFeeByMsa, nil
+	case MinimumFeeByMsa, MinimumFeeByAccount, MinimumFeeByAssetType:
+		return level, nil
+	default:
+		return "", errors.New(fmt.Sprintf("Invalid minimum fee level %q, expected %q, %q or %q", value, MinimumFeeByMsa, MinimumFeeByAccount, MinimumFeeByAssetType))
+	}
+}
+
+// minimumFeeGroup is the accounts, and at the asset type level the one asset
+// type, whose fees are compared to one minimum charge.
+type minimumFeeGroup struct {
+	org       mfr.Organization
+	accounts  []mfr.Account
+	assetType *mfr.AssetType
+}
+
+func minimumFeeGroups(m *mfr.MasterFeeRates, level MinimumFeeLevel) []minimumFeeGroup {
+	var groups []minimumFeeGroup
+	for _, org := range m.GetSortedOrganizations() {
+		accounts := m.GetSortedAccounts(org.Id)
+		switch level {
+		case MinimumFeeByMsa:
+			groups = append(groups, minimumFeeGroup{org: org, accounts: accounts})
+		case MinimumFeeByAccount:
+			for _, account := range accounts {
+				groups = append(groups, minimumFeeGroup{org: org, accounts: []mfr.Account{account}})
+			}
+		case MinimumFeeByAssetType:
+			for _, account := range accounts {
+				for _, assetType := range m.GetSortedAssetTypes(org.Id, account.Id) {
+					assetType := assetType
+					groups = append(groups, minimumFeeGroup{org: org, accounts: []mfr.Account{account}, assetType: &assetType})
+				}
+			}
+		}
+	}
+	return groups
+}
+
+// minimum returns the minimum charge of the group and the asset type it was
+// read from. The MFR repeats the customer level minimum on every asset type,
+// the largest one is used when they differ.
+func (g minimumFeeGroup) minimum(m *mfr.MasterFeeRates) (mfr.AssetType, bool) {
+	assetTypes := []mfr.AssetType{}
+	if g.assetType != nil {
+		assetTypes = append(assetTypes, *g.assetType)
+	} else {
+		for _, account := range g.accounts {
+			assetTypes = append(assetTypes, m.GetSortedAssetTypes(g.org.Id, account.Id)...)
+		}
+	}
+
+	var found mfr.AssetType
+	ok := false
+	for _, assetType := range assetTypes {
+		minimumFee := assetType.MinimumFee
+		if (!minimumFee.IsGreaterOf() && !minimumFee.IsAucBased()) || !minimumFee.MinimumCharge.IsPositive() {
+			continue
+		}
+		if !ok || minimumFee.MinimumCharge.GreaterThan(found.MinimumFee.MinimumCharge) {
+			found, ok = assetType, true
+		}
+	}
+	return found, ok
+}
+
+func (g minimumFeeGroup) describe(level MinimumFeeLevel) string {
+	switch level {
+	case MinimumFeeByAccount:
+		return "account " + g.accounts[0].DisplayName
+	case MinimumFeeByAssetType:
+		return fmt.Sprintf("account %s, asset type %d", g.accounts[0].DisplayName, g.assetType.Id)
+	default:
+		return "MSA " + string(g.org.Id)
+	}
+}
+
+// countsTowardsMinimum tells whether a line is one of the recurring fees the
+// minimum charge is compared to.
+func countsTowardsMinimum(line StakingOutput) bool {
+	return line.ServiceType == CustodyServiceType || line.ServiceType == StakingServiceType
+}
+
+// inAssetType tells whether a line was billed for the asset type. Custody
+// lines know the asset type they were calculated for, staking lines are
+// matched by their asset.
+func inAssetType(line StakingOutput, id mfr.AssetID, assetTypeList *assettypes.AssetTypeList) bool {
+	if line.assetTypeID != nil {
+		return *line.assetTypeID == id
+	}
+	assetType, err := assetTypeList.GetTypeByAssetName(line.Asset, int(id))
+	return err == nil && assetType != nil
+}
+
+// applyMinimumFees compares the custody and staking fees of each group of
+// level to its minimum charge, prorated to the billable days of its accounts,
+// and adds one Minimum Fee True-up line for the difference. The line goes to
+// the first account of the group with fees, groups without any are not
+// billed a minimum.
+func applyMinimumFees(ctx context.Context, summary StakingSummary, data *datasource.Datasets, level MinimumFeeLevel, invoiceDate time.Time) (StakingSummary, error) {
+	assetTypeList, err := assettypes.NewAssetTypeList()
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	type position struct{ org, acc int }
+	positions := make(map[string]position)
+	result := make(StakingSummary, len(summary))
+	for i, org := range summary {
+		org.Accounts = append([]AccountResult{}, org.Accounts...)
+		result[i] = org
+		for j, acc := range org.Accounts {
+			key := org.MsaID + "/" + acc.AccountID
+			if _, exists := positions[key]; !exists {
+				positions[key] = position{i, j}
+			}
+		}
+	}
+
+	daysInMonth := date.NumberOfDaysInTime(invoiceDate)
+	for _, group := range minimumFeeGroups(data.Mfr, level) {
+		minimumType, ok := group.minimum(data.Mfr)
+		if !ok {
+			continue
+		}
+		minimumFee := minimumType.MinimumFee
+
+		var orgAuc decimal.Decimal
+		if minimumFee.IsAucBased() {
+			orgAuc, _ = data.DailyBalances.GetAverageUsdBalanceByOrg(string(group.org.Id), daysInMonth)
+			if len(minimumType.TierData) > 0 && orgAuc.GreaterThanOrEqual(minimumType.TierData[0].Floor) {
+				continue
+			}
+		}
+
+		fees := decimal.Zero
+		billedLines := 0
+		var target *AccountResult
+		maxBillable := 0
+		for _, account := range group.accounts {
+			billable, _ := billableDays(account, invoiceDate)
+			if billable > maxBillable {
+				maxBillable = billable
+			}
+
+			pos, found := positions[string(group.org.Id)+"/"+string(account.Id)]
+			if !found {
+				continue
+			}
+			acc := &result[pos.org].Accounts[pos.acc]
+			for _, line := range acc.Assets {
+				if !countsTowardsMinimum(line) {
+					continue
+				}
+				if group.assetType != nil && !inAssetType(line, group.assetType.Id, assetTypeList) {
+					continue
+				}
+				fees = fees.Add(line.Amount)
+				billedLines++
+				if target == nil {
+					target = acc
+				}
+			}
+		}
+		if billedLines == 0 || maxBillable == 0 {
+			continue
+		}
+
+		ex := explain.New(ctx, "Minimum fee true-up")
+		ex.Input("Level", group.describe(level))
+		ex.Input("Minimum fee type", minimumFee.MinimumFeeType)
+		ex.Input("Minimum charge", minimumFee.MinimumCharge)
+		if minimumFee.IsAucBased() {
+			ex.Input("Organization average AUC", orgAuc)
+		}
+		ex.Input("Billable days", fmt.Sprintf("%d of %d", maxBillable, daysInMonth))
+
+		minimum := minimumFee.MinimumCharge
+		if maxBillable < daysInMonth {
+			proration := decimal.NewFromInt(int64(maxBillable)).DivRound(decimal.NewFromInt(int64(daysInMonth)), 16)
+			minimum = minimum.Mul(proration).Round(2)
+			ex.Step("Proration", "billable days / days in month", proration, explain.Divided(16))
+			ex.Step("Minimum", "minimum charge x proration", minimum, explain.Rounded(2))
+		}
+		ex.Step("Fees", fmt.Sprintf("sum of %d custody and staking lines", billedLines), fees, "")
+
+		trueUp := minimum.Sub(fees)
+		if !trueUp.IsPositive() {
+			continue
+		}
+		ex.Step("Amount", "minimum - fees", trueUp, "")
+
+		debug.NewMessageContext(ctx, fmt.Sprintf("Minimum fee true-up of %s for %s", trueUp.StringFixed(2), group.describe(level)))
+		target.Assets = append(append([]StakingOutput{}, target.Assets...), StakingOutput{
+			ServiceType:   MinimumFeeServiceType,
+			Amount:        trueUp,
+			EarnedRewards: fees,
+			FeeRates:      minimumFee.MinimumCharge,
+			ItemCategory:  MinimumFeeServiceType,
+			ItemQuantity:  "1",
+			Memo:          fmt.Sprintf("Minimum charge %s for %s", minimumFee.MinimumCharge.StringFixed(2), group.describe(level)),
+			MonthlyRate:   minimum.StringFixed(2),
+			Explanation:   ex,
+		})
+	}
+
+	return result, nil
+}
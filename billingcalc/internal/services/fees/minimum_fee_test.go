@@ -0,0 +1,80 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static/statictest"
+)
+
+func TestMinimumFeeTrueUp(t *testing.T) {
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	// The Beta custody fees are 7,425 + 14,180.83 + 1,402.50 = 23,008.33.
+	calculate := func(t *testing.T, minimumCharge string, level fees.MinimumFeeLevel) []fees.StakingOutput {
+		mfrCsv := statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+			for i := 4; i < 8; i++ {
+				rows[i][3] = "Greater of"
+				rows[i][29] = minimumCharge
+			}
+		})
+		sources := datasource.Sources{
+			Mfr:           datasource.NewMfrFromCsv(bytes.NewReader(mfrCsv)),
+			DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, strings.NewReader(dailyBalancesCsv)),
+		}
+		result, err := fees.Calculate(context.Background(), sources, fees.Invoicing{FirstExternalId: 1, MinimumFeeLevel: level}, invoiceDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var trueUps []fees.StakingOutput
+		for _, org := range result.Summary {
+			for _, acc := range org.Accounts {
+				for _, line := range acc.Assets {
+					if line.ServiceType == fees.MinimumFeeServiceType {
+						trueUps = append(trueUps, line)
+					}
+				}
+			}
+		}
+		return trueUps
+	}
+
+	t.Run("Should add one true-up for the customer fees below the minimum", func(t *testing.T) {
+		trueUps := calculate(t, "$30,000", fees.MinimumFeeByMsa)
+		if assert.Len(t, trueUps, 1) {
+			assert.Equal(t, "6991.67", trueUps[0].Amount.StringFixed(2))
+			assert.Equal(t, "23008.33", trueUps[0].EarnedRewards.StringFixed(2))
+		}
+	})
+
+	t.Run("Should not add a true-up when the fees reach the minimum", func(t *testing.T) {
+		assert.Empty(t, calculate(t, "$5,000", fees.MinimumFeeByMsa))
+	})
+
+	t.Run("Should compare the fees of each asset type at the asset type level", func(t *testing.T) {
+		trueUps := calculate(t, "$30,000", fees.MinimumFeeByAssetType)
+		if assert.Len(t, trueUps, 2) {
+			billed := trueUps[0].EarnedRewards.Add(trueUps[1].EarnedRewards)
+			assert.Equal(t, "23008.33", billed.StringFixed(2))
+		}
+	})
+}
+
+func TestParseMinimumFeeLevel(t *testing.T) {
+	level, err := fees.ParseMinimumFeeLevel("")
+	assert.NoError(t, err)
+	assert.Equal(t, fees.MinimumFeeByMsa, level)
+
+	_, err = fees.ParseMinimumFeeLevel("customer")
+	assert.EqualError(t, err, `Invalid minimum fee level "customer", expected "msa", "account" or "asset_type"`)
+}
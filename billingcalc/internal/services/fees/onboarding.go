@@ -0,0 +1,198 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+)
+
+const (
+	OnboardingCalculatorName = "onboarding"
+	OnboardingServiceType    = "Onboarding Fee"
+)
+
+type onboardingCalculator struct{}
+
+func (onboardingCalculator) Name() string { return OnboardingCalculatorName }
+
+// Datasets is empty, the onboarding terms are all in the MFR.
+func (onboardingCalculator) Datasets() []datasource.Dataset {
+	return nil
+}
+
+func (onboardingCalculator) Calculate(ctx context.Context, data *datasource.Datasets, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	return CalculateOnboardingFees(ctx, data.Mfr, invoiceDate)
+}
+
+// CalculateOnboardingFees bills the onboarding fee of the accounts onboarded
+// in the month of invoiceDate. Fees without an onboarding date are reported
+// as warnings and not billed.
+func CalculateOnboardingFees(ctx context.Context, mfr *mfr.MasterFeeRates, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	debug.NewMessageContext(ctx, "Start of Onboarding Fees calculation.")
+	summary := StakingSummary{}
+	var warnings []Warning
+	var errs []string
+
+	for _, organization := range mfr.GetSortedOrganizations() {
+		accResults := []AccountResult{}
+		for _, mfrAccount := range mfr.GetSortedAccounts(organization.Id) {
+			onboarding := mfrAccount.Onboarding
+			if !onboarding.Fee.IsPositive() {
+				continue
+			}
+			if onboarding.Date.IsZero() {
+				warnings = append(warnings, Warning{
+					OrgName:     organization.Name,
+					AccName:     mfrAccount.Name,
+					Description: "The onboarding fee was not billed, the account has no Onboarding Date in the MFR.",
+				})
+				continue
+			}
+			if !sameMonth(onboarding.Date, invoiceDate) {
+				continue
+			}
+
+			dueDate, err := GenDueDate(ctx, invoiceDate, mfrAccount.BillingTerms)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("Account %s: %v", mfrAccount.DisplayName, err))
+				continue
+			}
+
+			ex := explain.New(ctx, "Onboarding fee")
+			ex.Input("Account", mfrAccount.Name)
+			ex.Input("Onboarding Date", onboarding.Date.Format("2006-01-02"))
+			ex.Input("Onboarding Fee", onboarding.Fee)
+			amount := onboarding.Fee.Round(2)
+			ex.Step("Amount", "onboarding fee", amount, explain.Rounded(2))
+
+			accResults = append(accResults, AccountResult{
+				AccountID:    string(mfrAccount.Id),
+				AccName:      mfrAccount.Name,
+				BillingTerms: mfrAccount.BillingTerms,
+				CustomerID:   mfrAccount.CustomerId,
+				DisplayName:  mfrAccount.DisplayName,
+				EntityID:     organization.EntityId,
+				InvoiceDate:  invoiceDate.Format("01/02/2006"),
+				DueDate:      dueDate.Format("01/02/2006"),
+				Assets: []StakingOutput{{
+					ServiceType:  OnboardingServiceType,
+					Amount:       amount,
+					ItemCategory: OnboardingServiceType,
+					ItemQuantity: "1",
+					Memo:         "Onboarded on " + onboarding.Date.Format("01/02/2006"),
+					Explanation:  ex,
+				}},
+			})
+		}
+
+		if len(accResults) > 0 {
+			summary = append(summary, OrgResult{
+				MsaID:    string(organization.Id),
+				OrgName:  organization.Name,
+				Accounts: accResults,
+			})
+		}
+	}
+
+	debug.NewMessageContext(ctx, "End of Onboarding Fees calculation.")
+
+	if len(errs) > 0 {
+		return nil, nil, errors.New(strings.Join(errs, "\n"))
+	}
+
+	return summary, warnings, nil
+}
+
+func sameMonth(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+// chargeOneTimeFees records the onboarding fees of the summary as charged by
+// runID and drops the ones another run already charged, with a warning.
+// Without a tracker the fees are billed with a warning, as nothing keeps
+// another run from billing them again.
+func chargeOneTimeFees(ctx context.Context, summary StakingSummary, tracker *charges.Tracker, runID string, invoiceDate time.Time) (StakingSummary, []Warning, error) {
+	if tracker == nil {
+		return summary, unrecordedChargeWarnings(summary), nil
+	}
+
+	var candidates []charges.Charge
+	for _, org := range summary {
+		for _, acc := range org.Accounts {
+			for _, output := range acc.Assets {
+				if output.ServiceType == OnboardingServiceType {
+					candidates = append(candidates, charges.Charge{AccountID: acc.AccountID, Fee: OnboardingServiceType, Amount: output.Amount, InvoiceDate: invoiceDate})
+				}
+			}
+		}
+	}
+
+	_, chargedBefore, err := tracker.Claim(ctx, runID, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chargedBefore) == 0 {
+		return summary, nil, nil
+	}
+
+	charged := make(map[string]charges.Charge)
+	for _, c := range chargedBefore {
+		charged[c.AccountID] = c
+	}
+
+	var warnings []Warning
+	filtered := make(StakingSummary, 0, len(summary))
+	for _, org := range summary {
+		accounts := make([]AccountResult, 0, len(org.Accounts))
+		for _, acc := range org.Accounts {
+			outputs := make([]StakingOutput, 0, len(acc.Assets))
+			for _, output := range acc.Assets {
+				c, found := charged[acc.AccountID]
+				if output.ServiceType == OnboardingServiceType && found {
+					warnings = append(warnings, Warning{
+						OrgName:     org.OrgName,
+						AccName:     acc.AccName,
+						Description: fmt.Sprintf("The onboarding fee was not billed again, run %s already charged it on the invoice of %s.", c.RunID, c.InvoiceDate.Format("01/02/2006")),
+					})
+					continue
+				}
+				outputs = append(outputs, output)
+			}
+			if len(outputs) > 0 {
+				acc.Assets = outputs
+				accounts = append(accounts, acc)
+			}
+		}
+		if len(accounts) > 0 {
+			org.Accounts = accounts
+			filtered = append(filtered, org)
+		}
+	}
+	return filtered, warnings, nil
+}
+
+func unrecordedChargeWarnings(summary StakingSummary) []Warning {
+	var warnings []Warning
+	for _, org := range summary {
+		for _, acc := range org.Accounts {
+			for _, output := range acc.Assets {
+				if output.ServiceType == OnboardingServiceType {
+					warnings = append(warnings, Warning{
+						OrgName:     org.OrgName,
+						AccName:     acc.AccName,
+						Description: "The onboarding fee was billed without being recorded, no one-time charges store is configured so another run can bill it again.",
+					})
+				}
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,124 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/charges"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static/statictest"
+)
+
+// onboardingMfrCsv is the sample MFR with a 5,000 onboarding fee for
+// accountIdFor2222, onboarded on 9/14/2022.
+func onboardingMfrCsv(t *testing.T) []byte {
+	return statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+		rows[4][50] = "5,000"
+	})
+}
+
+func onboardingLines(summary fees.StakingSummary) []fees.StakingOutput {
+	var lines []fees.StakingOutput
+	for _, org := range summary {
+		for _, acc := range org.Accounts {
+			for _, line := range acc.Assets {
+				if line.ServiceType == fees.OnboardingServiceType {
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+func TestCalculateOnboardingFees(t *testing.T) {
+	rows, err := csv.NewReader(bytes.NewReader(onboardingMfrCsv(t))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfrData, err := mfr.ParseMfrCsv(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should bill the fee in the month of the onboarding date", func(t *testing.T) {
+		summary, _, err := fees.CalculateOnboardingFees(context.Background(), mfrData, time.Date(2022, time.September, 30, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		lines := onboardingLines(summary)
+		if assert.Len(t, lines, 1) {
+			assert.Equal(t, "5000.00", lines[0].Amount.StringFixed(2))
+			assert.Equal(t, "1", lines[0].ItemQuantity)
+			assert.Equal(t, "accountIdFor2222", summary[0].Accounts[0].AccountID)
+		}
+	})
+
+	t.Run("Should not bill the fee in another month", func(t *testing.T) {
+		summary, _, err := fees.CalculateOnboardingFees(context.Background(), mfrData, time.Date(2022, time.October, 31, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Empty(t, onboardingLines(summary))
+	})
+}
+
+func TestCalculateChargesOnboardingOnce(t *testing.T) {
+	store, err := charges.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := charges.NewTracker(store)
+	mfrCsv := onboardingMfrCsv(t)
+	invoiceDate := time.Date(2022, time.September, 30, 0, 0, 0, 0, time.UTC)
+
+	calculate := func(runID string) *fees.CalculatedFees {
+		sources := datasource.Sources{Mfr: datasource.NewMfrFromCsv(bytes.NewReader(mfrCsv))}
+		invoicing := fees.Invoicing{FirstExternalId: 1, Charges: tracker, RunID: runID, Calculators: []string{fees.OnboardingCalculatorName}}
+		result, err := fees.Calculate(context.Background(), sources, invoicing, invoiceDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	first := calculate("run-1")
+	assert.Len(t, onboardingLines(first.Summary), 1)
+	assert.Equal(t, "run-1", first.RunID)
+
+	t.Run("Should bill the fee again when the same run is calculated again", func(t *testing.T) {
+		assert.Len(t, onboardingLines(calculate("run-1").Summary), 1)
+	})
+
+	t.Run("Should not bill the fee charged by another run", func(t *testing.T) {
+		result := calculate("run-2")
+		assert.Empty(t, onboardingLines(result.Summary))
+		// The first warning is the terminated account of the sample MFR.
+		if assert.Len(t, result.Warns, 2) {
+			assert.Equal(t, "The onboarding fee was not billed again, run run-1 already charged it on the invoice of 09/30/2022.", result.Warns[1].Description)
+		}
+	})
+
+	t.Run("Should bill the fee of a released run", func(t *testing.T) {
+		_, err := tracker.Release(context.Background(), "run-1")
+		assert.NoError(t, err)
+		assert.Len(t, onboardingLines(calculate("run-3").Summary), 1)
+	})
+
+	t.Run("Should warn when the fee can't be recorded", func(t *testing.T) {
+		sources := datasource.Sources{Mfr: datasource.NewMfrFromCsv(bytes.NewReader(mfrCsv))}
+		invoicing := fees.Invoicing{FirstExternalId: 1, Calculators: []string{fees.OnboardingCalculatorName}}
+		result, err := fees.Calculate(context.Background(), sources, invoicing, invoiceDate)
+		assert.NoError(t, err)
+
+		assert.Len(t, onboardingLines(result.Summary), 1)
+		if assert.Len(t, result.Warns, 2) {
+			assert.Contains(t, result.Warns[1].Description, "no one-time charges store is configured")
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package fees
+
+import (
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+// billableDays returns the days of the invoice month the account is billed
+// for, from its accrual start to its termination, and the days of the month.
+func billableDays(account mfr.Account, invoiceDate time.Time) (billable int, daysInMonth int) {
+	first, last, daysInMonth := billableWindow(account, invoiceDate)
+	if last.Before(first) {
+		return 0, daysInMonth
+	}
+	return int(last.Sub(first).Hours()/24) + 1, daysInMonth
+}
+
+// billableWindow returns the first and last days of the invoice month the
+// account is billed for, last is before first when it is not billed at all.
+func billableWindow(account mfr.Account, invoiceDate time.Time) (first time.Time, last time.Time, daysInMonth int) {
+	daysInMonth = date.NumberOfDaysInTime(invoiceDate)
+	first = time.Date(invoiceDate.Year(), invoiceDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last = first.AddDate(0, 0, daysInMonth-1)
+
+	if start := account.AccrualStart(); start.After(first) {
+		first = start
+	}
+	if end := account.TerminationDate; !end.IsZero() && end.Before(last) {
+		last = end
+	}
+	return first, last, daysInMonth
+}
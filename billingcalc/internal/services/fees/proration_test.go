@@ -0,0 +1,219 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static/statictest"
+)
+
+// The accountIdFor2222 rows of the sample MFR, the only account with daily
+// balances.
+var betaRows = []int{4, 5}
+
+const (
+	csvColLegalName       = 9
+	csvColStatus          = 13
+	csvColFeeAccrual      = 28
+	csvColAccountID       = 92
+	csvColTerminationDate = 93
+)
+
+func custodyLines(t *testing.T, mfrCsv []byte, invoiceDate time.Time) []fees.StakingOutput {
+	return custodyLinesByAccount(t, mfrCsv, dailyBalancesCsv, invoiceDate)["accountIdFor2222"]
+}
+
+// custodyLinesByAccount returns the custody line items of each account ID.
+func custodyLinesByAccount(t *testing.T, mfrCsv []byte, balancesCsv string, invoiceDate time.Time) map[string][]fees.StakingOutput {
+	sources := datasource.Sources{
+		Mfr:           datasource.NewMfrFromCsv(bytes.NewReader(mfrCsv)),
+		DailyBalances: datasource.NewCsvSource(datasource.DailyBalancesReport, strings.NewReader(balancesCsv)),
+	}
+	invoicing := fees.Invoicing{FirstExternalId: 1, Calculators: []string{fees.CustodyCalculatorName}}
+	result, err := fees.Calculate(explain.NewContext(context.Background()), sources, invoicing, invoiceDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := map[string][]fees.StakingOutput{}
+	for _, org := range result.Summary {
+		for _, acc := range org.Accounts {
+			lines[acc.AccountID] = append(lines[acc.AccountID], acc.Assets...)
+		}
+	}
+	return lines
+}
+
+func prorationStep(line fees.StakingOutput) (explain.Step, bool) {
+	for _, step := range line.Explanation.Steps {
+		if step.Name == "Proration" {
+			return step, true
+		}
+	}
+	return explain.Step{}, false
+}
+
+func TestCustodyProration(t *testing.T) {
+	june := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should bill a full month after the fee accrual date", func(t *testing.T) {
+		lines := custodyLines(t, statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", nil), june)
+		if assert.NotEmpty(t, lines) {
+			for _, line := range lines {
+				_, prorated := prorationStep(line)
+				assert.False(t, prorated)
+			}
+		}
+	})
+
+	t.Run("Should prorate from a fee accrual date in the month", func(t *testing.T) {
+		mfrCsv := statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+			for _, i := range betaRows {
+				rows[i][csvColFeeAccrual] = "6/16/2023"
+			}
+		})
+		lines := custodyLines(t, mfrCsv, june)
+		if assert.NotEmpty(t, lines) {
+			for _, line := range lines {
+				step, prorated := prorationStep(line)
+				if assert.True(t, prorated) {
+					assert.Equal(t, "0.5", step.Value.String())
+				}
+			}
+		}
+	})
+
+	t.Run("Should not bill before the fee accrual date", func(t *testing.T) {
+		mfrCsv := statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+			for _, i := range betaRows {
+				rows[i][csvColFeeAccrual] = "7/1/2023"
+			}
+		})
+		assert.Empty(t, custodyLines(t, mfrCsv, june))
+	})
+
+	terminated := statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+		for _, i := range betaRows {
+			rows[i][csvColStatus] = "Terminated"
+			rows[i][csvColTerminationDate] = "6/12/2023"
+		}
+	})
+
+	t.Run("Should prorate the last month of a terminated account", func(t *testing.T) {
+		lines := custodyLines(t, terminated, june)
+		if assert.NotEmpty(t, lines) {
+			for _, line := range lines {
+				step, prorated := prorationStep(line)
+				if assert.True(t, prorated) {
+					assert.Equal(t, "0.4", step.Value.String())
+				}
+			}
+		}
+	})
+
+	t.Run("Should not bill a terminated account after its last month", func(t *testing.T) {
+		assert.Empty(t, custodyLines(t, terminated, time.Date(2023, time.July, 31, 0, 0, 0, 0, time.UTC)))
+	})
+}
+
+// datedBalancesCsv is a daily balances export with the date of each row, the
+// accounts given holding usd of SOL every day of June 2023.
+func datedBalancesCsv(usd string, accounts ...[2]string) string {
+	var b strings.Builder
+	b.WriteString("Date,MSA ID,Account Name,Asset Type,Account Internal ID,Total Quantity,Total USD Value,Unclaimed Rewards USD,Total AUC in USD\n")
+	for day := 1; day <= 30; day++ {
+		for _, account := range accounts {
+			fmt.Fprintf(&b, "2023-06-%02d,22222,%s,SOL,%s,1,%s,0,%s\n", day, account[0], account[1], usd, usd)
+		}
+	}
+	return b.String()
+}
+
+// gammaMfrCsv is the sample MFR with accountIdFor3333, a copy of
+// accountIdFor2222 in the same MSA whose fees accrue from feeAccrual.
+func gammaMfrCsv(t *testing.T, feeAccrual string) []byte {
+	var gamma [][]string
+	mfrCsv := statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+		for _, i := range betaRows {
+			row := append([]string(nil), rows[i]...)
+			row[csvColLegalName] = "Test Gamma Account"
+			row[csvColAccountID] = "accountIdFor3333"
+			row[csvColFeeAccrual] = feeAccrual
+			gamma = append(gamma, row)
+		}
+	})
+
+	buf := bytes.NewBuffer(mfrCsv)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(gamma); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCustodyProrationOfDatedBalances(t *testing.T) {
+	june := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	// Both accounts hold 1,000,000 of SOL every day of June, accountIdFor3333
+	// included before its fees accrue.
+	balancesCsv := datedBalancesCsv("1000000", [2]string{"Test Beta Account", "accountIdFor2222"}, [2]string{"Test Gamma Account", "accountIdFor3333"})
+
+	t.Run("Should only bill the balances held once the fees accrue", func(t *testing.T) {
+		lines := custodyLinesByAccount(t, gammaMfrCsv(t, "6/16/2023"), balancesCsv, june)
+		beta, gamma := solLine(t, lines["accountIdFor2222"]), solLine(t, lines["accountIdFor3333"])
+
+		// Both accounts are tiered on the 2,000,000 the organization held on
+		// average over June.
+		assert.Equal(t, "2000000", inputValue(t, beta, "Organization average AUC"))
+		assert.Equal(t, "2000000", inputValue(t, gamma, "Organization average AUC"))
+		assert.True(t, beta.FeeRates.Equal(gamma.FeeRates))
+
+		// accountIdFor3333 held the same balance for half of the month.
+		assert.Equal(t, "500000", gamma.EarnedRewards.String())
+		diff := gamma.Amount.Mul(decimal.NewFromInt(2)).Sub(beta.Amount).Abs()
+		assert.True(t, diff.LessThanOrEqual(decimal.NewFromFloat(0.01)), "expected half of %s, got %s", beta.Amount, gamma.Amount)
+		_, prorated := prorationStep(gamma)
+		assert.False(t, prorated, "the balances outside the billable days must not be prorated again")
+	})
+
+	t.Run("Should bill less as the fee accrual date moves later", func(t *testing.T) {
+		previous := solLine(t, custodyLinesByAccount(t, gammaMfrCsv(t, "9/22/2022"), balancesCsv, june)["accountIdFor3333"]).Amount
+		for _, feeAccrual := range []string{"6/6/2023", "6/16/2023", "6/26/2023"} {
+			amount := solLine(t, custodyLinesByAccount(t, gammaMfrCsv(t, feeAccrual), balancesCsv, june)["accountIdFor3333"]).Amount
+			assert.True(t, amount.LessThan(previous), "accrual %s billed %s, not less than %s", feeAccrual, amount, previous)
+			previous = amount
+		}
+	})
+}
+
+func solLine(t *testing.T, lines []fees.StakingOutput) fees.StakingOutput {
+	for _, line := range lines {
+		if line.Asset == "SOL" {
+			return line
+		}
+	}
+	t.Fatal("no SOL custody line")
+	return fees.StakingOutput{}
+}
+
+func inputValue(t *testing.T, line fees.StakingOutput, name string) string {
+	for _, input := range line.Explanation.Inputs {
+		if input.Name == name {
+			return input.Value
+		}
+	}
+	t.Fatalf("no %q input", name)
+	return ""
+}
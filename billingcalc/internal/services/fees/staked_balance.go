@@ -0,0 +1,107 @@
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatuses"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+// StakedBalanceFill is how the days of the invoice month without operations
+// status count in the average staked balance.
+type StakedBalanceFill string
+
+const (
+	// FillPrevious gives a day the balance of the last status before it, the
+	// days before the first status of the month take the first one.
+	FillPrevious StakedBalanceFill = "previous"
+	// FillZero counts the days without status as nothing staked.
+	FillZero StakedBalanceFill = "zero"
+	// FillSkip averages over the days with a status only.
+	FillSkip StakedBalanceFill = "skip"
+)
+
+// ParseStakedBalanceFill reads the fill policy of a request, an empty value
+// is FillPrevious.
+func ParseStakedBalanceFill(value string) (StakedBalanceFill, error) {
+	switch fill := StakedBalanceFill(value); fill {
+	case "":
+		return FillPrevious, nil
+	case FillPrevious, FillZero, FillSkip:
+		return fill, nil
+	default:
+		return "", errors.New(fmt.Sprintf("Invalid staked balance fill %q, expected %q, %q or %q", value, FillPrevious, FillZero, FillSkip))
+	}
+}
+
+// lastStatus is the status of the latest day, the one telling the validator
+// commission, or an empty status.
+func lastStatus(statuses []operationsstatuses.Status) operationsstatuses.Status {
+	if len(statuses) == 0 {
+		return operationsstatuses.Status{}
+	}
+	return statuses[len(statuses)-1]
+}
+
+// calcAverageStakedBalance is the time-weighted average USD balance staked to
+// the validator over the days of the invoice month. The statuses of the same
+// day are added up.
+func calcAverageStakedBalance(statuses []operationsstatuses.Status, invoiceDate time.Time, fill StakedBalanceFill, ex *explain.Explanation) decimal.Decimal {
+	byDay := make(map[time.Time]decimal.Decimal)
+	for _, status := range statuses {
+		day := dayOf(status.StatusesDate)
+		byDay[day] = byDay[day].Add(status.StatusesActiveDelegatedValue)
+	}
+
+	previous := decimal.Zero
+	if len(statuses) > 0 {
+		previous = byDay[dayOf(statuses[0].StatusesDate)]
+	}
+
+	monthStart := time.Date(invoiceDate.Year(), invoiceDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := date.NumberOfDaysInTime(invoiceDate)
+	total := decimal.Zero
+	counted := 0
+	for i := 0; i < daysInMonth; i++ {
+		row := explain.StakedBalanceRow{Date: monthStart.AddDate(0, 0, i), Counted: true}
+		if value, ok := byDay[row.Date]; ok {
+			row.Value = value
+			previous = value
+		} else {
+			row.Fill = string(fill)
+			switch fill {
+			case FillZero:
+			case FillSkip:
+				row.Counted = false
+			default:
+				row.Value = previous
+			}
+		}
+
+		ex.StakedBalance(row)
+		if row.Counted {
+			total = total.Add(row.Value)
+			counted++
+		}
+	}
+
+	ex.Input("Staked balance fill", fill)
+	ex.Input("Days with a status", len(byDay))
+	ex.Input("Days counted", counted)
+	if counted == 0 {
+		ex.Step("Average staked balance", "no day counted", decimal.Zero, "")
+		return decimal.Zero
+	}
+	averageStakedBalance := total.DivRound(decimal.NewFromInt(int64(counted)), 16).Round(2)
+	ex.Step("Average staked balance", "sum of the daily staked balances / days counted", averageStakedBalance, explain.DividedThenRounded(16, 2))
+	return averageStakedBalance
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
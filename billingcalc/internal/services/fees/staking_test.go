@@ -0,0 +1,277 @@
+//go:build !selectTest || unitTest
+
+package fees_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/datasource"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static/statictest"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+const (
+	csvColStatusAsset       = 5
+	csvColStatusValidatorID = 19
+	csvColStatusAddress     = 18
+	csvColStatusRate        = 24
+	csvColRewardAcount      = 1
+	csvColHashStakedBalance = 68
+	rewardsHeaderRow        = 7
+	alphaAccountID          = "2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22"
+)
+
+// alphaRewardsCsv is the sample rewards report with the account IDs of Test
+// Alpha Account, the staking calculation finds the rewards by account ID.
+func alphaRewardsCsv(t *testing.T) *bytes.Reader {
+	return bytes.NewReader(statictest.EditedCsv(t, "gsheet/rewards_test_calc.csv", func(rows [][]string) {
+		for i, row := range rows {
+			id := ""
+			switch {
+			case i == rewardsHeaderRow:
+				id = "Operations Account Internal ID"
+			case i > rewardsHeaderRow && row[csvColRewardAcount] == "Test Alpha Account":
+				id = alphaAccountID
+			}
+			rows[i] = append(row, id)
+		}
+	}))
+}
+
+// statusesCsv is the sample operations statuses export, every validator rate
+// replaced by rate.
+func statusesCsv(t *testing.T, rate string) *bytes.Reader {
+	return bytes.NewReader(statictest.EditedCsv(t, "gsheet/operations_statuses_test_calc.csv", func(rows [][]string) {
+		for _, row := range rows[1:] {
+			row[csvColStatusRate] = rate
+		}
+	}))
+}
+
+// stakingLines are the June HASH lines of Test Alpha Account, from the
+// operations statuses given.
+func stakingLines(t *testing.T, statuses *bytes.Reader, fill fees.StakedBalanceFill, validators *validatorfees.Registry) []fees.StakingOutput {
+	return stakingLinesIn(explain.NewContext(context.Background()), t, statuses, fill, validators)
+}
+
+// stakingLinesIn is stakingLines calculated in ctx.
+func stakingLinesIn(ctx context.Context, t *testing.T, statuses *bytes.Reader, fill fees.StakedBalanceFill, validators *validatorfees.Registry) []fees.StakingOutput {
+	result := stakingResult(ctx, t, bytes.NewReader(statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", nil)), statuses, fill, validators)
+	var lines []fees.StakingOutput
+	for _, org := range result.Summary {
+		for _, acc := range org.Accounts {
+			for _, line := range acc.Assets {
+				if acc.AccountID == alphaAccountID && line.Asset == "HASH" {
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+	if len(lines) == 0 {
+		t.Fatal("No HASH staking line for Test Alpha Account")
+	}
+	return lines
+}
+
+// stakingResult is the June staking calculation of the MFR and operations
+// statuses given.
+func stakingResult(ctx context.Context, t *testing.T, mfrCsv *bytes.Reader, statuses *bytes.Reader, fill fees.StakedBalanceFill, validators *validatorfees.Registry) *fees.CalculatedFees {
+	sources := datasource.Sources{
+		Mfr:                datasource.NewMfrFromCsv(mfrCsv),
+		Rewards:            datasource.NewCsvSource(datasource.RewardsReport, alphaRewardsCsv(t)),
+		UnclaimedBalances:  datasource.NewCsvSource(datasource.UnclaimedBalancesReport, bytes.NewReader(statictest.EditedCsv(t, "gsheet/unclaimed_test_calc.csv", nil))),
+		OperationsStatuses: datasource.NewCsvSource(datasource.OperationsStatusesReport, statuses),
+	}
+	invoiceDate := time.Date(2023, time.June, 30, 0, 0, 0, 0, time.UTC)
+	invoicing := fees.Invoicing{FirstExternalId: 1, Calculators: []string{fees.StakingCalculatorName}, StakedBalanceFill: fill, ValidatorFees: validators}
+
+	result, err := fees.Calculate(ctx, sources, invoicing, invoiceDate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// hashWarnings are the warnings about the HASH staking lines.
+func hashWarnings(result *fees.CalculatedFees) []string {
+	var warnings []string
+	for _, warning := range result.Warns {
+		if warning.Asset == "HASH" {
+			warnings = append(warnings, warning.Description)
+		}
+	}
+	return warnings
+}
+
+// stakingLine is the June HASH line of Test Alpha Account, its validator rate
+// replaced by rate.
+func stakingLine(t *testing.T, rate string, fill fees.StakedBalanceFill) fees.StakingOutput {
+	return stakingLines(t, statusesCsv(t, rate), fill, nil)[0]
+}
+
+func TestStakingFeeModels(t *testing.T) {
+	t.Run("Should bill the rewards without a commission", func(t *testing.T) {
+		line := stakingLine(t, "0%", "")
+
+		assert.Equal(t, fees.RewardsFeeModel, line.FeeModel)
+		assert.Equal(t, "Delegation Rewards Fees", line.ItemCategory)
+		assert.Equal(t, "2.46", line.Amount.StringFixed(2))
+	})
+
+	t.Run("Should bill the staked balance when the validator keeps all the rewards", func(t *testing.T) {
+		line := stakingLine(t, "100.00%", "")
+
+		// The June average of 1,545,930.01 x 1% / 12
+		assert.Equal(t, fees.StakedBalanceFeeModel, line.FeeModel)
+		assert.Equal(t, "Delegation Rewards Fees - 100% validator", line.ItemCategory)
+		assert.Equal(t, "1288.28", line.Amount.StringFixed(2))
+		assert.Equal(t, "1.00%", line.MonthlyRate)
+	})
+
+	t.Run("Should weight both fees by the commission", func(t *testing.T) {
+		line := stakingLine(t, "80%", "")
+
+		// 20% of the rewards fee and 80% of the staked balance fee.
+		assert.Equal(t, fees.BlendedFeeModel, line.FeeModel)
+		assert.Equal(t, "1031.11", line.Amount.StringFixed(2))
+		assert.Equal(t, "Staking fee, commission validator", line.Explanation.Method)
+		for _, step := range line.Explanation.Steps {
+			if step.Name == "Amount" {
+				assert.True(t, line.Amount.Equal(step.Value))
+			}
+		}
+	})
+
+	t.Run("Should bill every fee model outside explain mode", func(t *testing.T) {
+		for _, rate := range []string{"0%", "100.00%", "80%"} {
+			explained := stakingLine(t, rate, "")
+			line := stakingLinesIn(context.Background(), t, statusesCsv(t, rate), "", nil)[0]
+
+			assert.Nil(t, line.Explanation)
+			assert.True(t, explained.Amount.Equal(line.Amount), rate)
+		}
+	})
+
+	t.Run("Should warn about a commission without a staked balance fee", func(t *testing.T) {
+		mfrCsv := bytes.NewReader(statictest.EditedCsv(t, "gsheet/mfr_test_calc.csv", func(rows [][]string) {
+			for _, row := range rows[3:] {
+				row[csvColHashStakedBalance] = ""
+			}
+		}))
+		result := stakingResult(context.Background(), t, mfrCsv, statusesCsv(t, "80%"), "", nil)
+
+		warnings := hashWarnings(result)
+		if assert.NotEmpty(t, warnings) {
+			assert.Equal(t, "The validator keeps 80% of the rewards but the MFR has no staked balance fee for HASH, the staking fee was billed on the rewards", warnings[0])
+		}
+	})
+}
+
+func TestStakedBalanceFill(t *testing.T) {
+	// Six statuses in June, from 1,660,500.01 on the 6th to 1,459,200.01 on
+	// the 29th.
+	cases := []struct {
+		fill    fees.StakedBalanceFill
+		average string
+		amount  string
+		counted int
+	}{
+		{fees.FillPrevious, "1545930.01", "1288.28", 30},
+		{fees.FillZero, "300860", "250.72", 30},
+		{fees.FillSkip, "1504300.01", "1253.58", 6},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.fill), func(t *testing.T) {
+			line := stakingLine(t, "100.00%", c.fill)
+
+			assert.Equal(t, c.amount, line.Amount.StringFixed(2))
+			assert.Equal(t, c.average, line.FeeRates.String())
+			if assert.Len(t, line.Explanation.StakedBalances, 30) {
+				counted := 0
+				for _, row := range line.Explanation.StakedBalances {
+					if row.Counted {
+						counted++
+					}
+				}
+				assert.Equal(t, c.counted, counted)
+				assert.Equal(t, "", line.Explanation.StakedBalances[5].Fill)
+				assert.Equal(t, string(c.fill), line.Explanation.StakedBalances[6].Fill)
+			}
+		})
+	}
+
+	t.Run("Should reject an unknown fill", func(t *testing.T) {
+		_, err := fees.ParseStakedBalanceFill("interpolate")
+		assert.Error(t, err)
+	})
+}
+
+// validatorStatusesCsv is the sample operations statuses export without
+// commission, the June HASH statuses delegated to the validator given by
+// validatorOf their date.
+func validatorStatusesCsv(t *testing.T, validatorOf func(date string) (id string, address string)) *bytes.Reader {
+	return bytes.NewReader(statictest.EditedCsv(t, "gsheet/operations_statuses_test_calc.csv", func(rows [][]string) {
+		for _, row := range rows[1:] {
+			row[csvColStatusRate] = "0%"
+			if row[csvColStatusAsset] == "HASH" {
+				row[csvColStatusValidatorID], row[csvColStatusAddress] = validatorOf(row[7])
+			}
+		}
+	}))
+}
+
+func TestValidatorStakingLines(t *testing.T) {
+	registry, err := validatorfees.Parse([]byte(`{"version": "1", "validators": [
+		{"validatorId": "val-a", "address": "Operations_Source_Addresses_Alpha_1", "name": "Partner",
+		 "fees": {"HASH": {"rewards": 8}}, "accounts": {"` + alphaAccountID + `": {"HASH": {"rewards": 10}}}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsplit := stakingLine(t, "0%", "")
+
+	t.Run("Should resolve the fee of the validator of the line", func(t *testing.T) {
+		lines := stakingLines(t, validatorStatusesCsv(t, func(string) (string, string) {
+			return "val-a", "Operations_Source_Addresses_Alpha_1"
+		}), "", registry)
+
+		if assert.Len(t, lines, 1) {
+			assert.Equal(t, "Partner", lines[0].Validator)
+			assert.Equal(t, "Validator Partner", lines[0].ItemDescription)
+			assert.Equal(t, "10", lines[0].FeeRates.String())
+			assert.Equal(t, unsplit.EarnedRewards.String(), lines[0].EarnedRewards.String())
+			assert.Contains(t, lines[0].Explanation.Inputs, explain.Value{Name: "Rewards fee source", Value: "account override"})
+		}
+	})
+
+	t.Run("Should split the rewards by the staked balance of each validator", func(t *testing.T) {
+		lines := stakingLines(t, validatorStatusesCsv(t, func(date string) (string, string) {
+			if date >= "2023-06-21" {
+				return "val-b", "hashvaloper1b"
+			}
+			return "val-a", "Operations_Source_Addresses_Alpha_1"
+		}), "", registry)
+
+		if assert.Len(t, lines, 2) {
+			assert.Equal(t, "Partner", lines[0].Validator)
+			assert.Equal(t, "10", lines[0].FeeRates.String())
+			assert.Equal(t, "val-b", lines[1].Validator)
+			assert.True(t, unsplit.FeeRates.Equal(lines[1].FeeRates))
+			assert.Contains(t, lines[1].Explanation.Inputs, explain.Value{Name: "Rewards fee source", Value: "MFR"})
+
+			// 4,522,500.03 of the 9,025,800.06 staked in June was delegated to val-a.
+			total := lines[0].EarnedRewards.Add(lines[1].EarnedRewards)
+			assert.True(t, total.Sub(unsplit.EarnedRewards).Abs().LessThanOrEqual(decimal.NewFromFloat(0.01)))
+			assert.True(t, lines[0].EarnedRewards.GreaterThan(lines[1].EarnedRewards))
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package fees
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/operationsstatuses"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/rewards"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees/explain"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+// mfrFeeSource is the source of a staking fee no validator level sets.
+const mfrFeeSource = "MFR"
+
+// stakingValidator is the part of a staking line delegated to one validator.
+type stakingValidator struct {
+	ID       string
+	Address  string
+	Statuses []operationsstatuses.Status
+	// Share is the part of the month's staked balance delegated to the
+	// validator, 1 when the line has a single validator.
+	Share decimal.Decimal
+	// Rewards are the rewards paid from the validator address.
+	Rewards []rewards.ClaimedReward
+}
+
+// splitByValidator groups the month's statuses of a staking line by
+// validator, keeping the validators on the side of entry when the statuses
+// tell it. Rewards are given to the validator whose address paid them, the
+// ones paid from no known address are returned to be split by share. With
+// one validator or none it has all the statuses and rewards.
+func splitByValidator(entry CalcTableEntry, statuses []operationsstatuses.Status, claimed []rewards.ClaimedReward) ([]stakingValidator, []rewards.ClaimedReward) {
+	side := "No"
+	if entry.Validator == "anchorage" {
+		side = "Yes"
+	}
+
+	byKey := make(map[string]*stakingValidator)
+	for _, status := range statuses {
+		if status.IsAnchorageValidator != "" && status.IsAnchorageValidator != side {
+			continue
+		}
+		key := status.ValidatorKey()
+		validator, ok := byKey[key]
+		if !ok {
+			validator = &stakingValidator{ID: status.ValidatorID, Address: status.ValidatorAddress}
+			byKey[key] = validator
+		}
+		validator.Statuses = append(validator.Statuses, status)
+	}
+
+	if len(byKey) <= 1 {
+		validator := stakingValidator{Statuses: statuses, Share: decimal.NewFromInt(1), Rewards: claimed}
+		for _, v := range byKey {
+			validator.ID, validator.Address, validator.Statuses = v.ID, v.Address, v.Statuses
+		}
+		return []stakingValidator{validator}, nil
+	}
+
+	total := decimal.Zero
+	for _, validator := range byKey {
+		for _, status := range validator.Statuses {
+			total = total.Add(status.StatusesActiveDelegatedValue)
+		}
+	}
+
+	validators := make([]stakingValidator, 0, len(byKey))
+	for _, key := range sortedKeys(byKey) {
+		validator := *byKey[key]
+		if total.IsZero() {
+			validator.Share = decimal.NewFromInt(1).DivRound(decimal.NewFromInt(int64(len(byKey))), 16)
+		} else {
+			staked := decimal.Zero
+			for _, status := range validator.Statuses {
+				staked = staked.Add(status.StatusesActiveDelegatedValue)
+			}
+			validator.Share = staked.DivRound(total, 16)
+		}
+		validators = append(validators, validator)
+	}
+
+	var unattributed []rewards.ClaimedReward
+	for _, reward := range claimed {
+		paid := false
+		for i := range validators {
+			if reward.PaidBy(validators[i].Address) {
+				validators[i].Rewards = append(validators[i].Rewards, reward)
+				paid = true
+				break
+			}
+		}
+		if !paid {
+			unattributed = append(unattributed, reward)
+		}
+	}
+	return validators, unattributed
+}
+
+// validatorStakingFee returns the MFR fees of the line with the ones the
+// registry sets for the validator, and the label of the validator. The
+// account override comes first, then the validator rate, then the MFR.
+func validatorStakingFee(registry *validatorfees.Registry, validator stakingValidator, entry CalcTableEntry, accountID string, stakingFee mfr.StakingFee, ex *explain.Explanation) (mfr.StakingFee, string) {
+	label := validator.ID
+	if label == "" {
+		label = validator.Address
+	}
+	rewardsSource, stakedBalanceSource := mfrFeeSource, mfrFeeSource
+
+	if registry != nil {
+		if resolved, ok := registry.Resolve(validator.ID, validator.Address, accountID, entry.Asset); ok {
+			label = resolved.Validator.Label()
+			if resolved.RewardsSource != "" {
+				rewardsSource = resolved.RewardsSource
+				if entry.Validator == "anchorage" {
+					stakingFee.AnchorageFee = resolved.Rewards
+				} else {
+					stakingFee.ThirdPartyFee = resolved.Rewards
+				}
+			}
+			if resolved.StakedBalanceSource != "" {
+				stakedBalanceSource = resolved.StakedBalanceSource
+				stakingFee.StakedBalanceFee = resolved.StakedBalance
+			}
+		}
+	}
+
+	if validator.ID != "" {
+		ex.Input("Validator ID", validator.ID)
+	}
+	if validator.Address != "" {
+		ex.Input("Validator address", validator.Address)
+	}
+	ex.Input("Rewards fee source", rewardsSource)
+	ex.Input("Staked balance fee source", stakedBalanceSource)
+	return stakingFee, label
+}
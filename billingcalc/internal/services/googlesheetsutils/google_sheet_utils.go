@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
 	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/sanitization"
 )
@@ -52,11 +53,12 @@ type GetDatabindFromSheetTabParams[T any] struct {
 	TabName      string
 	ReportName   string
 	HeaderRow    int
-	Parser       func(data [][]string) T
+	Schema       databind.Schema
+	Parser       func(data [][]string) (T, error)
 }
 
 func GetDatabindFromSheetTab[T any](ctx context.Context, params GetDatabindFromSheetTabParams[T]) (T, error) {
-	debug.NewMessage(fmt.Sprintf("Start parse \"%s\" file from sheet.", params.ReportName))
+	debug.NewMessageContext(ctx, fmt.Sprintf("Start parse \"%s\" file from sheet.", params.ReportName))
 
 	var emptyReturn T
 
@@ -71,13 +73,30 @@ func GetDatabindFromSheetTab[T any](ctx context.Context, params GetDatabindFromS
 		errMessage := fmt.Sprintf("Error while parsing %s: No %s found.", params.ReportName, strings.Replace(params.ReportName, " Report", "", -1))
 		err := errors.New(errMessage)
 
-		debug.NewMessage(fmt.Sprintf("End parse \"%s\" file from sheet. %v", params.ReportName, errMessage))
+		debug.NewMessageContext(ctx, fmt.Sprintf("End parse \"%s\" file from sheet. %v", params.ReportName, errMessage))
 		return emptyReturn, err
 	}
 
-	newData := params.Parser(table)
+	if params.Schema != nil && params.HeaderRow > 0 {
+		table, err = params.Schema.Bind(values[params.HeaderRow-1], table)
+		if err != nil {
+			errMessage := fmt.Sprintf("Error while parsing %s: %v", params.ReportName, err)
+			debug.NewMessageContext(ctx, fmt.Sprintf("End parse \"%s\" file from sheet. %v", params.ReportName, errMessage))
+			return emptyReturn, errors.New(errMessage)
+		}
+	}
+
+	newData, err := params.Parser(table)
+	var parseErrs *databind.ParseErrors
+	if errors.As(err, &parseErrs) {
+		parseErrs.Locate(params.ReportName, params.HeaderRow)
+	}
+	if err != nil {
+		debug.NewMessageContext(ctx, fmt.Sprintf("End parse \"%s\" file from sheet. %v", params.ReportName, err))
+		return emptyReturn, err
+	}
 
-	debug.NewMessage(fmt.Sprintf("End parse \"%s\" file.", params.ReportName))
+	debug.NewMessageContext(ctx, fmt.Sprintf("End parse \"%s\" file.", params.ReportName))
 	return newData, nil
 }
 
@@ -153,7 +153,7 @@ func TestReadGoogleSheet_GetDatabindFromTab(t *testing.T) {
 		databind, err := googlesheetsutils.GetDatabindFromSheetTab[*rewards.Rewards](ctx, params)
 
 		// Get the Rewards struct for the first line and to be compared
-		expected := parser(fakeDataResponse[headerRows:])
+		expected, _ := parser(fakeDataResponse[headerRows:])
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, databind)
@@ -176,7 +176,7 @@ func TestReadGoogleSheet_GetDatabindFromTab(t *testing.T) {
 		databind, err := googlesheetsutils.GetDatabindFromSheetTab[*ubalances.UnclaimedBalances](ctx, params)
 
 		// Get the Unclaimed Balances struct for the first line and compared
-		expected := parser(fakeDataResponse[headerRows:])
+		expected, _ := parser(fakeDataResponse[headerRows:])
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, databind)
@@ -199,7 +199,7 @@ func TestReadGoogleSheet_GetDatabindFromTab(t *testing.T) {
 		databind, err := googlesheetsutils.GetDatabindFromSheetTab[*operationsstatuses.OperationsStatuses](ctx, params)
 
 		// Get the Operations Statuses struct for the first line and compared
-		expected := parser(fakeDataResponse[headerRows:])
+		expected, _ := parser(fakeDataResponse[headerRows:])
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, databind)
@@ -222,7 +222,7 @@ func TestReadGoogleSheet_GetDatabindFromTab(t *testing.T) {
 		databind, err := googlesheetsutils.GetDatabindFromSheetTab[*balanceadjustments.BalanceAdjustments](ctx, params)
 
 		// Get the Balance Adjustments struct for the first line and compared
-		expected := parser(fakeDataResponse[headerRows:])
+		expected, _ := parser(fakeDataResponse[headerRows:])
 
 		assert.Nil(t, err)
 		assert.Equal(t, expected, databind)
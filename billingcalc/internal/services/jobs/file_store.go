@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore keeps one JSON file per job in a directory, so local runs can be
+// inspected after the server stops.
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to create the jobs directory %s: %v", dir, err))
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Save(_ context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to encode job %s: %v", job.Id, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Write to a temporary file first so a reader never sees a partial job.
+	tmp := s.path(job.Id) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write job %s: %v", job.Id, err))
+	}
+	if err := os.Rename(tmp, s.path(job.Id)); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write job %s: %v", job.Id, err))
+	}
+	return nil
+}
+
+func (s *FileStore) Get(_ context.Context, id string) (*Job, error) {
+	if filepath.Base(id) != id {
+		return nil, ErrJobNotFound
+	}
+
+	s.mu.Lock()
+	data, err := os.ReadFile(s.path(id))
+	s.mu.Unlock()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to read job %s: %v", id, err))
+	}
+
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to decode job %s: %v", id, err))
+	}
+	return job, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
@@ -0,0 +1,49 @@
+//go:build !selectTest || unitTest
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+)
+
+func TestFileStore(t *testing.T) {
+	store, err := jobs.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := &jobs.Job{
+		Id:        "job1",
+		Status:    jobs.StatusSucceeded,
+		Result:    &fees.CalculatedFees{Summary: fees.StakingSummary{{OrgName: "Org1"}}},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("Should read back a saved job", func(t *testing.T) {
+		err := store.Save(context.Background(), job)
+		assert.NoError(t, err)
+
+		got, err := store.Get(context.Background(), "job1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, job.Status, got.Status)
+		assert.Equal(t, "Org1", got.Result.Summary[0].OrgName)
+		assert.True(t, job.CreatedAt.Equal(got.CreatedAt))
+	})
+
+	t.Run("Should not find unknown jobs", func(t *testing.T) {
+		_, err := store.Get(context.Background(), "unknown")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+
+		_, err = store.Get(context.Background(), "../job1")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+	})
+}
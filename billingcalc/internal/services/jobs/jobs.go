@@ -0,0 +1,46 @@
+// package jobs runs fee calculations in the background, so callers can poll
+// for their progress instead of waiting on a single long HTTP request.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+var ErrJobNotFound = errors.New("Job not found.")
+
+type Job struct {
+	Id        string               `json:"id"`
+	Status    Status               `json:"status"`
+	Progress  progress.Progress    `json:"progress"`
+	Result    *fees.CalculatedFees `json:"result,omitempty"`
+	Err       string               `json:"err,omitempty"`
+	Debug     json.RawMessage      `json:"debug,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+func (j *Job) IsFinished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed || j.Status == StatusCancelled
+}
+
+// Store keeps the jobs state. Get returns ErrJobNotFound for unknown IDs.
+type Store interface {
+	Save(ctx context.Context, job *Job) error
+	Get(ctx context.Context, id string) (*Job, error)
+}
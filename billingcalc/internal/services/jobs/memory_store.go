@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultJobTTL is how long the memory store keeps a finished job.
+	DefaultJobTTL = 24 * time.Hour
+	// DefaultMaxJobs is how many finished jobs the memory store keeps.
+	DefaultMaxJobs = 100
+)
+
+// MemoryStore keeps the jobs in the server memory, they are lost on restart.
+// A finished job is evicted once it wasn't updated for the TTL, or when more
+// than maxJobs jobs are finished, the oldest first. Pending and running jobs
+// are never evicted.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	jobs    map[string]Job
+	ttl     time.Duration
+	maxJobs int
+}
+
+func NewMemoryStore(ttl time.Duration, maxJobs int) *MemoryStore {
+	return &MemoryStore{
+		jobs:    make(map[string]Job),
+		ttl:     ttl,
+		maxJobs: maxJobs,
+	}
+}
+
+func (s *MemoryStore) Save(_ context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.Id] = *job
+	s.evict()
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok || s.expired(&job) {
+		return nil, ErrJobNotFound
+	}
+	return &job, nil
+}
+
+// evict drops the expired jobs, then the oldest finished jobs over maxJobs.
+func (s *MemoryStore) evict() {
+	finished := make([]Job, 0, len(s.jobs))
+	for id, job := range s.jobs {
+		if s.expired(&job) {
+			delete(s.jobs, id)
+			continue
+		}
+		if job.IsFinished() {
+			finished = append(finished, job)
+		}
+	}
+
+	if len(finished) <= s.maxJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].UpdatedAt.Before(finished[j].UpdatedAt)
+	})
+	for _, job := range finished[:len(finished)-s.maxJobs] {
+		delete(s.jobs, job.Id)
+	}
+}
+
+func (s *MemoryStore) expired(job *Job) bool {
+	return job.IsFinished() && time.Since(job.UpdatedAt) > s.ttl //nolint:forbidigo
+}
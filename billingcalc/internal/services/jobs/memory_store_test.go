@@ -0,0 +1,53 @@
+//go:build !selectTest || unitTest
+
+package jobs_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Run("Should evict finished jobs older than the TTL", func(t *testing.T) {
+		store := jobs.NewMemoryStore(time.Hour, 10)
+		old := time.Now().Add(-2 * time.Hour)
+
+		_ = store.Save(context.Background(), &jobs.Job{Id: "finished", Status: jobs.StatusSucceeded, UpdatedAt: old})
+		_ = store.Save(context.Background(), &jobs.Job{Id: "running", Status: jobs.StatusRunning, UpdatedAt: old})
+		_ = store.Save(context.Background(), &jobs.Job{Id: "recent", Status: jobs.StatusFailed, UpdatedAt: time.Now()})
+
+		_, err := store.Get(context.Background(), "finished")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+		_, err = store.Get(context.Background(), "running")
+		assert.NoError(t, err)
+		_, err = store.Get(context.Background(), "recent")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should evict the oldest finished jobs over the maximum", func(t *testing.T) {
+		store := jobs.NewMemoryStore(time.Hour, 2)
+		start := time.Now().Add(-time.Minute)
+
+		_ = store.Save(context.Background(), &jobs.Job{Id: "pending", Status: jobs.StatusPending, UpdatedAt: start})
+		for i := 0; i < 3; i++ {
+			_ = store.Save(context.Background(), &jobs.Job{
+				Id:        fmt.Sprintf("job%d", i),
+				Status:    jobs.StatusSucceeded,
+				UpdatedAt: start.Add(time.Duration(i) * time.Second),
+			})
+		}
+
+		_, err := store.Get(context.Background(), "job0")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+		for _, id := range []string{"pending", "job1", "job2"} {
+			_, err := store.Get(context.Background(), id)
+			assert.NoError(t, err, id)
+		}
+	})
+}
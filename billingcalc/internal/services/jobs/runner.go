@@ -0,0 +1,209 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
+)
+
+// Calculation is the work run by a job, e.g. fees.CalculateFromCsv with its
+// parameters already bound.
+type Calculation func(ctx context.Context) (*fees.CalculatedFees, error)
+
+// Runner starts the jobs and keeps the cancel function of the ones running in
+// this process.
+type Runner struct {
+	store Store
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	locks   map[string]*jobLock
+}
+
+// jobLock serializes the updates of one job, users counts the updates holding
+// or waiting for it.
+type jobLock struct {
+	sync.Mutex
+	users int
+}
+
+func NewRunner(store Store) *Runner {
+	return &Runner{
+		store:   store,
+		cancels: make(map[string]context.CancelFunc),
+		locks:   make(map[string]*jobLock),
+	}
+}
+
+// Start saves a pending job and runs the calculation in the background. The
+// job does not depend on the request that started it, only the debug trace is
+// carried over.
+func (r *Runner) Start(trace *debug.Trace, calculation Calculation) (*Job, error) {
+	id, err := newJobId()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now() //nolint:forbidigo
+	job := &Job{
+		Id:        id,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := r.store.Save(context.Background(), job); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = debug.NewContext(ctx, trace)
+	ctx = progress.NewContext(ctx, func(update progress.Progress) {
+		r.update(id, func(job *Job) {
+			job.Progress = update
+		})
+	})
+
+	r.mu.Lock()
+	r.cancels[id] = cancel
+	r.mu.Unlock()
+
+	go r.run(ctx, id, trace, calculation)
+
+	return job, nil
+}
+
+func (r *Runner) Get(ctx context.Context, id string) (*Job, error) {
+	return r.store.Get(ctx, id)
+}
+
+// Cancel stops a running job through its context. A job that is not running
+// in this process anymore, e.g. after a restart, is only marked as cancelled.
+func (r *Runner) Cancel(ctx context.Context, id string) (*Job, error) {
+	job, err := r.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.IsFinished() {
+		return job, errors.New(fmt.Sprintf("Job %s is already %s.", id, job.Status))
+	}
+
+	r.mu.Lock()
+	cancel, running := r.cancels[id]
+	r.mu.Unlock()
+	if running {
+		cancel()
+	}
+
+	return r.update(id, func(job *Job) {
+		if !job.IsFinished() {
+			job.Status = StatusCancelled
+		}
+	})
+}
+
+func (r *Runner) run(ctx context.Context, id string, trace *debug.Trace, calculation Calculation) {
+	defer func() {
+		r.mu.Lock()
+		if cancel, ok := r.cancels[id]; ok {
+			cancel()
+			delete(r.cancels, id)
+		}
+		r.mu.Unlock()
+	}()
+
+	r.update(id, func(job *Job) {
+		job.Status = StatusRunning
+	})
+
+	result, err := r.calculate(ctx, calculation)
+	debugJson, _ := trace.GetJson()
+
+	r.update(id, func(job *Job) {
+		job.Debug = debugJson
+		switch {
+		case job.Status == StatusCancelled || ctx.Err() != nil:
+			job.Status = StatusCancelled
+		case err != nil:
+			job.Status = StatusFailed
+			job.Err = err.Error()
+		default:
+			job.Status = StatusSucceeded
+			job.Result = result
+		}
+	})
+}
+
+// calculate keeps a panic in the calculation from taking the server down, as
+// there is no HTTP handler above the job to recover it.
+func (r *Runner) calculate(ctx context.Context, calculation Calculation) (result *fees.CalculatedFees, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New(fmt.Sprintf("Calculation failed: %v", recovered))
+		}
+	}()
+
+	return calculation(ctx)
+}
+
+// update changes the stored job. The updates of a job are applied one at a
+// time, the ones of other jobs don't wait for its store reads and writes.
+func (r *Runner) update(id string, change func(job *Job)) (*Job, error) {
+	unlock := r.lockJob(id)
+	defer unlock()
+
+	job, err := r.store.Get(context.Background(), id)
+	if err != nil {
+		debug.NewMessage(fmt.Sprintf("Failed to update job %s: %v", id, err))
+		return nil, err
+	}
+
+	change(job)
+	job.UpdatedAt = time.Now() //nolint:forbidigo
+
+	if err := r.store.Save(context.Background(), job); err != nil {
+		debug.NewMessage(fmt.Sprintf("Failed to update job %s: %v", id, err))
+		return nil, err
+	}
+	return job, nil
+}
+
+// lockJob locks the job id and returns the function unlocking it. The lock is
+// forgotten once no update uses it.
+func (r *Runner) lockJob(id string) func() {
+	r.mu.Lock()
+	lock, ok := r.locks[id]
+	if !ok {
+		lock = &jobLock{}
+		r.locks[id] = lock
+	}
+	lock.users++
+	r.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		r.mu.Lock()
+		lock.users--
+		if lock.users == 0 {
+			delete(r.locks, id)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func newJobId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.New(fmt.Sprintf("Failed to generate a job ID: %v", err))
+	}
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,155 @@
+//go:build !selectTest || unitTest
+
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/fees"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/jobs"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/debug"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/progress"
+)
+
+func waitFinished(t *testing.T, runner *jobs.Runner, id string) *jobs.Job {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := runner.Get(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.IsFinished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("Job %s did not finish in time", id)
+	return nil
+}
+
+// slowStore holds the saves of the jobs working on the "Slow" organization
+// until release is closed, telling saving when the first one is held.
+type slowStore struct {
+	jobs.Store
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s slowStore) Save(ctx context.Context, job *jobs.Job) error {
+	if job.Progress.Organization == "Slow" {
+		select {
+		case s.saving <- struct{}{}:
+		default:
+		}
+		<-s.release
+	}
+	return s.Store.Save(ctx, job)
+}
+
+func TestRunner(t *testing.T) {
+	t.Run("Should store the result of a succeeded job", func(t *testing.T) {
+		runner := jobs.NewRunner(jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs))
+		expected := &fees.CalculatedFees{
+			Summary: fees.StakingSummary{{OrgName: "Org1"}},
+			Warns:   []fees.Warning{{OrgName: "Org1", Description: "Warning"}},
+		}
+
+		job, err := runner.Start(debug.NewTrace(true), func(ctx context.Context) (*fees.CalculatedFees, error) {
+			progress.ProcessingOrganization(ctx, progress.StageStaking, "Org1")
+			debug.NewMessageContext(ctx, "Inside the job")
+			return expected, nil
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, job.Id)
+
+		job = waitFinished(t, runner, job.Id)
+
+		assert.Equal(t, jobs.StatusSucceeded, job.Status)
+		assert.Equal(t, expected, job.Result)
+		assert.Equal(t, "Org1", job.Progress.Organization)
+		assert.Contains(t, string(job.Debug), "Inside the job")
+	})
+
+	t.Run("Should store the error of a failed job", func(t *testing.T) {
+		runner := jobs.NewRunner(jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs))
+
+		job, err := runner.Start(debug.NewTrace(false), func(ctx context.Context) (*fees.CalculatedFees, error) {
+			return nil, errors.New("Failed to load the MFR")
+		})
+		assert.NoError(t, err)
+
+		job = waitFinished(t, runner, job.Id)
+
+		assert.Equal(t, jobs.StatusFailed, job.Status)
+		assert.Equal(t, "Failed to load the MFR", job.Err)
+		assert.Nil(t, job.Result)
+	})
+
+	t.Run("Should cancel a running job through its context", func(t *testing.T) {
+		runner := jobs.NewRunner(jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs))
+		started := make(chan struct{})
+
+		job, err := runner.Start(debug.NewTrace(false), func(ctx context.Context) (*fees.CalculatedFees, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		assert.NoError(t, err)
+		<-started
+
+		_, err = runner.Cancel(context.Background(), job.Id)
+		assert.NoError(t, err)
+
+		job = waitFinished(t, runner, job.Id)
+		assert.Equal(t, jobs.StatusCancelled, job.Status)
+
+		_, err = runner.Cancel(context.Background(), job.Id)
+		assert.Error(t, err, "A finished job can't be cancelled")
+	})
+
+	t.Run("Should update a job while the store is saving another one", func(t *testing.T) {
+		store := slowStore{Store: jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs), saving: make(chan struct{}, 1), release: make(chan struct{})}
+		runner := jobs.NewRunner(store)
+		var released sync.Once
+		release := func() { released.Do(func() { close(store.release) }) }
+		defer release()
+
+		finish := make(chan struct{})
+		fast, err := runner.Start(debug.NewTrace(false), func(ctx context.Context) (*fees.CalculatedFees, error) {
+			<-finish
+			return &fees.CalculatedFees{}, nil
+		})
+		assert.NoError(t, err)
+
+		slow, err := runner.Start(debug.NewTrace(false), func(ctx context.Context) (*fees.CalculatedFees, error) {
+			progress.ProcessingOrganization(ctx, progress.StageStaking, "Slow")
+			return &fees.CalculatedFees{}, nil
+		})
+		assert.NoError(t, err)
+		<-store.saving
+		close(finish)
+
+		assert.Equal(t, jobs.StatusSucceeded, waitFinished(t, runner, fast.Id).Status)
+		release()
+		assert.Equal(t, jobs.StatusSucceeded, waitFinished(t, runner, slow.Id).Status)
+	})
+
+	t.Run("Should fail for unknown jobs", func(t *testing.T) {
+		runner := jobs.NewRunner(jobs.NewMemoryStore(jobs.DefaultJobTTL, jobs.DefaultMaxJobs))
+
+		_, err := runner.Get(context.Background(), "unknown")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+
+		_, err = runner.Cancel(context.Background(), "unknown")
+		assert.ErrorIs(t, err, jobs.ErrJobNotFound)
+	})
+}
@@ -0,0 +1,170 @@
+// package mfrlint checks a Master Fee Rates sheet for values that would make
+// the billing wrong, without calculating anything.
+package mfrlint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/assettypes"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
+)
+
+// Checks
+const (
+	CheckEntityID       = "entity_id"
+	CheckTierFloors     = "tier_floors"
+	CheckTierRates      = "tier_rates"
+	CheckMinimumFeeType = "minimum_fee_type"
+	CheckAssetID        = "asset_id"
+	CheckDuplicateAcc   = "duplicate_account"
+	CheckBillingTerms   = "billing_terms"
+	CheckTermination    = "termination_date"
+)
+
+var maxRate = decimal.NewFromInt(100)
+
+// Issue is a problem found in the MFR. AccountID and AssetID are empty when
+// the issue is about the whole organization.
+type Issue struct {
+	Check     string `json:"check"`
+	MsaID     string `json:"msaId"`
+	OrgName   string `json:"orgName"`
+	AccountID string `json:"accountId,omitempty"`
+	AssetID   string `json:"assetId,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (i Issue) String() string {
+	location := "MSA " + i.MsaID
+	if i.AccountID != "" {
+		location += ", account " + i.AccountID
+	}
+	if i.AssetID != "" {
+		location += ", asset ID " + i.AssetID
+	}
+	return fmt.Sprintf("[%s] %s (%s): %s", i.Check, location, i.OrgName, i.Message)
+}
+
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+func (r *Report) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Lint runs every check over the MFR, entity IDs are checked against
+// registry. Issues are sorted by MSA, account and asset ID so the report is
+// the same for the same sheet.
+func Lint(m *mfr.MasterFeeRates, registry *entities.Registry) (*Report, error) {
+	assetTypes, err := assettypes.NewAssetTypeList()
+	if err != nil {
+		return nil, errors.New(err.Error())
+	}
+
+	report := &Report{Issues: []Issue{}}
+	msaIdsByAccount := make(map[databind.AccountID][]string)
+
+	for _, org := range m.GetSortedOrganizations() {
+		orgIssue := Issue{MsaID: string(org.Id), OrgName: org.DisplayName}
+		report.checkEntityID(orgIssue, org.EntityId, registry)
+
+		for _, acc := range m.GetSortedAccounts(org.Id) {
+			msaIdsByAccount[acc.Id] = append(msaIdsByAccount[acc.Id], string(org.Id))
+
+			accIssue := orgIssue
+			accIssue.AccountID = string(acc.Id)
+			if acc.BillingTerms == "" || acc.BillingTerms == "0" {
+				report.add(accIssue, CheckBillingTerms, "Billing Terms is empty or invalid")
+			}
+
+			for _, assetType := range m.GetSortedAssetTypes(org.Id, acc.Id) {
+				assetIssue := accIssue
+				assetIssue.AssetID = fmt.Sprint(assetType.Id)
+				if !assetTypes.HasAssetId(int(assetType.Id)) {
+					report.add(assetIssue, CheckAssetID, "Asset ID not found in asset_types.json")
+				}
+				// An empty Minimum Fee Type is an asset without a minimum fee.
+				minimumFee := assetType.MinimumFee
+				if minimumFee.MinimumFeeType != "" && !minimumFee.IsAucBased() && !minimumFee.IsGreaterOf() {
+					report.add(assetIssue, CheckMinimumFeeType, fmt.Sprintf("Unknown Minimum Fee Type %q, expected AUC based or Greater of", minimumFee.MinimumFeeType))
+				}
+				report.checkTiers(assetIssue, tiers.ParseMode(assetType.GraduatedTier), assetType.TierData)
+			}
+		}
+	}
+
+	report.checkDuplicateAccounts(m, msaIdsByAccount)
+	report.checkSkippedAccounts(m)
+
+	return report, nil
+}
+
+func (r *Report) add(issue Issue, check, message string) {
+	issue.Check = check
+	issue.Message = message
+	r.Issues = append(r.Issues, issue)
+}
+
+func (r *Report) checkEntityID(issue Issue, entityID string, registry *entities.Registry) {
+	if strings.TrimSpace(entityID) == "" {
+		r.add(issue, CheckEntityID, "Anchorage Entity ID is missing")
+		return
+	}
+	if _, err := registry.Get(entityID); err != nil {
+		r.add(issue, CheckEntityID, err.Error())
+	}
+}
+
+// checkTiers ignores the unused tiers, the ones with floor and rate at 0, as
+// the calculation does. Band widths don't have to increase.
+func (r *Report) checkTiers(issue Issue, mode tiers.Mode, tierData []mfr.TierData) {
+	var previous *mfr.TierData
+	for i := range tierData {
+		tier := tierData[i]
+		if tier.Floor.IsZero() && tier.Rate.IsZero() {
+			continue
+		}
+		if tier.Rate.IsNegative() || tier.Rate.GreaterThan(maxRate) {
+			r.add(issue, CheckTierRates, fmt.Sprintf("Tier %d rate %s%% is not between 0%% and 100%%", i+1, tier.Rate))
+		}
+		if mode != tiers.BandWidth && previous != nil && !tier.Floor.GreaterThan(previous.Floor) {
+			r.add(issue, CheckTierFloors, fmt.Sprintf("Tier %d floor %s is not greater than the previous floor %s", i+1, tier.Floor, previous.Floor))
+		}
+		previous = &tierData[i]
+	}
+}
+
+func (r *Report) checkDuplicateAccounts(m *mfr.MasterFeeRates, msaIdsByAccount map[databind.AccountID][]string) {
+	accountIds := make([]string, 0, len(msaIdsByAccount))
+	for accountId, msaIds := range msaIdsByAccount {
+		if len(msaIds) > 1 {
+			accountIds = append(accountIds, string(accountId))
+		}
+	}
+	sort.Strings(accountIds)
+
+	for _, accountId := range accountIds {
+		msaIds := msaIdsByAccount[databind.AccountID(accountId)]
+		org := m.GetOrganizations()[mfr.MSAID(msaIds[0])]
+		issue := Issue{MsaID: msaIds[0], OrgName: org.DisplayName, AccountID: accountId}
+		r.add(issue, CheckDuplicateAcc, fmt.Sprintf("RDB Account ID is used by MSAs %s", strings.Join(msaIds, ", ")))
+	}
+}
+
+// checkSkippedAccounts reports the rows the MFR parser left out of the
+// billing, like a terminated account without a Termination Date.
+func (r *Report) checkSkippedAccounts(m *mfr.MasterFeeRates) {
+	for _, skipped := range m.GetSkippedAccounts() {
+		issue := Issue{MsaID: string(skipped.MsaID), OrgName: skipped.OrgName, AccountID: string(skipped.AccountID)}
+		r.add(issue, CheckTermination, fmt.Sprintf("Row %d: %s", skipped.Line, skipped.Reason))
+	}
+}
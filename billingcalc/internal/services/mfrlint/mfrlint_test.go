@@ -0,0 +1,130 @@
+//go:build !selectTest || unitTest
+
+package mfrlint_test
+
+import (
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/databind/mfr"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/entities"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/mfrlint"
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+const (
+	colMinimumFeeType  = 3
+	colEntityID        = 7
+	colBillingTerms    = 11
+	col1stTierRate     = 31
+	col2ndTierFloor    = 32
+	colAssetID         = 87
+	colGraduatedTier   = 89
+	colRDBAccountID    = 92
+	colTerminationDate = 93
+
+	alphaAccount = "2d0d35f608815f0a406d9b44d4b3af141b6c2258937028dc8a0b003616afdf22"
+)
+
+func readMfrCsv(t *testing.T) [][]string {
+	f, err := static.Files.Open("gsheet/mfr_test_calc.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+// validMfrCsv is the sample MFR with a Termination Date on its terminated
+// account.
+func validMfrCsv(t *testing.T) [][]string {
+	rows := readMfrCsv(t)
+	rows[7][colTerminationDate] = "3/15/2023"
+	return rows
+}
+
+func lint(t *testing.T, rows [][]string) *mfrlint.Report {
+	m, err := mfr.ParseMfrCsv(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := entities.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := mfrlint.Lint(m, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestLintValidMfr(t *testing.T) {
+	report := lint(t, validMfrCsv(t))
+
+	assert.True(t, report.Valid(), "unexpected issues: %v", report.Issues)
+}
+
+func TestLintWithoutMinimumFee(t *testing.T) {
+	rows := validMfrCsv(t)
+	for _, row := range rows[3:] {
+		row[colMinimumFeeType] = ""
+	}
+
+	report := lint(t, rows)
+
+	assert.True(t, report.Valid(), "unexpected issues: %v", report.Issues)
+}
+
+func TestLint(t *testing.T) {
+	rows := readMfrCsv(t)
+	// MSA 11111
+	rows[3][colEntityID] = "99"
+	rows[3][colMinimumFeeType] = "Flat"
+	rows[3][colGraduatedTier] = "Flat"
+	rows[3][col2ndTierFloor] = "$1,000"
+	// MSA 22222
+	rows[4][col1stTierRate] = "150%"
+	rows[5][colAssetID] = "999"
+	rows[6][colRDBAccountID] = alphaAccount
+	rows[6][colBillingTerms] = ""
+
+	report := lint(t, rows)
+
+	checks := []string{}
+	for _, issue := range report.Issues {
+		checks = append(checks, issue.Check)
+	}
+	assert.Equal(t, []string{
+		mfrlint.CheckEntityID,
+		mfrlint.CheckMinimumFeeType,
+		mfrlint.CheckTierFloors,
+		mfrlint.CheckBillingTerms,
+		mfrlint.CheckTierRates,
+		mfrlint.CheckAssetID,
+		mfrlint.CheckDuplicateAcc,
+		mfrlint.CheckTermination,
+	}, checks)
+
+	assert.Equal(t, mfrlint.Issue{
+		Check:     mfrlint.CheckAssetID,
+		MsaID:     "22222",
+		OrgName:   "Org Test Beta",
+		AccountID: "accountIdFor2222",
+		AssetID:   "999",
+		Message:   "Asset ID not found in asset_types.json",
+	}, report.Issues[5])
+	assert.Equal(t, "RDB Account ID is used by MSAs 11111, 22222", report.Issues[6].Message)
+	assert.Equal(t, mfrlint.Issue{
+		Check:     mfrlint.CheckTermination,
+		MsaID:     "22222",
+		OrgName:   "Org Test Beta",
+		AccountID: "Account_ID_from_RDB_Beta_Acc",
+		Message:   "Row 8: Terminated account without a Termination Date, its last month is not billed",
+	}, report.Issues[7])
+}
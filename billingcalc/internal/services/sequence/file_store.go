@@ -0,0 +1,125 @@
+package sequence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FileStore keeps one JSON file per sequence in a directory, for local runs.
+// The version check is only safe within one server process.
+type FileStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+type fileLedger struct {
+	Version int64   `json:"version"`
+	Ledger  *Ledger `json:"ledger"`
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to create the sequences directory %s: %v", dir, err))
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Load(_ context.Context, sequence string) (*Ledger, int64, error) {
+	if err := validName(sequence); err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.read(sequence)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stored.Ledger, stored.Version, nil
+}
+
+func (s *FileStore) Save(_ context.Context, ledger *Ledger, version int64) error {
+	if err := validName(ledger.Sequence); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := s.read(ledger.Sequence)
+	if err != nil {
+		return err
+	}
+	if stored.Version != version {
+		return ErrConflict
+	}
+
+	data, err := json.MarshalIndent(fileLedger{Version: version + 1, Ledger: ledger}, "", "  ")
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to encode sequence %s: %v", ledger.Sequence, err))
+	}
+
+	// Write to a temporary file first so a reader never sees a partial ledger.
+	tmp := s.path(ledger.Sequence) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write sequence %s: %v", ledger.Sequence, err))
+	}
+	if err := os.Rename(tmp, s.path(ledger.Sequence)); err != nil {
+		return errors.New(fmt.Sprintf("Failed to write sequence %s: %v", ledger.Sequence, err))
+	}
+	return nil
+}
+
+func (s *FileStore) List(_ context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to list the sequences: %v", err))
+	}
+
+	sequences := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			sequences = append(sequences, name)
+		}
+	}
+	sort.Strings(sequences)
+	return sequences, nil
+}
+
+func (s *FileStore) read(sequence string) (*fileLedger, error) {
+	data, err := os.ReadFile(s.path(sequence))
+	if errors.Is(err, os.ErrNotExist) {
+		return &fileLedger{Ledger: newLedger(sequence)}, nil
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to read sequence %s: %v", sequence, err))
+	}
+
+	stored := &fileLedger{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, errors.New(fmt.Sprintf("Failed to decode sequence %s: %v", sequence, err))
+	}
+	return stored, nil
+}
+
+func (s *FileStore) path(sequence string) string {
+	return filepath.Join(s.dir, sequence+".json")
+}
+
+func validName(sequence string) error {
+	if sequence == "" || filepath.Base(sequence) != sequence || strings.HasPrefix(sequence, ".") {
+		return errors.New(fmt.Sprintf("Invalid sequence name %q", sequence))
+	}
+	return nil
+}
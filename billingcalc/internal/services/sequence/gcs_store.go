@@ -0,0 +1,109 @@
+package sequence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// GCSStore keeps one JSON object per sequence in a bucket. The object
+// generation is the ledger version, writes are conditioned on it so Cloud
+// Run instances never overwrite each other.
+type GCSStore struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+func NewGCSStore(client *storage.Client, bucket, prefix string) *GCSStore {
+	return &GCSStore{bucket: client.Bucket(bucket), prefix: prefix}
+}
+
+func (s *GCSStore) Load(ctx context.Context, sequence string) (*Ledger, int64, error) {
+	if err := validName(sequence); err != nil {
+		return nil, 0, err
+	}
+
+	rc, err := s.object(sequence).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return newLedger(sequence), 0, nil
+	}
+	if err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to read sequence %s: %v", sequence, err))
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to read sequence %s: %v", sequence, err))
+	}
+
+	ledger := &Ledger{}
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, 0, errors.New(fmt.Sprintf("Failed to decode sequence %s: %v", sequence, err))
+	}
+	return ledger, rc.Attrs.Generation, nil
+}
+
+func (s *GCSStore) Save(ctx context.Context, ledger *Ledger, version int64) error {
+	if err := validName(ledger.Sequence); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return errors.New(fmt.Sprintf("Failed to encode sequence %s: %v", ledger.Sequence, err))
+	}
+
+	conditions := storage.Conditions{GenerationMatch: version}
+	if version == 0 {
+		conditions = storage.Conditions{DoesNotExist: true}
+	}
+
+	wc := s.object(ledger.Sequence).If(conditions).NewWriter(ctx)
+	wc.ContentType = "application/json"
+	if _, err := wc.Write(data); err != nil {
+		wc.Close()
+		return errors.New(fmt.Sprintf("Failed to write sequence %s: %v", ledger.Sequence, err))
+	}
+	if err := wc.Close(); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			return ErrConflict
+		}
+		return errors.New(fmt.Sprintf("Failed to write sequence %s: %v", ledger.Sequence, err))
+	}
+	return nil
+}
+
+func (s *GCSStore) List(ctx context.Context) ([]string, error) {
+	sequences := []string{}
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: s.prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Failed to list the sequences: %v", err))
+		}
+		name, ok := strings.CutSuffix(strings.TrimPrefix(attrs.Name, s.prefix), ".json")
+		if ok && validName(name) == nil {
+			sequences = append(sequences, name)
+		}
+	}
+	sort.Strings(sequences)
+	return sequences, nil
+}
+
+func (s *GCSStore) object(sequence string) *storage.ObjectHandle {
+	return s.bucket.Object(s.prefix + sequence + ".json")
+}
@@ -0,0 +1,220 @@
+// package sequence hands out invoice numbers and external IDs that are never
+// given twice, whoever runs the calculation and however many times. Each
+// sequence keeps a ledger of the ranges reserved by each run, so the numbers
+// of a discarded draft can be released.
+package sequence
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExternalIDSequence is shared by every entity, NetSuite external IDs must be
+// unique across entities. Invoice numbers use one sequence per entity acronym.
+const ExternalIDSequence = "external-id"
+
+type Status string
+
+const (
+	StatusReserved Status = "reserved"
+	StatusReleased Status = "released"
+)
+
+// ErrConflict is returned by Store.Save when the ledger changed since it was
+// loaded.
+var ErrConflict = errors.New("Sequence ledger changed concurrently.")
+
+// maxAttempts bounds the retries of an update losing the race against other
+// runs.
+const maxAttempts = 10
+
+// Reservation is a range of Count numbers from First, reserved by a run.
+type Reservation struct {
+	RunID      string     `json:"runId"`
+	First      int        `json:"first"`
+	Count      int        `json:"count"`
+	Status     Status     `json:"status"`
+	ReservedAt time.Time  `json:"reservedAt"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+}
+
+// Ledger is the state of a sequence. Next is the first number never reserved.
+type Ledger struct {
+	Sequence     string        `json:"sequence"`
+	Next         int           `json:"next"`
+	Reservations []Reservation `json:"reservations"`
+}
+
+func newLedger(sequence string) *Ledger {
+	return &Ledger{Sequence: sequence, Next: 1, Reservations: []Reservation{}}
+}
+
+// Store keeps the ledgers. Save must only succeed when the stored version is
+// still the one returned by Load, so concurrent runs never reserve the same
+// numbers.
+type Store interface {
+	// Load returns the ledger of sequence and its version. A sequence never
+	// saved is a new ledger at version 0.
+	Load(ctx context.Context, sequence string) (*Ledger, int64, error)
+	// Save replaces the ledger stored at version, or returns ErrConflict.
+	Save(ctx context.Context, ledger *Ledger, version int64) error
+	// List returns the names of the saved sequences.
+	List(ctx context.Context) ([]string, error)
+}
+
+type Allocator struct {
+	store Store
+}
+
+func NewAllocator(store Store) *Allocator {
+	return &Allocator{store: store}
+}
+
+// errUnchanged stops an update without saving the ledger.
+var errUnchanged = errors.New("unchanged")
+
+// Reserve reserves count numbers of sequence for runID and returns the first
+// one. A run calculated again gets back the numbers it already holds, as long
+// as it doesn't need more of them.
+func (a *Allocator) Reserve(ctx context.Context, sequence, runID string, count int) (int, error) {
+	if runID == "" {
+		return 0, errors.New("A run ID is required to reserve numbers.")
+	}
+	if count <= 0 {
+		return 0, nil
+	}
+
+	first := 0
+	_, err := a.update(ctx, sequence, func(ledger *Ledger) error {
+		for _, r := range ledger.Reservations {
+			if r.RunID != runID || r.Status != StatusReserved {
+				continue
+			}
+			if r.Count < count {
+				return errors.New(fmt.Sprintf("Run %s already reserved %d numbers of %s and now needs %d, release it first", runID, r.Count, sequence, count))
+			}
+			first = r.First
+			return errUnchanged
+		}
+
+		first = ledger.Next
+		ledger.Next += count
+		ledger.Reservations = append(ledger.Reservations, Reservation{
+			RunID:      runID,
+			First:      first,
+			Count:      count,
+			Status:     StatusReserved,
+			ReservedAt: time.Now().UTC(),
+		})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return first, nil
+}
+
+// Release releases the numbers reserved by runID in every sequence. The
+// numbers at the end of a sequence are given again to the next run, the ones
+// followed by other reservations stay unused so no number is given twice.
+func (a *Allocator) Release(ctx context.Context, runID string) ([]Reservation, error) {
+	sequences, err := a.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	released := []Reservation{}
+	for _, sequence := range sequences {
+		_, err := a.update(ctx, sequence, func(ledger *Ledger) error {
+			now := time.Now().UTC()
+			changed := false
+			for i := range ledger.Reservations {
+				r := &ledger.Reservations[i]
+				if r.RunID == runID && r.Status == StatusReserved {
+					r.Status = StatusReleased
+					r.ReleasedAt = &now
+					released = append(released, *r)
+					changed = true
+				}
+			}
+			if !changed {
+				return errUnchanged
+			}
+			ledger.rewind()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return released, nil
+}
+
+// rewind moves Next back over the released reservations ending the ledger.
+func (l *Ledger) rewind() {
+	for i := len(l.Reservations) - 1; i >= 0; i-- {
+		r := l.Reservations[i]
+		if r.Status != StatusReleased || r.First+r.Count != l.Next {
+			return
+		}
+		l.Next = r.First
+	}
+}
+
+// Seed moves the next number of sequence to next, e.g. to continue the
+// numbers already used in NetSuite. It never moves back.
+func (a *Allocator) Seed(ctx context.Context, sequence string, next int) (*Ledger, error) {
+	return a.update(ctx, sequence, func(ledger *Ledger) error {
+		if next < ledger.Next {
+			return errors.New(fmt.Sprintf("Sequence %s is already at %d, it can't go back to %d", sequence, ledger.Next, next))
+		}
+		ledger.Next = next
+		return nil
+	})
+}
+
+// Ledger returns the state of sequence.
+func (a *Allocator) Ledger(ctx context.Context, sequence string) (*Ledger, error) {
+	ledger, _, err := a.store.Load(ctx, sequence)
+	return ledger, err
+}
+
+// update applies change to the latest ledger of sequence and saves it, again
+// on a fresh copy when another run saved it in the meantime.
+func (a *Allocator) update(ctx context.Context, sequence string, change func(ledger *Ledger) error) (*Ledger, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		ledger, version, err := a.store.Load(ctx, sequence)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := change(ledger); errors.Is(err, errUnchanged) {
+			return ledger, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		err = a.store.Save(ctx, ledger, version)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ledger, nil
+	}
+	return nil, errors.New(fmt.Sprintf("Failed to update sequence %s: too many concurrent updates", sequence))
+}
+
+// NewRunID names a calculation run that didn't get a name from its caller.
+func NewRunID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.New(fmt.Sprintf("Failed to generate a run ID: %v", err))
+	}
+	return "run-" + hex.EncodeToString(id), nil
+}
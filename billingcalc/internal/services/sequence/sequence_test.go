@@ -0,0 +1,174 @@
+//go:build !selectTest || unitTest
+
+package sequence_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/sequence"
+)
+
+func newAllocator(t *testing.T) (*sequence.Allocator, *sequence.FileStore) {
+	store, err := sequence.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sequence.NewAllocator(store), store
+}
+
+func TestReserve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should reserve consecutive ranges per sequence", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+
+		first, err := allocator.Reserve(ctx, "ADB", "run-1", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first)
+
+		first, err = allocator.Reserve(ctx, "ADB", "run-2", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, first)
+
+		first, err = allocator.Reserve(ctx, "ABS", "run-2", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first)
+
+		ledger, err := allocator.Ledger(ctx, "ADB")
+		assert.NoError(t, err)
+		assert.Equal(t, 6, ledger.Next)
+		assert.Len(t, ledger.Reservations, 2)
+	})
+
+	t.Run("Should give a run calculated again the numbers it holds", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+
+		_, _ = allocator.Reserve(ctx, "ADB", "run-1", 3)
+		first, err := allocator.Reserve(ctx, "ADB", "run-1", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, first)
+
+		_, err = allocator.Reserve(ctx, "ADB", "run-1", 4)
+		assert.EqualError(t, err, "Run run-1 already reserved 3 numbers of ADB and now needs 4, release it first")
+	})
+
+	t.Run("Should never give the same number to concurrent runs", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		firsts := []int{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				first, err := allocator.Reserve(ctx, "ADB", fmt.Sprintf("run-%d", i), 2)
+				assert.NoError(t, err)
+				mu.Lock()
+				firsts = append(firsts, first)
+				mu.Unlock()
+			}(i)
+		}
+		wg.Wait()
+
+		sort.Ints(firsts)
+		assert.Equal(t, []int{1, 3, 5, 7, 9, 11, 13, 15}, firsts)
+	})
+
+	t.Run("Should require a run ID", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+
+		_, err := allocator.Reserve(ctx, "ADB", "", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestRelease(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should give the numbers ending a sequence again", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-1", 3)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-2", 2)
+		_, _ = allocator.Reserve(ctx, sequence.ExternalIDSequence, "run-2", 2)
+
+		released, err := allocator.Release(ctx, "run-2")
+		assert.NoError(t, err)
+		assert.Len(t, released, 2)
+
+		first, err := allocator.Reserve(ctx, "ADB", "run-3", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, first)
+	})
+
+	t.Run("Should keep unused the numbers followed by other runs", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-1", 3)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-2", 2)
+
+		_, err := allocator.Release(ctx, "run-1")
+		assert.NoError(t, err)
+
+		first, err := allocator.Reserve(ctx, "ADB", "run-3", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, first)
+
+		ledger, _ := allocator.Ledger(ctx, "ADB")
+		assert.Equal(t, sequence.StatusReleased, ledger.Reservations[0].Status)
+		assert.NotNil(t, ledger.Reservations[0].ReleasedAt)
+	})
+
+	t.Run("Should reserve new numbers for a released run", func(t *testing.T) {
+		allocator, _ := newAllocator(t)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-1", 3)
+		_, _ = allocator.Reserve(ctx, "ADB", "run-2", 1)
+		_, _ = allocator.Release(ctx, "run-1")
+
+		first, err := allocator.Reserve(ctx, "ADB", "run-1", 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, first)
+	})
+}
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	allocator, _ := newAllocator(t)
+
+	_, err := allocator.Seed(ctx, "ADB", 1200)
+	assert.NoError(t, err)
+	first, _ := allocator.Reserve(ctx, "ADB", "run-1", 1)
+	assert.Equal(t, 1200, first)
+
+	_, err = allocator.Seed(ctx, "ADB", 10)
+	assert.EqualError(t, err, "Sequence ADB is already at 1201, it can't go back to 10")
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	_, store := newAllocator(t)
+
+	t.Run("Should refuse a ledger saved from an old version", func(t *testing.T) {
+		ledger, version, err := store.Load(ctx, "ADB")
+		assert.NoError(t, err)
+		assert.NoError(t, store.Save(ctx, ledger, version))
+
+		assert.ErrorIs(t, store.Save(ctx, ledger, version), sequence.ErrConflict)
+	})
+
+	t.Run("Should refuse names outside its directory", func(t *testing.T) {
+		_, _, err := store.Load(ctx, "../ADB")
+		assert.Error(t, err)
+	})
+
+	t.Run("Should list the saved sequences", func(t *testing.T) {
+		sequences, err := store.List(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ADB"}, sequences)
+	})
+}
@@ -0,0 +1,35 @@
+// package statictest edits the sample reports of package static for the
+// tests.
+package statictest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+// EditedCsv reads the sample report name and returns it once edit changed
+// its rows. A nil edit returns the report as it is.
+func EditedCsv(t testing.TB, name string, edit func(rows [][]string)) []byte {
+	t.Helper()
+
+	file, err := static.Files.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edit != nil {
+		edit(rows)
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(rows); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
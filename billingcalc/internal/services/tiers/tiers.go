@@ -0,0 +1,188 @@
+// package tiers applies the custody fee tiers of an MFR asset type to an AUC
+// balance, band by band.
+package tiers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Mode is how the tier floors of a schedule split the balance.
+type Mode string
+
+const (
+	// Graduated floors are cumulative thresholds: each tier charges the part
+	// of the balance between its floor and the next one.
+	Graduated Mode = "graduated"
+	// BandWidth floors are the widths of consecutive bands, the last tier
+	// charges whatever is left.
+	BandWidth Mode = "band_width"
+	// Flat charges the whole balance at the rate of the highest tier whose
+	// floor it reaches, the first tier below the first floor.
+	Flat Mode = "flat"
+)
+
+var monthsInYear = decimal.NewFromInt(12)
+
+// ParseMode reads the MFR "Graduated tier?" column: "Graduated" is
+// Graduated, "Flat" or "No" is Flat. Anything else, a blank cell included, is
+// BandWidth, the way the custody fees have always split the tiers.
+func ParseMode(value string) Mode {
+	switch strings.Join(strings.Fields(strings.ToUpper(value)), " ") {
+	case "GRADUATED":
+		return Graduated
+	case "FLAT", "NO":
+		return Flat
+	default:
+		return BandWidth
+	}
+}
+
+// Tier is a floor in USD and its annual rate.
+type Tier struct {
+	Floor decimal.Decimal `json:"floor"`
+	Rate  decimal.Decimal `json:"rate"`
+}
+
+// Band is the part of a balance charged at one tier. Ceiling is nil for the
+// last band, which has no upper bound.
+type Band struct {
+	Tier        int              `json:"tier"`
+	Floor       decimal.Decimal  `json:"floor"`
+	Ceiling     *decimal.Decimal `json:"ceiling,omitempty"`
+	Rate        decimal.Decimal  `json:"rate"`
+	MonthlyRate decimal.Decimal  `json:"monthlyRate"`
+	Balance     decimal.Decimal  `json:"balance"`
+	Amount      decimal.Decimal  `json:"amount"`
+}
+
+// Result is the monthly fee of a balance and the bands it was charged in.
+type Result struct {
+	Mode       Mode            `json:"mode"`
+	Balance    decimal.Decimal `json:"balance"`
+	Bands      []Band          `json:"bands"`
+	MonthlyFee decimal.Decimal `json:"monthlyFee"`
+}
+
+// Schedule is the tiers of an asset type, the unused ones (floor and rate at
+// 0) left out.
+type Schedule struct {
+	Mode  Mode
+	Tiers []Tier
+}
+
+// NewSchedule checks the tiers of a schedule. Graduated and flat floors must
+// be increasing, band widths must be positive except for the first band.
+func NewSchedule(mode Mode, tiers []Tier) (Schedule, error) {
+	used := make([]Tier, 0, len(tiers))
+	for _, tier := range tiers {
+		if tier.Floor.IsZero() && tier.Rate.IsZero() {
+			continue
+		}
+		used = append(used, tier)
+	}
+
+	for i, tier := range used {
+		if tier.Floor.IsNegative() || tier.Rate.IsNegative() {
+			return Schedule{}, errors.New(fmt.Sprintf("Tier %d has a negative floor or rate", i+1))
+		}
+		if mode != BandWidth && i > 0 && !tier.Floor.GreaterThan(used[i-1].Floor) {
+			return Schedule{}, errors.New(fmt.Sprintf("Tier %d floor %s is not greater than the previous floor %s", i+1, tier.Floor, used[i-1].Floor))
+		}
+	}
+
+	return Schedule{Mode: mode, Tiers: used}, nil
+}
+
+// Apply charges balance band by band. A balance below the first floor of a
+// graduated schedule is not charged.
+func (s Schedule) Apply(balance decimal.Decimal) Result {
+	result := Result{Mode: s.Mode, Balance: balance, Bands: []Band{}}
+	switch s.Mode {
+	case Graduated:
+		result.Bands = s.graduated(balance)
+	case BandWidth:
+		result.Bands = s.bandWidth(balance)
+	default:
+		result.Bands = s.flat(balance)
+	}
+
+	for _, band := range result.Bands {
+		result.MonthlyFee = result.MonthlyFee.Add(band.Amount)
+	}
+	return result
+}
+
+func (s Schedule) graduated(balance decimal.Decimal) []Band {
+	var bands []Band
+	for i, tier := range s.Tiers {
+		if balance.LessThanOrEqual(tier.Floor) {
+			break
+		}
+		band := newBand(i, tier)
+		charged := balance.Sub(tier.Floor)
+		if i+1 < len(s.Tiers) {
+			ceiling := s.Tiers[i+1].Floor
+			band.Ceiling = &ceiling
+			charged = decimal.Min(charged, ceiling.Sub(tier.Floor))
+		}
+		bands = append(bands, band.charge(charged))
+	}
+	return bands
+}
+
+func (s Schedule) bandWidth(balance decimal.Decimal) []Band {
+	var bands []Band
+	start := decimal.Zero
+	remaining := balance
+	for i, tier := range s.Tiers {
+		if !remaining.IsPositive() {
+			break
+		}
+		band := newBand(i, tier)
+		band.Floor = start
+		charged := remaining
+		if i+1 < len(s.Tiers) {
+			ceiling := start.Add(tier.Floor)
+			band.Ceiling = &ceiling
+			charged = decimal.Min(remaining, tier.Floor)
+		}
+		bands = append(bands, band.charge(charged))
+		remaining = remaining.Sub(charged)
+		start = start.Add(tier.Floor)
+	}
+	return bands
+}
+
+func (s Schedule) flat(balance decimal.Decimal) []Band {
+	if len(s.Tiers) == 0 {
+		return nil
+	}
+	reached := 0
+	for i, tier := range s.Tiers {
+		if balance.GreaterThanOrEqual(tier.Floor) {
+			reached = i
+		}
+	}
+	return []Band{newBand(reached, s.Tiers[reached]).charge(balance)}
+}
+
+func newBand(i int, tier Tier) Band {
+	return Band{
+		Tier:        i + 1,
+		Floor:       tier.Floor,
+		Rate:        tier.Rate,
+		MonthlyRate: tier.Rate.DivRound(monthsInYear, 16),
+	}
+}
+
+// charge bills balance at the monthly rate of the band, the way the custody
+// fees always have: balance x rate / 12.
+func (b Band) charge(balance decimal.Decimal) Band {
+	b.Balance = balance
+	b.Amount = balance.Mul(b.MonthlyRate)
+	return b
+}
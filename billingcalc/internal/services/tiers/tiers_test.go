@@ -0,0 +1,117 @@
+//go:build !selectTest || unitTest
+
+package tiers_test
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/tiers"
+)
+
+func schedule(t *testing.T, mode tiers.Mode, floorsAndRates ...int64) tiers.Schedule {
+	var tierList []tiers.Tier
+	for i := 0; i < len(floorsAndRates); i += 2 {
+		tierList = append(tierList, tiers.Tier{
+			Floor: decimal.NewFromInt(floorsAndRates[i]),
+			Rate:  decimal.NewFromInt(floorsAndRates[i+1]),
+		})
+	}
+	s, err := tiers.NewSchedule(mode, tierList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func amounts(result tiers.Result) []string {
+	out := []string{}
+	for _, band := range result.Bands {
+		out = append(out, band.Amount.StringFixed(2))
+	}
+	return out
+}
+
+func TestParseMode(t *testing.T) {
+	assert.Equal(t, tiers.Graduated, tiers.ParseMode(" graduated "))
+	assert.Equal(t, tiers.Flat, tiers.ParseMode("Flat"))
+	assert.Equal(t, tiers.Flat, tiers.ParseMode("No"))
+	assert.Equal(t, tiers.BandWidth, tiers.ParseMode("Band  width"))
+	assert.Equal(t, tiers.BandWidth, tiers.ParseMode(""))
+}
+
+func TestNewSchedule(t *testing.T) {
+	t.Run("Should leave out the unused tiers", func(t *testing.T) {
+		s := schedule(t, tiers.Graduated, 0, 12, 1000, 6, 0, 0)
+		assert.Len(t, s.Tiers, 2)
+	})
+
+	t.Run("Should reject floors that don't increase", func(t *testing.T) {
+		_, err := tiers.NewSchedule(tiers.Graduated, []tiers.Tier{
+			{Floor: decimal.NewFromInt(1000), Rate: decimal.NewFromInt(12)},
+			{Floor: decimal.NewFromInt(500), Rate: decimal.NewFromInt(6)},
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("Should accept band widths in any order", func(t *testing.T) {
+		_, err := tiers.NewSchedule(tiers.BandWidth, []tiers.Tier{
+			{Floor: decimal.NewFromInt(1000), Rate: decimal.NewFromInt(12)},
+			{Floor: decimal.NewFromInt(500), Rate: decimal.NewFromInt(6)},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should reject negative rates", func(t *testing.T) {
+		_, err := tiers.NewSchedule(tiers.Flat, []tiers.Tier{{Floor: decimal.Zero, Rate: decimal.NewFromInt(-1)}})
+		assert.Error(t, err)
+	})
+}
+
+func TestApply(t *testing.T) {
+	balance := decimal.NewFromInt(2500)
+
+	t.Run("Should charge graduated tiers between consecutive floors", func(t *testing.T) {
+		result := schedule(t, tiers.Graduated, 0, 12, 1000, 6, 2000, 3).Apply(balance)
+
+		assert.Equal(t, []string{"1000.00", "500.00", "125.00"}, amounts(result))
+		assert.Equal(t, "1625.00", result.MonthlyFee.StringFixed(2))
+		assert.Equal(t, "1000", result.Bands[0].Ceiling.String())
+		assert.Nil(t, result.Bands[2].Ceiling)
+	})
+
+	t.Run("Should not charge a graduated balance below the first floor", func(t *testing.T) {
+		result := schedule(t, tiers.Graduated, 1000, 12).Apply(decimal.NewFromInt(500))
+
+		assert.Empty(t, result.Bands)
+		assert.True(t, result.MonthlyFee.IsZero())
+	})
+
+	t.Run("Should charge band widths and the remainder in the last tier", func(t *testing.T) {
+		result := schedule(t, tiers.BandWidth, 1000, 12, 1000, 6, 1, 3).Apply(balance)
+
+		assert.Equal(t, []string{"1000.00", "500.00", "125.00"}, amounts(result))
+		assert.Equal(t, "2000", result.Bands[2].Floor.String())
+		assert.Equal(t, "500", result.Bands[2].Balance.String())
+	})
+
+	t.Run("Should charge the whole balance at the flat tier reached", func(t *testing.T) {
+		result := schedule(t, tiers.Flat, 0, 12, 1000, 6, 3000, 3).Apply(balance)
+
+		if assert.Len(t, result.Bands, 1) {
+			assert.Equal(t, 2, result.Bands[0].Tier)
+		}
+		assert.Equal(t, "1250.00", result.MonthlyFee.StringFixed(2))
+	})
+
+	t.Run("Should charge a flat balance below the first floor at the first tier", func(t *testing.T) {
+		result := schedule(t, tiers.Flat, 1000, 12, 3000, 6).Apply(decimal.NewFromInt(500))
+
+		if assert.Len(t, result.Bands, 1) {
+			assert.Equal(t, 1, result.Bands[0].Tier)
+		}
+		assert.Equal(t, "500.00", result.MonthlyFee.StringFixed(2))
+	})
+}
@@ -0,0 +1,195 @@
+// package validatorfees is the registry of the staking fees negotiated per
+// validator, keyed by the validator ID or address of the operations statuses.
+package validatorfees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/static"
+)
+
+// DefaultFile is the registry embedded in the binary, used when the server
+// has no managed validator fees file.
+const DefaultFile = "validator_fees.json"
+
+// Where a resolved fee comes from, the MFR when neither level sets it.
+const (
+	SourceAccount   = "account override"
+	SourceValidator = "validator"
+)
+
+// Rates are the staking fees of one asset, in percent like the MFR. A rate
+// left out falls back to the next level.
+type Rates struct {
+	Rewards       *decimal.Decimal `json:"rewards,omitempty"`
+	StakedBalance *decimal.Decimal `json:"stakedBalance,omitempty"`
+}
+
+type Validator struct {
+	ValidatorID string `json:"validatorId"`
+	Address     string `json:"address"`
+	Name        string `json:"name"`
+	// Fees are the rates of the validator by asset.
+	Fees map[string]Rates `json:"fees"`
+	// Accounts override Fees for some accounts, by RDB account ID then asset.
+	Accounts map[string]map[string]Rates `json:"accounts,omitempty"`
+}
+
+// Label names the validator on the staking lines.
+func (v Validator) Label() string {
+	switch {
+	case v.Name != "":
+		return v.Name
+	case v.ValidatorID != "":
+		return v.ValidatorID
+	}
+	return v.Address
+}
+
+type Registry struct {
+	Version    string      `json:"version"`
+	Validators []Validator `json:"validators"`
+
+	byId      map[string]Validator
+	byAddress map[string]Validator
+}
+
+// Resolved are the fees of a validator for an account and asset. A source
+// is empty when the fee is not set for the validator and the MFR one applies.
+type Resolved struct {
+	Validator           Validator
+	Rewards             decimal.Decimal
+	RewardsSource       string
+	StakedBalance       decimal.Decimal
+	StakedBalanceSource string
+}
+
+// Parse reads and checks a registry file, every problem found is reported.
+func Parse(data []byte) (*Registry, error) {
+	registry := &Registry{}
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, errors.New(fmt.Sprintf("Invalid validator fees file: %v", err))
+	}
+
+	var problems []string
+	if registry.Version == "" {
+		problems = append(problems, "version is required")
+	}
+
+	registry.byId = make(map[string]Validator)
+	registry.byAddress = make(map[string]Validator)
+	for i, validator := range registry.Validators {
+		name := fmt.Sprintf("validator %d", i+1)
+		if label := validator.Label(); label != "" {
+			name = "validator " + label
+		}
+
+		if validator.ValidatorID == "" && validator.Address == "" {
+			problems = append(problems, name+": validatorId or address is required")
+		}
+		if _, exists := registry.byId[validator.ValidatorID]; exists && validator.ValidatorID != "" {
+			problems = append(problems, name+": validatorId is used more than once")
+		}
+		if _, exists := registry.byAddress[validator.Address]; exists && validator.Address != "" {
+			problems = append(problems, name+": address is used more than once")
+		}
+		problems = append(problems, checkRates(name, validator.Fees)...)
+		for _, accountID := range sortedKeys(validator.Accounts) {
+			if accountID == "" {
+				problems = append(problems, name+": account IDs cannot be empty")
+			}
+			problems = append(problems, checkRates(fmt.Sprintf("%s account %s", name, accountID), validator.Accounts[accountID])...)
+		}
+
+		if validator.ValidatorID != "" {
+			registry.byId[validator.ValidatorID] = validator
+		}
+		if validator.Address != "" {
+			registry.byAddress[validator.Address] = validator
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New("Invalid validator fees file: " + strings.Join(problems, "; "))
+	}
+	return registry, nil
+}
+
+func checkRates(name string, fees map[string]Rates) []string {
+	var problems []string
+	for _, asset := range sortedKeys(fees) {
+		if asset == "" {
+			problems = append(problems, name+": asset names cannot be empty")
+		}
+		rates := fees[asset]
+		for _, rate := range []struct {
+			name  string
+			value *decimal.Decimal
+		}{{"rewards", rates.Rewards}, {"stakedBalance", rates.StakedBalance}} {
+			if rate.value != nil && (rate.value.IsNegative() || rate.value.GreaterThan(decimal.NewFromInt(100))) {
+				problems = append(problems, fmt.Sprintf("%s: %s %s fee %s is not between 0 and 100", name, asset, rate.name, rate.value))
+			}
+		}
+	}
+	return problems
+}
+
+// Default returns the registry embedded in the binary.
+func Default() (*Registry, error) {
+	data, err := static.Files.ReadFile(DefaultFile)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error reading file %s: %v", DefaultFile, err))
+	}
+	return Parse(data)
+}
+
+// Find returns the validator registered with the ID, or else the address.
+func (r *Registry) Find(validatorID string, address string) (Validator, bool) {
+	if validator, ok := r.byId[validatorID]; ok && validatorID != "" {
+		return validator, true
+	}
+	validator, ok := r.byAddress[address]
+	return validator, ok && address != ""
+}
+
+// Resolve returns the fees of a validator for an account and asset: the
+// account override first, then the rate of the validator. ok is false for a
+// validator that is not registered.
+func (r *Registry) Resolve(validatorID string, address string, accountID string, asset string) (Resolved, bool) {
+	validator, ok := r.Find(validatorID, address)
+	if !ok {
+		return Resolved{}, false
+	}
+
+	resolved := Resolved{Validator: validator}
+	for _, level := range []struct {
+		rates  Rates
+		source string
+	}{
+		{validator.Accounts[accountID][asset], SourceAccount},
+		{validator.Fees[asset], SourceValidator},
+	} {
+		if level.rates.Rewards != nil && resolved.RewardsSource == "" {
+			resolved.Rewards, resolved.RewardsSource = *level.rates.Rewards, level.source
+		}
+		if level.rates.StakedBalance != nil && resolved.StakedBalanceSource == "" {
+			resolved.StakedBalance, resolved.StakedBalanceSource = *level.rates.StakedBalance, level.source
+		}
+	}
+	return resolved, true
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+//go:build !selectTest || unitTest
+
+package validatorfees_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/services/validatorfees"
+)
+
+const registry = `{"version": "2", "validators": [
+	{"validatorId": "val-1", "address": "pbvaloper1abc", "name": "Partner", "fees": {"HASH": {"rewards": 8}}, "accounts": {"acc-1": {"HASH": {"rewards": 6}}}},
+	{"address": "osmovaloper1xyz", "fees": {"OSMO": {"rewards": 10, "stakedBalance": 0.5}}}
+]}`
+
+func TestDefault(t *testing.T) {
+	r, err := validatorfees.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, r.Version)
+}
+
+func TestResolve(t *testing.T) {
+	r, err := validatorfees.Parse([]byte(registry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Should prefer the account override", func(t *testing.T) {
+		resolved, ok := r.Resolve("val-1", "", "acc-1", "HASH")
+
+		assert.True(t, ok)
+		assert.Equal(t, "Partner", resolved.Validator.Label())
+		assert.Equal(t, "6", resolved.Rewards.String())
+		assert.Equal(t, validatorfees.SourceAccount, resolved.RewardsSource)
+		assert.Equal(t, "", resolved.StakedBalanceSource)
+	})
+
+	t.Run("Should use the validator rate for other accounts", func(t *testing.T) {
+		resolved, _ := r.Resolve("", "pbvaloper1abc", "acc-2", "HASH")
+
+		assert.Equal(t, "8", resolved.Rewards.String())
+		assert.Equal(t, validatorfees.SourceValidator, resolved.RewardsSource)
+	})
+
+	t.Run("Should find a validator by address", func(t *testing.T) {
+		resolved, ok := r.Resolve("unknown", "osmovaloper1xyz", "acc-1", "OSMO")
+
+		assert.True(t, ok)
+		assert.Equal(t, "osmovaloper1xyz", resolved.Validator.Label())
+		assert.Equal(t, "0.5", resolved.StakedBalance.String())
+	})
+
+	t.Run("Should leave the rates of other assets to the MFR", func(t *testing.T) {
+		resolved, ok := r.Resolve("val-1", "", "acc-1", "OSMO")
+
+		assert.True(t, ok)
+		assert.Equal(t, "", resolved.RewardsSource)
+	})
+
+	t.Run("Should not find an unregistered validator", func(t *testing.T) {
+		_, ok := r.Resolve("", "", "acc-1", "HASH")
+		assert.False(t, ok)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Should report every invalid validator", func(t *testing.T) {
+		_, err := validatorfees.Parse([]byte(`{"validators": [
+			{"validatorId": "val-1", "fees": {"HASH": {"rewards": 101}}},
+			{"validatorId": "val-1", "accounts": {"acc-1": {"HASH": {"stakedBalance": -1}}}},
+			{"name": "Nameless"}
+		]}`))
+
+		assert.EqualError(t, err, "Invalid validator fees file: version is required; "+
+			"validator val-1: HASH rewards fee 101 is not between 0 and 100; "+
+			"validator val-1: validatorId is used more than once; "+
+			"validator val-1 account acc-1: HASH stakedBalance fee -1 is not between 0 and 100; "+
+			"validator Nameless: validatorId or address is required")
+	})
+
+	t.Run("Should reject a file that is not JSON", func(t *testing.T) {
+		_, err := validatorfees.Parse([]byte(`[`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package date
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the order a report writes the day and month of slash dates in.
+// ISO dates and datetimes, with or without a timezone, and spreadsheet serial
+// day numbers are read whatever the format.
+type Format int
+
+const (
+	MonthFirst Format = iota // 06/30/2023, the Sheets and Looker US exports
+	DayFirst                 // 30/06/2023
+	ISOOnly                  // slash dates are rejected
+)
+
+func (f Format) String() string {
+	switch f {
+	case DayFirst:
+		return "YYYY-MM-DD or DD/MM/YYYY"
+	case ISOOnly:
+		return "YYYY-MM-DD"
+	default:
+		return "YYYY-MM-DD or MM/DD/YYYY"
+	}
+}
+
+var isoLayouts = []string{
+	"2006-1-2",
+	"2006-1-2T15:04:05Z07:00",
+	"2006-1-2T15:04:05",
+	"2006-1-2 15:04:05Z07:00",
+	"2006-1-2 15:04:05 -0700 MST",
+	"2006-1-2 15:04:05 -0700",
+	"2006-1-2 15:04:05 MST",
+	"2006-1-2 15:04:05",
+	"2006-1-2 15:04",
+}
+
+var slashTimes = []string{"", " 15:04:05", " 15:04", " 3:04:05 PM", " 3:04 PM"}
+
+var serialDay = regexp.MustCompile(`^\d{1,5}(\.\d+)?$`)
+
+// Sheets and Excel count serial days from 1899-12-30.
+var serialEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// Parse reads the day of value, at midnight UTC. The day is the one written,
+// a datetime with a timezone is not moved to UTC first.
+func Parse(value string, format Format) (time.Time, error) {
+	v := strings.TrimSpace(value)
+	if v == "" {
+		return time.Time{}, errors.New("missing date")
+	}
+
+	if serialDay.MatchString(v) {
+		days, _ := strconv.ParseFloat(v, 64)
+		return serialEpoch.AddDate(0, 0, int(days)), nil
+	}
+
+	layouts := isoLayouts
+	if strings.Contains(v, "/") {
+		layouts = slashLayouts(format)
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return day(t), nil
+		}
+	}
+
+	return time.Time{}, errors.New("not a date, expected " + format.String())
+}
+
+func slashLayouts(format Format) []string {
+	var date string
+	switch format {
+	case MonthFirst:
+		date = "1/2/2006"
+	case DayFirst:
+		date = "2/1/2006"
+	default:
+		return nil
+	}
+
+	layouts := make([]string, 0, len(slashTimes))
+	for _, t := range slashTimes {
+		layouts = append(layouts, date+t)
+	}
+	return layouts
+}
+
+func day(t time.Time) time.Time {
+	year, month, d := t.Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,72 @@
+//go:build !selectTest || unitTest
+
+package date_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/date"
+)
+
+func TestParse(t *testing.T) {
+	june23 := time.Date(2023, time.June, 23, 0, 0, 0, 0, time.UTC)
+	june1 := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		format   date.Format
+		expected time.Time
+	}{
+		{"Date_Without_Time", "2023-06-23", date.MonthFirst, june23},
+		{"Date_With_Time", "2023-06-23 10:30:10", date.MonthFirst, june23},
+		{"Date_With_One_Digit", "2023-6-1", date.MonthFirst, june1},
+		{"ISO_Datetime", "2023-06-23T10:30:10Z", date.MonthFirst, june23},
+		{"ISO_Datetime_Fraction", "2023-06-23T10:30:10.123Z", date.ISOOnly, june23},
+		{"Timezone_Keeps_The_Day", "2023-06-23T23:30:00-03:00", date.MonthFirst, june23},
+		{"Go_Time_String", "2023-06-23 23:56:39 +0000 UTC", date.MonthFirst, june23},
+		{"American_Date", "06/23/2023", date.MonthFirst, june23},
+		{"American_Date_With_One_Digit", "6/1/2023", date.MonthFirst, june1},
+		{"American_Datetime", "6/23/2023 3:04 PM", date.MonthFirst, june23},
+		{"Day_First_Date", "1/6/2023", date.DayFirst, june1},
+		{"Serial_Day", "45100", date.MonthFirst, june23},
+		{"Serial_Datetime", "45100.75", date.MonthFirst, june23},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := date.Parse(tt.value, tt.format)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		format date.Format
+		reason string
+	}{
+		{"Empty", " ", date.MonthFirst, "missing date"},
+		{"Text", "yesterday", date.MonthFirst, "not a date, expected YYYY-MM-DD or MM/DD/YYYY"},
+		{"Day_First_As_Month_First", "23/06/2023", date.MonthFirst, "not a date, expected YYYY-MM-DD or MM/DD/YYYY"},
+		{"Slash_Date_When_ISO_Only", "06/23/2023", date.ISOOnly, "not a date, expected YYYY-MM-DD"},
+		{"Missing_Parts", "2023-06", date.MonthFirst, "not a date, expected YYYY-MM-DD or MM/DD/YYYY"},
+		{"Lone_Slash", "/", date.MonthFirst, "not a date, expected YYYY-MM-DD or MM/DD/YYYY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := date.Parse(tt.value, tt.format)
+
+			assert.EqualError(t, err, tt.reason)
+			assert.True(t, actual.IsZero())
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package debug
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	stdlog "log"
+	"sync"
 	"time"
 )
 
@@ -13,32 +15,74 @@ type log struct {
 	Time    string `json:"time"`
 }
 
-var (
-	database = []log{}
-	debug    = false
-)
+type traceKey struct{}
 
-func Init(isActive bool) {
-	debug = isActive
-	database = nil
+// Trace collects the debug messages of a single request. It is carried in the
+// request context so concurrent requests never share their messages, and it
+// is safe to use from the goroutines of the same request.
+type Trace struct {
+	mu       sync.Mutex
+	active   bool
+	messages []log
 }
 
-func NewMessage(message string) {
+func NewTrace(isActive bool) *Trace {
+	return &Trace{active: isActive, messages: []log{}}
+}
+
+// NewContext returns a copy of ctx carrying the trace.
+func NewContext(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// FromContext returns the trace carried by ctx. Without one, an inactive
+// trace is returned so the messages are only logged.
+func FromContext(ctx context.Context) *Trace {
+	if trace, ok := ctx.Value(traceKey{}).(*Trace); ok && trace != nil {
+		return trace
+	}
+	return NewTrace(false)
+}
+
+func (t *Trace) NewMessage(message string) {
 	stdlog.Println(message)
-	if debug {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.active {
 		now := time.Now() //nolint:forbidigo
-		database = append(database, log{Message: message, Time: now.Format(time.RFC3339)})
+		t.messages = append(t.messages, log{Message: message, Time: now.Format(time.RFC3339)})
 	}
 }
 
-func GetAllMessages() []log {
-	return database
+func (t *Trace) GetAllMessages() []log {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	messages := make([]log, len(t.messages))
+	copy(messages, t.messages)
+	return messages
 }
 
-func GetJson() ([]byte, error) {
-	json, err := json.Marshal(GetAllMessages())
+func (t *Trace) GetJson() ([]byte, error) {
+	json, err := json.Marshal(t.GetAllMessages())
 	if err != nil {
 		return nil, errors.New(fmt.Sprintf("Failed to encode the debug data: %v", err))
 	}
 	return json, nil
 }
+
+// NewMessageContext adds the message to the trace of the request in ctx.
+func NewMessageContext(ctx context.Context, message string) {
+	FromContext(ctx).NewMessage(message)
+}
+
+// NewMessage only logs the message, for code that runs outside of a request.
+func NewMessage(message string) {
+	stdlog.Println(message)
+}
+
+// GetAllMessages returns the messages collected for the request in ctx.
+func GetAllMessages(ctx context.Context) []log {
+	return FromContext(ctx).GetAllMessages()
+}
@@ -3,6 +3,9 @@
 package debug_test
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,31 +15,64 @@ import (
 
 func TestDebug(t *testing.T) {
 	t.Run("Should initialize without messages.", func(t *testing.T) {
-		debug.Init(true)
-		assert.Equal(t, len(debug.GetAllMessages()), 0)
+		ctx := debug.NewContext(context.Background(), debug.NewTrace(true))
+		assert.Equal(t, len(debug.GetAllMessages(ctx)), 0)
 	})
 
 	t.Run("Should create a new debug message.", func(t *testing.T) {
-		debug.Init(true)
+		ctx := debug.NewContext(context.Background(), debug.NewTrace(true))
 		newMessage := "Mock debug message"
-		debug.NewMessage(newMessage)
-		assert.Equal(t, len(debug.GetAllMessages()), 1)
-		assert.Equal(t, debug.GetAllMessages()[0].Message, newMessage)
+		debug.NewMessageContext(ctx, newMessage)
+		assert.Equal(t, len(debug.GetAllMessages(ctx)), 1)
+		assert.Equal(t, debug.GetAllMessages(ctx)[0].Message, newMessage)
 	})
 
 	t.Run("Should not create any message if Debug is false.", func(t *testing.T) {
-		debug.Init(false)
-		debug.NewMessage("Mock debug message")
-		assert.Equal(t, len(debug.GetAllMessages()), 0)
+		ctx := debug.NewContext(context.Background(), debug.NewTrace(false))
+		debug.NewMessageContext(ctx, "Mock debug message")
+		assert.Equal(t, len(debug.GetAllMessages(ctx)), 0)
+	})
+
+	t.Run("Should not create any message without a trace in the context.", func(t *testing.T) {
+		ctx := context.Background()
+		debug.NewMessageContext(ctx, "Mock debug message")
+		assert.Equal(t, len(debug.GetAllMessages(ctx)), 0)
 	})
 
 	t.Run("Should get Json.", func(t *testing.T) {
-		debug.Init(true)
-		debug.NewMessage("Mock debug message")
-		json, err := debug.GetJson()
+		trace := debug.NewTrace(true)
+		trace.NewMessage("Mock debug message")
+		json, err := trace.GetJson()
 
 		notEmpty := len(string(json)) > 0
 		assert.Equal(t, notEmpty, true)
 		assert.Nil(t, err)
 	})
+
+	t.Run("Should keep the messages of concurrent requests apart.", func(t *testing.T) {
+		var wg sync.WaitGroup
+		requests := make([]context.Context, 4)
+		for i := range requests {
+			requests[i] = debug.NewContext(context.Background(), debug.NewTrace(true))
+		}
+
+		for i, ctx := range requests {
+			for j := 0; j < 50; j++ {
+				wg.Add(1)
+				go func(i int, ctx context.Context) {
+					defer wg.Done()
+					debug.NewMessageContext(ctx, fmt.Sprintf("request %d", i))
+				}(i, ctx)
+			}
+		}
+		wg.Wait()
+
+		for i, ctx := range requests {
+			messages := debug.GetAllMessages(ctx)
+			assert.Equal(t, 50, len(messages))
+			for _, message := range messages {
+				assert.Equal(t, fmt.Sprintf("request %d", i), message.Message)
+			}
+		}
+	})
 }
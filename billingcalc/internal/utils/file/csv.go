@@ -0,0 +1,42 @@
+package file
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// ErrFileTooLarge is returned by a LimitReader once the file goes over its limit.
+var ErrFileTooLarge = errors.New("file too large")
+
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// LimitReader reads at most n bytes from r. Unlike io.LimitReader, going past
+// the limit fails with ErrFileTooLarge instead of silently truncating the file.
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitedReader{r: r, n: n}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// One extra byte is allowed through so a file of exactly n bytes can
+	// still reach io.EOF.
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return 0, ErrFileTooLarge
+	}
+
+	return n, err
+}
+
+// ReadCsv reads every row of a CSV stream, header rows included.
+func ReadCsv(r io.Reader) ([][]string, error) {
+	return csv.NewReader(r).ReadAll()
+}
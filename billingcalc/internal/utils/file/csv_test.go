@@ -0,0 +1,30 @@
+//go:build !selectTest || unitTest
+
+package file_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchorlabsinc/anchorage/source/go/service/billingcalc/internal/utils/file"
+)
+
+func TestReadCsv(t *testing.T) {
+	const data = "a,b\n1,2\n"
+
+	t.Run("Within the limit", func(t *testing.T) {
+		rows, err := file.ReadCsv(file.LimitReader(strings.NewReader(data), int64(len(data))))
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"a", "b"}, {"1", "2"}}, rows)
+	})
+
+	t.Run("Over the limit", func(t *testing.T) {
+		_, err := file.ReadCsv(file.LimitReader(strings.NewReader(data), int64(len(data)-1)))
+
+		assert.True(t, errors.Is(err, file.ErrFileTooLarge), "expected ErrFileTooLarge, got %v", err)
+	})
+}
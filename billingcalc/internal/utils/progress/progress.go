@@ -0,0 +1,42 @@
+package progress
+
+import "context"
+
+const (
+	StageLoading   = "Loading reports"
+	StageStaking   = "Calculating Staking Fees"
+	StageCustody   = "Calculating Custody Fees"
+	StageBrokerage = "Calculating Brokerage Fees"
+)
+
+// Progress tells which part of a calculation is running.
+type Progress struct {
+	Stage        string `json:"stage"`
+	Report       string `json:"report,omitempty"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// Reporter receives every progress update of a calculation.
+type Reporter func(update Progress)
+
+type reporterKey struct{}
+
+// NewContext returns a copy of ctx carrying the reporter.
+func NewContext(ctx context.Context, reporter Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, reporter)
+}
+
+// Report sends the update to the reporter in ctx, if any.
+func Report(ctx context.Context, update Progress) {
+	if reporter, ok := ctx.Value(reporterKey{}).(Reporter); ok && reporter != nil {
+		reporter(update)
+	}
+}
+
+func LoadingReport(ctx context.Context, reportName string) {
+	Report(ctx, Progress{Stage: StageLoading, Report: reportName})
+}
+
+func ProcessingOrganization(ctx context.Context, stage string, organizationName string) {
+	Report(ctx, Progress{Stage: stage, Organization: organizationName})
+}